@@ -0,0 +1,105 @@
+package commons
+
+import (
+	"encoding/hex"
+	"sync"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+// TestBeacon is a beacon node for scenarios, it serves deterministic attestation data and keeps submitted attestations
+type TestBeacon struct {
+	lock         sync.Mutex
+	attestations []*spec.Attestation
+	roots        []string
+}
+
+// NewTestBeacon creates a new beacon node for scenarios
+func NewTestBeacon() *TestBeacon {
+	return &TestBeacon{}
+}
+
+// SubmittedAttestations returns the attestations that were submitted to the beacon node
+func (b *TestBeacon) SubmittedAttestations() []*spec.Attestation {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	res := make([]*spec.Attestation, len(b.attestations))
+	copy(res, b.attestations)
+	return res
+}
+
+// BroadcastedRoots returns the roots (hex encoded) of the objects that were submitted to the beacon node
+func (b *TestBeacon) BroadcastedRoots() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	res := make([]string, len(b.roots))
+	copy(res, b.roots)
+	return res
+}
+
+// GetDuties impl
+func (b *TestBeacon) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*spectypes.Duty, error) {
+	return nil, nil
+}
+
+// GetValidatorData impl
+func (b *TestBeacon) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
+	return nil, nil
+}
+
+// GetAttestationData returns attestation data that is derived from the given slot and committee index,
+// therefore all the operators get the same data for a duty
+func (b *TestBeacon) GetAttestationData(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.AttestationData, error) {
+	epoch := spec.Epoch(uint64(slot) / 32)
+	return &spec.AttestationData{
+		Slot:            slot,
+		Index:           committeeIndex,
+		BeaconBlockRoot: spec.Root{byte(slot), byte(committeeIndex)},
+		Source:          &spec.Checkpoint{Epoch: epoch},
+		Target:          &spec.Checkpoint{Epoch: epoch + 1},
+	}, nil
+}
+
+// SubmitAttestation keeps the given attestation and its root
+func (b *TestBeacon) SubmitAttestation(attestation *spec.Attestation) error {
+	root, err := attestation.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute attestation root")
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.attestations = append(b.attestations, attestation)
+	b.roots = append(b.roots, hex.EncodeToString(root[:]))
+	return nil
+}
+
+// SubscribeToCommitteeSubnet impl
+func (b *TestBeacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error {
+	return nil
+}
+
+// SubmitProposalPreparation impl
+func (b *TestBeacon) SubmitProposalPreparation(preparations []*api.ProposalPreparation) error {
+	return nil
+}
+
+// GetDomain impl
+func (b *TestBeacon) GetDomain(data *spec.AttestationData) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+// ComputeSigningRoot impl
+func (b *TestBeacon) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
+	return [32]byte{}, errors.New("not implemented")
+}
+
+var _ beaconprotocol.Beacon = (*TestBeacon)(nil)
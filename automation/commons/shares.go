@@ -21,7 +21,7 @@ import (
 )
 
 // CreateShareAndValidators creates a share and the corresponding validators objects
-func CreateShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv1.LocalNet, kms []spectypes.KeyManager, stores []qbftstorage.QBFTStore) (*beacon.Share, map[uint64]*bls.SecretKey, []validator.IValidator, error) {
+func CreateShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv1.LocalNet, kms []spectypes.KeyManager, beacons []*TestBeacon, stores []qbftstorage.QBFTStore) (*beacon.Share, map[uint64]*bls.SecretKey, []validator.IValidator, error) {
 	validators := make([]validator.IValidator, 0)
 	operators := make([][]byte, 0)
 	for _, k := range net.NodeKeys {
@@ -57,7 +57,8 @@ func CreateShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv
 				Operators:    share.Operators,
 			},
 			ForkVersion:                forksprotocol.GenesisForkVersion, // TODO need to check v1 too?
-			Beacon:                     nil,
+			Beacon:                     beacons[i],
+			KeyManager:                 km,
 			DutyRoles:                  []spectypes.BeaconRole{spectypes.BNRoleAttester}, // TODO when implemented, need to add more types
 			SyncRateLimit:              time.Millisecond * 10,
 			SignatureCollectionTimeout: time.Second * 5,
@@ -69,6 +70,24 @@ func CreateShareAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv
 	return share, sks, validators, nil
 }
 
+// CreateSharesAndValidators creates the given amount of shares (validators) that are shared by all the nodes,
+// the validators of each share are returned in the order of the nodes
+func CreateSharesAndValidators(ctx context.Context, logger *zap.Logger, net *p2pv1.LocalNet, kms []spectypes.KeyManager, beacons []*TestBeacon, stores []qbftstorage.QBFTStore, count int) ([]*beacon.Share, []map[uint64]*bls.SecretKey, [][]validator.IValidator, error) {
+	shares := make([]*beacon.Share, 0, count)
+	sks := make([]map[uint64]*bls.SecretKey, 0, count)
+	validators := make([][]validator.IValidator, 0, count)
+	for i := 0; i < count; i++ {
+		share, shareSks, shareValidators, err := CreateShareAndValidators(ctx, logger, net, kms, beacons, stores)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		shares = append(shares, share)
+		sks = append(sks, shareSks)
+		validators = append(validators, shareValidators)
+	}
+	return shares, sks, validators, nil
+}
+
 // CreateShare creates a new beacon.Share
 func CreateShare(operators [][]byte) (*beacon.Share, map[uint64]*bls.SecretKey, error) {
 	threshold.Init()
@@ -10,6 +10,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 )
 
 type testKeyManager struct {
@@ -97,5 +98,21 @@ func (km *testKeyManager) getKey(key *bls.PublicKey) *bls.SecretKey {
 }
 
 func (km *testKeyManager) SignAttestation(data *spec.AttestationData, duty *spectypes.Duty, pk []byte) (*spec.Attestation, []byte, error) {
-	return nil, nil, nil
+	k, found := km.keys[hex.EncodeToString(pk)]
+	if !found {
+		return nil, nil, errors.New("pk not found")
+	}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not compute attestation root")
+	}
+	sig := spec.BLSSignature{}
+	copy(sig[:], k.SignByte(root[:]).Serialize())
+	aggregationBits := bitfield.NewBitlist(duty.CommitteeLength)
+	aggregationBits.SetBitAt(duty.ValidatorCommitteeIndex, true)
+	return &spec.Attestation{
+		AggregationBits: aggregationBits,
+		Data:            data,
+		Signature:       sig,
+	}, root[:], nil
 }
@@ -0,0 +1,106 @@
+package runner
+
+import (
+	"sync"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/network"
+)
+
+// Partition simulates a network partition between groups of operators,
+// while split, all the messages between operators of different groups are dropped
+type Partition struct {
+	lock   sync.RWMutex
+	groups map[spectypes.OperatorID]int
+}
+
+// NewPartition creates a new partition, the network is not split
+func NewPartition() *Partition {
+	return &Partition{}
+}
+
+// Split splits the network into the given groups of operators,
+// operators that are not part of any group are not affected
+func (p *Partition) Split(groups ...[]spectypes.OperatorID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.groups = make(map[spectypes.OperatorID]int)
+	for i, group := range groups {
+		for _, oid := range group {
+			p.groups[oid] = i
+		}
+	}
+}
+
+// Heal heals the network, messages are no longer dropped
+func (p *Partition) Heal() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.groups = nil
+}
+
+// Reachable returns true if messages of operator from can reach operator to
+func (p *Partition) Reachable(from, to spectypes.OperatorID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	fromGroup, ok := p.groups[from]
+	if !ok {
+		return true
+	}
+	toGroup, ok := p.groups[to]
+	if !ok {
+		return true
+	}
+	return fromGroup == toGroup
+}
+
+// Router wraps the router of the given operator, messages from unreachable operators are dropped
+func (p *Partition) Router(oid spectypes.OperatorID, router network.MessageRouter) network.MessageRouter {
+	return &partitionRouter{
+		partition: p,
+		oid:       oid,
+		router:    router,
+	}
+}
+
+type partitionRouter struct {
+	partition *Partition
+	oid       spectypes.OperatorID
+	router    network.MessageRouter
+}
+
+// Route routes the message if all of its signers are reachable
+func (r *partitionRouter) Route(message spectypes.SSVMessage) {
+	for _, signer := range signersOf(&message) {
+		if !r.partition.Reachable(signer, r.oid) {
+			return
+		}
+	}
+	r.router.Route(message)
+}
+
+// signersOf returns the signers of the given message, nil is returned for messages w/o signers
+func signersOf(msg *spectypes.SSVMessage) []spectypes.OperatorID {
+	switch msg.MsgType {
+	case spectypes.SSVConsensusMsgType, spectypes.SSVDecidedMsgType:
+		sm := &specqbft.SignedMessage{}
+		if err := sm.Decode(msg.Data); err != nil {
+			return nil
+		}
+		return sm.GetSigners()
+	case spectypes.SSVPartialSignatureMsgType:
+		psm := &specssv.SignedPartialSignatureMessage{}
+		if err := psm.Decode(msg.Data); err != nil {
+			return nil
+		}
+		return psm.GetSigners()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+const (
+	// DefaultReadinessTimeout is the default time to wait for validators to become ready
+	DefaultReadinessTimeout = time.Minute
+	// readinessInterval is the interval of readiness checks
+	readinessInterval = 50 * time.Millisecond
+)
+
+// WaitForReadiness blocks until all the given validators are ready, or returns an error once the timeout is reached.
+// a validator is ready once all of its qbft controllers are ready,
+// i.e. it is subscribed to its topic, connected to enough peers and synced decided history
+func WaitForReadiness(ctx context.Context, timeout time.Duration, validators ...validator.IValidator) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+	for {
+		if AllReady(validators...) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "validators are not ready")
+		case <-ticker.C:
+		}
+	}
+}
+
+// AllReady returns true if all the given validators are ready
+func AllReady(validators ...validator.IValidator) bool {
+	for _, val := range validators {
+		if !isReady(val) {
+			return false
+		}
+	}
+	return true
+}
+
+func isReady(val validator.IValidator) bool {
+	v, ok := val.(*validator.Validator)
+	if !ok {
+		return false
+	}
+	for _, ibft := range v.Ibfts() {
+		ctrl, ok := ibft.(*controller.Controller)
+		if !ok || !ctrl.IsReady() {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitForDecided blocks until the last decided of the given identifier in store reaches the given height,
+// or returns an error once the timeout is reached
+func WaitForDecided(ctx context.Context, timeout time.Duration, store qbftstorage.DecidedMsgStore, identifier []byte, height specqbft.Height) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+	for {
+		last, err := store.GetLastDecided(identifier)
+		if err != nil {
+			return errors.Wrap(err, "could not get last decided")
+		}
+		if last != nil && last.Message.Height >= height {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "last decided didn't reach height %d", height)
+		case <-ticker.C:
+		}
+	}
+}
@@ -23,3 +23,30 @@ func (r *Router) Route(message spectypes.SSVMessage) {
 			zap.String("identifier", hex.EncodeToString(identifier[:])))
 	}
 }
+
+// ValidatorsRouter is an helper router to read messages of multiple validators
+type ValidatorsRouter struct {
+	Logger *zap.Logger
+	// Validators holds the controllers of each validator by its public key (hex encoded)
+	Validators map[string]controller.Controllers
+}
+
+// Route processes message and routes it to the right controller of the message validator
+func (r *ValidatorsRouter) Route(message spectypes.SSVMessage) {
+	identifier := message.GetID()
+	pk := hex.EncodeToString(identifier.GetPubKey())
+	ctrls, ok := r.Validators[pk]
+	if !ok {
+		r.Logger.Warn("could not find validator", zap.String("pubKey", pk))
+		return
+	}
+	ctrl := ctrls.ControllerForIdentifier(identifier[:])
+	if ctrl == nil {
+		r.Logger.Warn("could not find controller", zap.String("identifier", hex.EncodeToString(identifier[:])))
+		return
+	}
+	if err := ctrl.ProcessMsg(&message); err != nil {
+		r.Logger.Error("failed to process message",
+			zap.String("identifier", hex.EncodeToString(identifier[:])))
+	}
+}
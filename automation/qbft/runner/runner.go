@@ -2,6 +2,9 @@ package runner
 
 import (
 	"context"
+	"sort"
+
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -32,7 +35,36 @@ func run(logger *zap.Logger, scenario Scenario, sctx *ScenarioContext) error {
 	if err := scenario.PostExecution(sctx); err != nil {
 		return err
 	}
+	if bs, ok := scenario.(BeaconScenario); ok {
+		logger.Info("checking broadcasted beacon roots")
+		if err := checkBeaconRoots(sctx, bs.ExpectedBeaconRoots()); err != nil {
+			return err
+		}
+	}
 	logger.Info("done")
 
 	return nil
 }
+
+// checkBeaconRoots compares the roots that were broadcasted by each operator with the expected roots, ignoring order
+func checkBeaconRoots(sctx *ScenarioContext, expected map[int][]string) error {
+	for i, b := range sctx.Beacons {
+		got, want := sortedRoots(b.BroadcastedRoots()), sortedRoots(expected[i])
+		if len(got) != len(want) {
+			return errors.Errorf("node-%d broadcasted %d beacon roots, expected %d", i, len(got), len(want))
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				return errors.Errorf("node-%d broadcasted unexpected beacon root %s", i, got[j])
+			}
+		}
+	}
+	return nil
+}
+
+func sortedRoots(roots []string) []string {
+	res := make([]string, len(roots))
+	copy(res, roots)
+	sort.Strings(res)
+	return res
+}
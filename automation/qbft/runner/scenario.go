@@ -6,6 +6,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/automation/commons"
 	p2pv1 "github.com/bloxapp/ssv/network/p2p"
 	qbftstorageprotocol "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
@@ -27,6 +28,8 @@ type ScenarioContext struct {
 	LocalNet    *p2pv1.LocalNet
 	Stores      []qbftstorageprotocol.QBFTStore
 	KeyManagers []spectypes.KeyManager
+	Beacons     []*commons.TestBeacon
+	Partition   *Partition
 	DBs         []basedb.IDb
 }
 
@@ -55,3 +58,10 @@ type Scenario interface {
 	// PostExecution is invoked after execution, used for cleanup etc.
 	PostExecution(ctx *ScenarioContext) error
 }
+
+// BeaconScenario is implemented by scenarios that expect duties to reach beacon submission
+type BeaconScenario interface {
+	// ExpectedBeaconRoots returns the roots (hex encoded) that are expected to be broadcasted by each operator,
+	// keyed by the index of the operator
+	ExpectedBeaconRoots() map[int][]string
+}
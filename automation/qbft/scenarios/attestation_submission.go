@@ -0,0 +1,167 @@
+package scenarios
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/automation/commons"
+	"github.com/bloxapp/ssv/automation/qbft/runner"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/utils/threshold"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+// AttestationSubmissionScenario is the attestation submission scenario name
+const AttestationSubmissionScenario = "attestation_submission"
+
+// attestationSubmissionScenario runs an attester duty on 4 operators,
+// and expects each operator to broadcast the reconstructed attestation to beacon
+type attestationSubmissionScenario struct {
+	logger       *zap.Logger
+	sks          map[uint64]*bls.SecretKey
+	share        *beacon.Share
+	validators   []validator.IValidator
+	expectedRoot string
+}
+
+// newAttestationSubmissionScenario creates an attestation submission scenario instance
+func newAttestationSubmissionScenario(logger *zap.Logger) runner.Scenario {
+	return &attestationSubmissionScenario{logger: logger}
+}
+
+func (r *attestationSubmissionScenario) NumOfOperators() int {
+	return 4
+}
+
+func (r *attestationSubmissionScenario) NumOfBootnodes() int {
+	return 0
+}
+
+func (r *attestationSubmissionScenario) NumOfFullNodes() int {
+	return 0
+}
+
+func (r *attestationSubmissionScenario) Name() string {
+	return AttestationSubmissionScenario
+}
+
+func (r *attestationSubmissionScenario) PreExecution(ctx *runner.ScenarioContext) error {
+	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores)
+	if err != nil {
+		return errors.Wrap(err, "could not create share")
+	}
+	r.share = share
+	r.sks = sks
+	r.validators = validators
+
+	for i, node := range ctx.LocalNet.Nodes {
+		node.UseMessageRouter(&runner.Router{
+			Logger:      zap.L().With(zap.String("who", fmt.Sprintf("msgRouter-%d", i))),
+			Controllers: r.validators[i].(*validator.Validator).Ibfts(),
+		})
+	}
+
+	return nil
+}
+
+func (r *attestationSubmissionScenario) Execute(ctx *runner.ScenarioContext) error {
+	if r.share == nil {
+		return errors.New("pre-execution failed")
+	}
+
+	for _, val := range r.validators {
+		if err := val.Start(); err != nil {
+			return errors.Wrap(err, "could not start validator")
+		}
+	}
+	if err := runner.WaitForReadiness(ctx.Ctx, runner.DefaultReadinessTimeout, r.validators...); err != nil {
+		return err
+	}
+
+	duty := r.duty()
+	root, err := r.attestationRoot(ctx.Beacons[0], duty)
+	if err != nil {
+		return err
+	}
+	r.expectedRoot = root
+
+	var wg sync.WaitGroup
+	for _, val := range r.validators {
+		wg.Add(1)
+		go func(val validator.IValidator) {
+			defer wg.Done()
+			val.StartDuty(duty)
+		}(val)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (r *attestationSubmissionScenario) PostExecution(ctx *runner.ScenarioContext) error {
+	return nil
+}
+
+// ExpectedBeaconRoots implements runner.BeaconScenario, each operator is expected to broadcast the attestation
+func (r *attestationSubmissionScenario) ExpectedBeaconRoots() map[int][]string {
+	res := make(map[int][]string)
+	for i := range r.validators {
+		res[i] = []string{r.expectedRoot}
+	}
+	return res
+}
+
+// attestationRoot returns the root of the attestation that is expected to be submitted for the given duty,
+// i.e. the attestation data of the duty, signed by the validator (reconstructed from the shares signatures)
+func (r *attestationSubmissionScenario) attestationRoot(b *commons.TestBeacon, duty *spectypes.Duty) (string, error) {
+	data, err := b.GetAttestationData(duty.Slot, duty.CommitteeIndex)
+	if err != nil {
+		return "", err
+	}
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "could not compute attestation data root")
+	}
+	sigs := make(map[spectypes.OperatorID][]byte)
+	for oid, sk := range r.sks {
+		sigs[spectypes.OperatorID(oid)] = sk.SignByte(dataRoot[:]).Serialize()
+	}
+	sig, err := threshold.ReconstructSignatures(sigs)
+	if err != nil {
+		return "", errors.Wrap(err, "could not reconstruct signature")
+	}
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig.Serialize())
+	aggregationBits := bitfield.NewBitlist(duty.CommitteeLength)
+	aggregationBits.SetBitAt(duty.ValidatorCommitteeIndex, true)
+	root, err := (&spec.Attestation{
+		AggregationBits: aggregationBits,
+		Data:            data,
+		Signature:       blsSig,
+	}).HashTreeRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "could not compute attestation root")
+	}
+	return hex.EncodeToString(root[:]), nil
+}
+
+func (r *attestationSubmissionScenario) duty() *spectypes.Duty {
+	pk := spec.BLSPubKey{}
+	copy(pk[:], r.share.PublicKey.Serialize())
+	return &spectypes.Duty{
+		Type:                    spectypes.BNRoleAttester,
+		PubKey:                  pk,
+		Slot:                    64,
+		CommitteeIndex:          2,
+		CommitteeLength:         128,
+		ValidatorCommitteeIndex: 7,
+	}
+}
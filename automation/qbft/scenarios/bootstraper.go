@@ -49,11 +49,13 @@ func QBFTScenarioBootstrapper() runner.Bootstrapper {
 		}
 		stores := make([]qbftstorageprotocol.QBFTStore, 0)
 		kms := make([]spectypes.KeyManager, 0)
+		beacons := make([]*commons.TestBeacon, 0)
 		for i, node := range ln.Nodes {
 			store := qbftstorage.New(dbs[i], loggerFactory(fmt.Sprintf("qbft-store-%d", i+1)), "attestations", forkVersion)
 			stores = append(stores, store)
 			km := commons.NewTestKeyManager()
 			kms = append(kms, km)
+			beacons = append(beacons, commons.NewTestBeacon())
 			node.RegisterHandlers(p2pprotocol.WithHandler(
 				p2pprotocol.LastDecidedProtocol,
 				handlers.LastDecidedHandler(loggerFactory(fmt.Sprintf("decided-handler-%d", i+1)), store, node),
@@ -71,6 +73,8 @@ func QBFTScenarioBootstrapper() runner.Bootstrapper {
 			LocalNet:    ln,
 			Stores:      stores,
 			KeyManagers: kms,
+			Beacons:     beacons,
+			Partition:   runner.NewPartition(),
 			DBs:         dbs,
 		}, nil
 	}
@@ -54,7 +54,7 @@ func (r *changeRoundSpeedupScenario) Name() string {
 }
 
 func (r *changeRoundSpeedupScenario) PreExecution(ctx *runner.ScenarioContext) error {
-	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Stores)
+	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores)
 	if err != nil {
 		return errors.Wrap(err, "could not create share")
 	}
@@ -112,11 +112,11 @@ func (r *changeRoundSpeedupScenario) Execute(ctx *runner.ScenarioContext) error
 }
 
 func (r *changeRoundSpeedupScenario) PostExecution(ctx *runner.ScenarioContext) error {
-	// using timeout to avoid flakiness
-	<-time.After(time.Second * 2)
-
 	for i := range ctx.Stores[:len(ctx.Stores)-1] {
 		messageID := spectypes.NewMsgID(r.share.PublicKey.Serialize(), spectypes.BNRoleAttester)
+		if err := runner.WaitForDecided(ctx.Ctx, runner.DefaultReadinessTimeout, ctx.Stores[i], messageID[:], specqbft.Height(1)); err != nil {
+			return errors.Wrapf(err, "node-%d didn't decide", i)
+		}
 		msgs, err := ctx.Stores[i].GetDecided(messageID[:], specqbft.Height(1), specqbft.Height(1))
 		if err != nil {
 			return err
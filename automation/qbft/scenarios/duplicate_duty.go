@@ -0,0 +1,132 @@
+package scenarios
+
+import (
+	"fmt"
+	"sync"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/automation/commons"
+	"github.com/bloxapp/ssv/automation/qbft/runner"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+// DuplicateDutyScenario is the duplicate duty scenario name
+const DuplicateDutyScenario = "duplicate_duty"
+
+// duplicateDutyScenario submits the same duty twice to each of the 4 operators,
+// only a single consensus instance is expected to run for the duty
+type duplicateDutyScenario struct {
+	logger     *zap.Logger
+	share      *beacon.Share
+	validators []validator.IValidator
+}
+
+// newDuplicateDutyScenario creates a duplicate duty scenario instance
+func newDuplicateDutyScenario(logger *zap.Logger) runner.Scenario {
+	return &duplicateDutyScenario{logger: logger}
+}
+
+func (r *duplicateDutyScenario) NumOfOperators() int {
+	return 4
+}
+
+func (r *duplicateDutyScenario) NumOfBootnodes() int {
+	return 0
+}
+
+func (r *duplicateDutyScenario) NumOfFullNodes() int {
+	return 0
+}
+
+func (r *duplicateDutyScenario) Name() string {
+	return DuplicateDutyScenario
+}
+
+func (r *duplicateDutyScenario) PreExecution(ctx *runner.ScenarioContext) error {
+	share, _, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores)
+	if err != nil {
+		return errors.Wrap(err, "could not create share")
+	}
+	r.share = share
+	r.validators = validators
+
+	for i, node := range ctx.LocalNet.Nodes {
+		node.UseMessageRouter(&runner.Router{
+			Logger:      zap.L().With(zap.String("who", fmt.Sprintf("msgRouter-%d", i))),
+			Controllers: r.validators[i].(*validator.Validator).Ibfts(),
+		})
+	}
+
+	return nil
+}
+
+func (r *duplicateDutyScenario) Execute(ctx *runner.ScenarioContext) error {
+	if r.share == nil {
+		return errors.New("pre-execution failed")
+	}
+
+	for _, val := range r.validators {
+		if err := val.Start(); err != nil {
+			return errors.Wrap(err, "could not start validator")
+		}
+	}
+	if err := runner.WaitForReadiness(ctx.Ctx, runner.DefaultReadinessTimeout, r.validators...); err != nil {
+		return err
+	}
+
+	duty := r.duty()
+	// the duty is submitted twice concurrently, and once again after it was executed
+	var wg sync.WaitGroup
+	for _, val := range r.validators {
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(val validator.IValidator) {
+				defer wg.Done()
+				val.StartDuty(duty)
+			}(val)
+		}
+	}
+	wg.Wait()
+	for _, val := range r.validators {
+		val.StartDuty(duty)
+	}
+
+	return nil
+}
+
+func (r *duplicateDutyScenario) PostExecution(ctx *runner.ScenarioContext) error {
+	messageID := spectypes.NewMsgID(r.share.PublicKey.Serialize(), spectypes.BNRoleAttester)
+	for i, store := range ctx.Stores {
+		decided, err := store.GetLastDecided(messageID[:])
+		if err != nil {
+			return err
+		}
+		if decided == nil {
+			return fmt.Errorf("node-%d didn't decide", i)
+		}
+		// another instance for the duplicated duty would have decided on a higher height
+		if decided.Message.Height != specqbft.Height(0) {
+			return fmt.Errorf("node-%d decided on height %d, expected a single instance", i, decided.Message.Height)
+		}
+	}
+
+	return nil
+}
+
+func (r *duplicateDutyScenario) duty() *spectypes.Duty {
+	pk := spec.BLSPubKey{}
+	copy(pk[:], r.share.PublicKey.Serialize())
+	return &spectypes.Duty{
+		Type:            spectypes.BNRoleAttester,
+		PubKey:          pk,
+		Slot:            32,
+		CommitteeIndex:  1,
+		CommitteeLength: 128,
+	}
+}
@@ -53,7 +53,7 @@ func (r *f1MultiRoundScenario) Name() string {
 }
 
 func (r *f1MultiRoundScenario) PreExecution(ctx *runner.ScenarioContext) error {
-	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Stores)
+	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores)
 	if err != nil {
 		return errors.Wrap(err, "could not create share")
 	}
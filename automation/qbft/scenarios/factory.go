@@ -16,14 +16,24 @@ func NewScenario(name string, logger *zap.Logger) runner.Scenario {
 	raw, ok := scenarios.Load(name)
 	if !ok {
 		switch name {
+		case AttestationSubmissionScenario:
+			s = newAttestationSubmissionScenario(logger)
 		case ChangeRoundSpeedupScenario:
 			s = newChangeRoundSpeedupScenario(logger)
+		case DuplicateDutyScenario:
+			s = newDuplicateDutyScenario(logger)
 		case F1MultiRoundScenario:
 			s = newF1MultiRoundScenario(logger)
 		case F1SpeedupScenario:
 			s = newF1SpeedupScenario(logger)
 		case FarFutureSyncScenario:
 			s = newFarFutureSyncScenario(logger)
+		case MultiValidatorsScenario:
+			s = newMultiValidatorsScenario(logger)
+		case PartitionHealScenario:
+			s = newPartitionHealScenario(logger)
+		case ReadinessScenario:
+			s = newReadinessScenario(logger)
 		case RegularScenario:
 			s = newRegularScenario(logger)
 		case SyncFailoverScenario:
@@ -98,7 +98,6 @@ func (r *fullNodeScenario) Execute(ctx *runner.ScenarioContext) error {
 			if err := val.Start(); err != nil {
 				startErr = errors.Wrap(err, "could not start validator")
 			}
-			<-time.After(time.Second * 3)
 		}(val)
 	}
 	wg.Wait()
@@ -106,6 +105,9 @@ func (r *fullNodeScenario) Execute(ctx *runner.ScenarioContext) error {
 	if startErr != nil {
 		return errors.Wrap(startErr, "could not start validators")
 	}
+	if err := runner.WaitForReadiness(ctx.Ctx, runner.DefaultReadinessTimeout, r.validators...); err != nil {
+		return err
+	}
 
 	const fromHeight = specqbft.Height(0)
 	const toHeight = specqbft.Height(3)
@@ -116,9 +118,9 @@ func (r *fullNodeScenario) Execute(ctx *runner.ScenarioContext) error {
 		}
 	}
 
-	<-time.After(time.Second * 3)
-
-	return nil
+	messageID := spectypes.NewMsgID(r.share.PublicKey.Serialize(), spectypes.BNRoleAttester)
+	fullNodeStore := ctx.Stores[len(ctx.Stores)-1]
+	return runner.WaitForDecided(ctx.Ctx, runner.DefaultReadinessTimeout, fullNodeStore, messageID[:], toHeight)
 }
 
 func (r *fullNodeScenario) PostExecution(ctx *runner.ScenarioContext) error {
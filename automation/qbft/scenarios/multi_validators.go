@@ -0,0 +1,142 @@
+package scenarios
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/automation/commons"
+	"github.com/bloxapp/ssv/automation/qbft/runner"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+// MultiValidatorsScenario is the multiple validators scenario name
+const MultiValidatorsScenario = "multi_validators"
+
+// validatorsPerOperator is the amount of validators that are running on each operator
+const validatorsPerOperator = 3
+
+// multiValidatorsScenario runs multiple validators on each of the 4 operators, sharing the same node and store.
+// each validator decides on its own value, which checks that the controllers of the validators are isolated
+type multiValidatorsScenario struct {
+	logger *zap.Logger
+	shares []*beacon.Share
+	// validators holds the validators of each share, in the order of the operators
+	validators [][]validator.IValidator
+}
+
+// newMultiValidatorsScenario creates a multiple validators scenario instance
+func newMultiValidatorsScenario(logger *zap.Logger) runner.Scenario {
+	return &multiValidatorsScenario{logger: logger}
+}
+
+func (r *multiValidatorsScenario) NumOfOperators() int {
+	return 4
+}
+
+func (r *multiValidatorsScenario) NumOfBootnodes() int {
+	return 0
+}
+
+func (r *multiValidatorsScenario) NumOfFullNodes() int {
+	return 0
+}
+
+func (r *multiValidatorsScenario) Name() string {
+	return MultiValidatorsScenario
+}
+
+func (r *multiValidatorsScenario) PreExecution(ctx *runner.ScenarioContext) error {
+	shares, _, validators, err := commons.CreateSharesAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores, validatorsPerOperator)
+	if err != nil {
+		return errors.Wrap(err, "could not create shares")
+	}
+	r.shares = shares
+	r.validators = validators
+
+	for i, node := range ctx.LocalNet.Nodes {
+		ctrls := make(map[string]controller.Controllers)
+		for j, share := range r.shares {
+			ctrls[share.PublicKey.SerializeToHexStr()] = r.validators[j][i].(*validator.Validator).Ibfts()
+		}
+		node.UseMessageRouter(&runner.ValidatorsRouter{
+			Logger:     zap.L().With(zap.String("who", fmt.Sprintf("msgRouter-%d", i))),
+			Validators: ctrls,
+		})
+	}
+
+	return nil
+}
+
+func (r *multiValidatorsScenario) Execute(ctx *runner.ScenarioContext) error {
+	if len(r.shares) != validatorsPerOperator {
+		return errors.New("pre-execution failed")
+	}
+
+	all := make([]validator.IValidator, 0)
+	for _, vals := range r.validators {
+		all = append(all, vals...)
+	}
+	for _, val := range all {
+		if err := val.Start(); err != nil {
+			return errors.Wrap(err, "could not start validator")
+		}
+	}
+	if err := runner.WaitForReadiness(ctx.Ctx, runner.DefaultReadinessTimeout, all...); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(all))
+	for j, vals := range r.validators {
+		for _, val := range vals {
+			wg.Add(1)
+			go func(val validator.IValidator, value []byte) {
+				defer wg.Done()
+				if err := startNode(val, specqbft.Height(0), value, r.logger); err != nil {
+					errs <- err
+				}
+			}(val, r.value(j))
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+func (r *multiValidatorsScenario) PostExecution(ctx *runner.ScenarioContext) error {
+	for j, share := range r.shares {
+		messageID := spectypes.NewMsgID(share.PublicKey.Serialize(), spectypes.BNRoleAttester)
+		for i, store := range ctx.Stores {
+			decided, err := store.GetLastDecided(messageID[:])
+			if err != nil {
+				return err
+			}
+			if decided == nil || decided.Message.Height != specqbft.Height(0) {
+				return fmt.Errorf("node-%d didn't decide for validator %d", i, j)
+			}
+			commitData, err := decided.Message.GetCommitData()
+			if err != nil {
+				return errors.Wrap(err, "could not get commit data")
+			}
+			if !bytes.Equal(commitData.Data, r.value(j)) {
+				return fmt.Errorf("node-%d decided on a wrong value for validator %d", i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// value returns the value to decide on for the validator of the given share index
+func (r *multiValidatorsScenario) value(j int) []byte {
+	return []byte(fmt.Sprintf("value-%s", r.shares[j].PublicKey.SerializeToHexStr()))
+}
@@ -0,0 +1,150 @@
+package scenarios
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/automation/commons"
+	"github.com/bloxapp/ssv/automation/qbft/runner"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+// PartitionHealScenario is the partition and heal scenario name
+const PartitionHealScenario = "partition_heal"
+
+// partitionedHeights is the amount of heights that are decided while the network is partitioned
+const partitionedHeights = 3
+
+// partitionHealScenario splits the committee of 4 operators, the majority keeps deciding while the minority
+// (below quorum) can't reach the others. once healed, the lagging operator is expected to catch up via decided sync
+type partitionHealScenario struct {
+	logger     *zap.Logger
+	share      *beacon.Share
+	validators []validator.IValidator
+}
+
+// newPartitionHealScenario creates a partition and heal scenario instance
+func newPartitionHealScenario(logger *zap.Logger) runner.Scenario {
+	return &partitionHealScenario{logger: logger}
+}
+
+func (r *partitionHealScenario) NumOfOperators() int {
+	return 4
+}
+
+func (r *partitionHealScenario) NumOfBootnodes() int {
+	return 0
+}
+
+func (r *partitionHealScenario) NumOfFullNodes() int {
+	return 0
+}
+
+func (r *partitionHealScenario) Name() string {
+	return PartitionHealScenario
+}
+
+func (r *partitionHealScenario) PreExecution(ctx *runner.ScenarioContext) error {
+	share, _, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores)
+	if err != nil {
+		return errors.Wrap(err, "could not create share")
+	}
+	r.share = share
+	r.validators = validators
+
+	for i, node := range ctx.LocalNet.Nodes {
+		node.UseMessageRouter(ctx.Partition.Router(spectypes.OperatorID(i+1), &runner.Router{
+			Logger:      zap.L().With(zap.String("who", fmt.Sprintf("msgRouter-%d", i))),
+			Controllers: r.validators[i].(*validator.Validator).Ibfts(),
+		}))
+	}
+
+	return nil
+}
+
+func (r *partitionHealScenario) Execute(ctx *runner.ScenarioContext) error {
+	if r.share == nil {
+		return errors.New("pre-execution failed")
+	}
+
+	for _, val := range r.validators {
+		if err := val.Start(); err != nil {
+			return errors.Wrap(err, "could not start validator")
+		}
+	}
+	if err := runner.WaitForReadiness(ctx.Ctx, runner.DefaultReadinessTimeout, r.validators...); err != nil {
+		return err
+	}
+
+	majority, minority := r.validators[:3], r.validators[3:]
+	ctx.Partition.Split([]spectypes.OperatorID{1, 2, 3}, []spectypes.OperatorID{4})
+	r.logger.Info("network was partitioned", zap.Int("majority", len(majority)), zap.Int("minority", len(minority)))
+	for h := specqbft.Height(0); h < partitionedHeights; h++ {
+		if err := r.startInstances(h, majority...); err != nil {
+			return errors.Wrapf(err, "majority could not decide on height %d", h)
+		}
+	}
+
+	ctx.Partition.Heal()
+	r.logger.Info("network was healed")
+	// the decided message of the next height triggers a decided sync on the lagging operator
+	if err := r.startInstances(partitionedHeights, majority...); err != nil {
+		return errors.Wrap(err, "could not decide after heal")
+	}
+	messageID := spectypes.NewMsgID(r.share.PublicKey.Serialize(), spectypes.BNRoleAttester)
+	for i := len(majority); i < len(ctx.Stores); i++ {
+		if err := runner.WaitForDecided(ctx.Ctx, runner.DefaultReadinessTimeout, ctx.Stores[i], messageID[:], partitionedHeights); err != nil {
+			return errors.Wrapf(err, "node-%d didn't catch up", i)
+		}
+	}
+
+	return nil
+}
+
+func (r *partitionHealScenario) PostExecution(ctx *runner.ScenarioContext) error {
+	messageID := spectypes.NewMsgID(r.share.PublicKey.Serialize(), spectypes.BNRoleAttester)
+	var expected *specqbft.SignedMessage
+	for i, store := range ctx.Stores {
+		decided, err := store.GetLastDecided(messageID[:])
+		if err != nil {
+			return err
+		}
+		if decided == nil || decided.Message.Height != partitionedHeights {
+			return fmt.Errorf("node-%d is not at height %d", i, partitionedHeights)
+		}
+		if expected == nil {
+			expected = decided
+			continue
+		}
+		if !bytes.Equal(decided.Message.Data, expected.Message.Data) {
+			return fmt.Errorf("node-%d decided on a different value", i)
+		}
+	}
+
+	return nil
+}
+
+func (r *partitionHealScenario) startInstances(h specqbft.Height, validators ...validator.IValidator) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(validators))
+	for _, val := range validators {
+		wg.Add(1)
+		go func(val validator.IValidator) {
+			defer wg.Done()
+			if err := startNode(val, h, []byte(fmt.Sprintf("value-%d", h)), r.logger); err != nil {
+				errs <- err
+			}
+		}(val)
+	}
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
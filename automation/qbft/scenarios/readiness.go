@@ -0,0 +1,147 @@
+package scenarios
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/automation/commons"
+	"github.com/bloxapp/ssv/automation/qbft/runner"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+)
+
+// ReadinessScenario is the readiness scenario name
+const ReadinessScenario = "readiness"
+
+const (
+	// readinessLatency is the max latency between readiness of the validators and the harness proceeding
+	readinessLatency = 500 * time.Millisecond
+	// readinessTrackInterval is the interval of checks that track the time in which the validators became ready
+	readinessTrackInterval = 5 * time.Millisecond
+)
+
+// readinessScenario starts 4 operators and checks that the harness proceeds once all validators are ready
+type readinessScenario struct {
+	logger     *zap.Logger
+	validators []validator.IValidator
+	// readyAt is the time in which the last validator became ready
+	readyAt time.Time
+	// proceededAt is the time in which the harness proceeded
+	proceededAt time.Time
+}
+
+// newReadinessScenario creates a readiness scenario instance
+func newReadinessScenario(logger *zap.Logger) runner.Scenario {
+	return &readinessScenario{logger: logger}
+}
+
+func (r *readinessScenario) NumOfOperators() int {
+	return 4
+}
+
+func (r *readinessScenario) NumOfBootnodes() int {
+	return 0
+}
+
+func (r *readinessScenario) NumOfFullNodes() int {
+	return 0
+}
+
+func (r *readinessScenario) Name() string {
+	return ReadinessScenario
+}
+
+func (r *readinessScenario) PreExecution(ctx *runner.ScenarioContext) error {
+	_, _, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores)
+	if err != nil {
+		return errors.Wrap(err, "could not create share")
+	}
+	r.validators = validators
+	return nil
+}
+
+func (r *readinessScenario) Execute(ctx *runner.ScenarioContext) error {
+	if len(r.validators) == 0 {
+		return errors.New("pre-execution failed")
+	}
+
+	// the ready transition is tracked separately from the harness, so the latency of the harness can be measured
+	readyAt := make(chan time.Time, 1)
+	go trackReadiness(ctx.Ctx, r.validators, readyAt)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	var startErrLock sync.Mutex
+	var startErr error
+	for _, val := range r.validators {
+		wg.Add(1)
+		go func(val validator.IValidator) {
+			defer wg.Done()
+			if err := val.Start(); err != nil {
+				startErrLock.Lock()
+				defer startErrLock.Unlock()
+				startErr = errors.Wrap(err, "could not start validator")
+			}
+		}(val)
+	}
+	wg.Wait()
+	startErrLock.Lock()
+	err := startErr
+	startErrLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := runner.WaitForReadiness(ctx.Ctx, runner.DefaultReadinessTimeout, r.validators...); err != nil {
+		return err
+	}
+	r.proceededAt = time.Now()
+	select {
+	case r.readyAt = <-readyAt:
+	case <-ctx.Ctx.Done():
+		return errors.Wrap(ctx.Ctx.Err(), "ready transition was not tracked")
+	}
+	r.logger.Info("validators are ready", zap.Duration("elapsed", r.readyAt.Sub(start)),
+		zap.Duration("latency", r.proceededAt.Sub(r.readyAt)))
+
+	return nil
+}
+
+// trackReadiness sends the time in which all the given validators became ready
+func trackReadiness(ctx context.Context, validators []validator.IValidator, readyAt chan<- time.Time) {
+	ticker := time.NewTicker(readinessTrackInterval)
+	defer ticker.Stop()
+	for {
+		if runner.AllReady(validators...) {
+			readyAt <- time.Now()
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *readinessScenario) PostExecution(ctx *runner.ScenarioContext) error {
+	for i, val := range r.validators {
+		for role, ibft := range val.(*validator.Validator).Ibfts() {
+			if !ibft.(*controller.Controller).IsReady() {
+				return fmt.Errorf("node-%d is not ready for role %s", i, role.String())
+			}
+		}
+	}
+
+	// the harness should proceed right after the last validator became ready
+	if latency := r.proceededAt.Sub(r.readyAt); latency > readinessLatency {
+		return fmt.Errorf("harness didn't proceed once ready, waited %s", latency)
+	}
+
+	return nil
+}
@@ -3,7 +3,6 @@ package scenarios
 import (
 	"fmt"
 	"sync"
-	"time"
 
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -51,7 +50,7 @@ func (r *regularScenario) Name() string {
 }
 
 func (r *regularScenario) PreExecution(ctx *runner.ScenarioContext) error {
-	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Stores)
+	share, sks, validators, err := commons.CreateShareAndValidators(ctx.Ctx, r.logger, ctx.LocalNet, ctx.KeyManagers, ctx.Beacons, ctx.Stores)
 	if err != nil {
 		return errors.Wrap(err, "could not create share")
 	}
@@ -102,7 +101,7 @@ func (r *regularScenario) PreExecution(ctx *runner.ScenarioContext) error {
 	return nil
 }
 
-func (r *regularScenario) Execute(_ *runner.ScenarioContext) error {
+func (r *regularScenario) Execute(ctx *runner.ScenarioContext) error {
 	if len(r.sks) == 0 || r.share == nil {
 		return errors.New("pre-execution failed")
 	}
@@ -116,12 +115,14 @@ func (r *regularScenario) Execute(_ *runner.ScenarioContext) error {
 			if err := val.Start(); err != nil {
 				startErr = errors.Wrap(err, "could not start validator")
 			}
-			<-time.After(time.Second * 3)
 		}(val)
 	}
 	wg.Wait()
+	if startErr != nil {
+		return startErr
+	}
 
-	return startErr
+	return runner.WaitForReadiness(ctx.Ctx, runner.DefaultReadinessTimeout, r.validators...)
 }
 
 func (r *regularScenario) PostExecution(ctx *runner.ScenarioContext) error {
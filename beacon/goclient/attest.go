@@ -1,36 +1,47 @@
 package goclient
 
 import (
+	"context"
+
 	eth2client "github.com/attestantio/go-eth2-client"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
 func (gc *goClient) GetAttestationData(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.AttestationData, error) {
-	if provider, isProvider := gc.client.(eth2client.AttestationDataProvider); isProvider {
-		gc.waitOneThirdOrValidBlock(uint64(slot))
-		attestationData, err := provider.AttestationData(gc.ctx, slot, committeeIndex)
-		if err != nil {
-			return nil, err
+	gc.waitOneThirdOrValidBlock(uint64(slot))
+	var attestationData *spec.AttestationData
+	err := gc.doRequest("attestation_data", func(ctx context.Context) error {
+		provider, isProvider := gc.client().(eth2client.AttestationDataProvider)
+		if !isProvider {
+			return errors.New("client does not support AttestationDataProvider")
 		}
-		return attestationData, nil
+		var err error
+		attestationData, err = provider.AttestationData(ctx, slot, committeeIndex)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("client does not support AttestationDataProvider")
+	return attestationData, nil
 }
 
 // SubmitAttestation implements Beacon interface
 func (gc *goClient) SubmitAttestation(attestation *spec.Attestation) error {
-	if provider, isProvider := gc.client.(eth2client.AttestationsSubmitter); isProvider {
-		signingRoot, err := gc.getSigningRoot(attestation.Data)
-		if err != nil {
-			return errors.Wrap(err, "failed to get signing root")
-		}
-
-		if err := gc.slashableAttestationCheck(gc.ctx, signingRoot); err != nil {
-			return errors.Wrap(err, "failed attestation slashing protection check")
-		}
+	signingRoot, err := gc.getSigningRoot(attestation.Data)
+	if err != nil {
+		return errors.Wrap(err, "failed to get signing root")
+	}
 
-		return provider.SubmitAttestations(gc.ctx, []*spec.Attestation{attestation})
+	if err := gc.slashableAttestationCheck(gc.ctx, signingRoot); err != nil {
+		return errors.Wrap(err, "failed attestation slashing protection check")
 	}
-	return nil
+
+	return gc.doRequest("submit_attestation", func(ctx context.Context) error {
+		provider, isProvider := gc.client().(eth2client.AttestationsSubmitter)
+		if !isProvider {
+			return errors.New("client does not support AttestationsSubmitter")
+		}
+		return provider.SubmitAttestations(ctx, []*spec.Attestation{attestation})
+	})
 }
@@ -1,6 +1,8 @@
 package goclient
 
 import (
+	"context"
+
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/pkg/errors"
@@ -8,8 +10,11 @@ import (
 
 // SubscribeToCommitteeSubnet is implementation for subscribing committee to subnet (p2p topic)
 func (gc *goClient) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error {
-	if provider, isProvider := gc.client.(eth2client.BeaconCommitteeSubscriptionsSubmitter); isProvider {
-		return provider.SubmitBeaconCommitteeSubscriptions(gc.ctx, subscription)
-	}
-	return errors.New("client does not support BeaconCommitteeSubscriptionsSubmitter")
+	return gc.doRequest("committee_subscriptions", func(ctx context.Context) error {
+		provider, isProvider := gc.client().(eth2client.BeaconCommitteeSubscriptionsSubmitter)
+		if !isProvider {
+			return errors.New("client does not support BeaconCommitteeSubscriptionsSubmitter")
+		}
+		return provider.SubmitBeaconCommitteeSubscriptions(ctx, subscription)
+	})
 }
@@ -0,0 +1,66 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+// requiredSpecKeys are the spec values that the beacon node must expose,
+// ALTAIR_FORK_EPOCH indicates that the node supports sync committee endpoints
+var requiredSpecKeys = []string{"SECONDS_PER_SLOT", "SLOTS_PER_EPOCH", "ALTAIR_FORK_EPOCH"}
+
+// checkCompatibility queries the beacon node version and spec,
+// and returns the list of issues that makes the node incompatible
+func checkCompatibility(ctx context.Context, svc eth2client.Service, network beaconprotocol.Network) (string, []string) {
+	var issues []string
+	if _, ok := svc.(eth2client.AttesterDutiesProvider); !ok {
+		issues = append(issues, "beacon node does not support attester duties")
+	}
+	if _, ok := svc.(eth2client.ValidatorsProvider); !ok {
+		issues = append(issues, "beacon node does not support validators")
+	}
+	if _, ok := svc.(eth2client.BeaconCommitteeSubscriptionsSubmitter); !ok {
+		issues = append(issues, "beacon node does not support beacon committee subscriptions")
+	}
+	if _, ok := svc.(eth2client.SyncCommitteeSubscriptionsSubmitter); !ok {
+		issues = append(issues, "beacon node does not support sync committee subscriptions")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var version string
+	if provider, ok := svc.(eth2client.NodeVersionProvider); ok {
+		v, err := provider.NodeVersion(ctx)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("could not get beacon node version: %s", err.Error()))
+		}
+		version = v
+	} else {
+		issues = append(issues, "beacon node does not support node version")
+	}
+
+	provider, ok := svc.(eth2client.SpecProvider)
+	if !ok {
+		return version, append(issues, "beacon node does not support spec")
+	}
+	spec, err := provider.Spec(ctx)
+	if err != nil {
+		return version, append(issues, fmt.Sprintf("could not get beacon node spec: %s", err.Error()))
+	}
+	for _, key := range requiredSpecKeys {
+		if _, exist := spec[key]; !exist {
+			issues = append(issues, fmt.Sprintf("beacon node spec is missing %s", key))
+		}
+	}
+	if slotDuration, ok := spec["SECONDS_PER_SLOT"].(time.Duration); ok && slotDuration != network.SlotDurationSec() {
+		issues = append(issues, fmt.Sprintf("beacon node slot duration (%s) does not match network (%s)",
+			slotDuration, network.SlotDurationSec()))
+	}
+	return version, issues
+}
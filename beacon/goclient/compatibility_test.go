@@ -0,0 +1,128 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/stretchr/testify/require"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	network := beaconprotocol.NewNetwork(core.PraterNetwork)
+
+	t.Run("compatible", func(t *testing.T) {
+		svc := &mockBeaconService{
+			version: "Lighthouse/v2.5.1",
+			spec: map[string]interface{}{
+				"SECONDS_PER_SLOT":  12 * time.Second,
+				"SLOTS_PER_EPOCH":   uint64(32),
+				"ALTAIR_FORK_EPOCH": uint64(36660),
+			},
+		}
+		version, issues := checkCompatibility(context.Background(), svc, network)
+		require.Equal(t, "Lighthouse/v2.5.1", version)
+		require.Len(t, issues, 0)
+	})
+
+	t.Run("pre altair node", func(t *testing.T) {
+		svc := &mockBeaconService{
+			version: "Lighthouse/v1.5.0",
+			spec: map[string]interface{}{
+				"SECONDS_PER_SLOT": 12 * time.Second,
+				"SLOTS_PER_EPOCH":  uint64(32),
+			},
+		}
+		version, issues := checkCompatibility(context.Background(), svc, network)
+		require.Equal(t, "Lighthouse/v1.5.0", version)
+		require.Equal(t, []string{"beacon node spec is missing ALTAIR_FORK_EPOCH"}, issues)
+	})
+
+	t.Run("slot duration mismatch", func(t *testing.T) {
+		svc := &mockBeaconService{
+			version: "Lighthouse/v2.5.1",
+			spec: map[string]interface{}{
+				"SECONDS_PER_SLOT":  6 * time.Second,
+				"SLOTS_PER_EPOCH":   uint64(32),
+				"ALTAIR_FORK_EPOCH": uint64(36660),
+			},
+		}
+		_, issues := checkCompatibility(context.Background(), svc, network)
+		require.Len(t, issues, 1)
+	})
+
+	t.Run("health check reports issues", func(t *testing.T) {
+		gc := &goClient{
+			ctx:                 context.Background(),
+			endpoints:           newTestEndpoints(&mockBeaconService{}),
+			compatibilityIssues: []string{"beacon node spec is missing ALTAIR_FORK_EPOCH"},
+		}
+		require.Equal(t, []string{"beacon node spec is missing ALTAIR_FORK_EPOCH"}, gc.HealthCheck())
+	})
+}
+
+type mockBeaconService struct {
+	version string
+	spec    map[string]interface{}
+	// delay is applied on duties requests
+	delay time.Duration
+	calls int32
+	// unhealthy is set to 1 when the node is not healthy
+	unhealthy int32
+}
+
+func (m *mockBeaconService) Name() string {
+	return "mock"
+}
+
+func (m *mockBeaconService) Address() string {
+	return "localhost"
+}
+
+func (m *mockBeaconService) NodeSyncing(ctx context.Context) (*eth2apiv1.SyncState, error) {
+	if atomic.LoadInt32(&m.unhealthy) == 1 {
+		return nil, errors.New("node is down")
+	}
+	return &eth2apiv1.SyncState{}, nil
+}
+
+func (m *mockBeaconService) NodeVersion(ctx context.Context) (string, error) {
+	return m.version, nil
+}
+
+func (m *mockBeaconService) Spec(ctx context.Context) (map[string]interface{}, error) {
+	return m.spec, nil
+}
+
+func (m *mockBeaconService) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error) {
+	atomic.AddInt32(&m.calls, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(m.delay):
+	}
+	return []*eth2apiv1.AttesterDuty{{Slot: 1}}, nil
+}
+
+func (m *mockBeaconService) Validators(ctx context.Context, stateID string, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	return nil, nil
+}
+
+func (m *mockBeaconService) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	return nil, nil
+}
+
+func (m *mockBeaconService) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscriptions []*eth2apiv1.BeaconCommitteeSubscription) error {
+	return nil
+}
+
+func (m *mockBeaconService) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptions []*eth2apiv1.SyncCommitteeSubscription) error {
+	return nil
+}
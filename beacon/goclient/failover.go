@@ -0,0 +1,189 @@
+package goclient
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/utils/async"
+)
+
+const (
+	// endpointsCheckInterval is the interval for re-checking the endpoints,
+	// so we can go back to the primary endpoint once it is healthy
+	endpointsCheckInterval = time.Minute
+)
+
+var (
+	metricsBeaconActiveEndpoint = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:beacon:active_endpoint",
+		Help: "The active beacon node endpoint (1 for active, 0 otherwise)",
+	}, []string{"address"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsBeaconActiveEndpoint); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
+
+// connectFunc creates a client for the given beacon node address
+type connectFunc func(ctx context.Context, address string) (eth2client.Service, error)
+
+// beaconEndpoint is a single beacon node endpoint, client is nil until connected
+type beaconEndpoint struct {
+	address string
+	client  eth2client.Service
+}
+
+// endpoints holds the beacon node endpoints ordered by priority, where the first one is the primary.
+// requests are sent to the active endpoint, which is replaced once it becomes unhealthy
+type endpoints struct {
+	ctx     context.Context
+	logger  *zap.Logger
+	connect connectFunc
+
+	// selectLock makes sure that only one selection is running at a time
+	selectLock sync.Mutex
+	lock       sync.RWMutex
+	list       []*beaconEndpoint
+	active     int
+}
+
+// parseEndpoints parses a comma-separated list of beacon node addresses
+func parseEndpoints(addresses string) []string {
+	var res []string
+	for _, addr := range strings.Split(addresses, ",") {
+		if addr = strings.TrimSpace(addr); len(addr) > 0 {
+			res = append(res, addr)
+		}
+	}
+	return res
+}
+
+// newEndpoints creates the endpoints and selects the active one
+func newEndpoints(ctx context.Context, logger *zap.Logger, addresses []string, connect connectFunc) (*endpoints, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("no beacon node address was provided")
+	}
+	e := &endpoints{
+		ctx:     ctx,
+		logger:  logger,
+		connect: connect,
+		active:  -1,
+	}
+	for _, addr := range addresses {
+		e.list = append(e.list, &beaconEndpoint{address: addr})
+	}
+	if !e.selectEndpoint() {
+		return nil, errors.New("could not connect to any beacon node")
+	}
+	return e, nil
+}
+
+// client returns the client of the active endpoint
+func (e *endpoints) client() eth2client.Service {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	if e.active < 0 {
+		return nil
+	}
+	return e.list[e.active].client
+}
+
+// activeAddress returns the address of the active endpoint
+func (e *endpoints) activeAddress() string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	if e.active < 0 {
+		return ""
+	}
+	return e.list[e.active].address
+}
+
+// size returns the number of endpoints
+func (e *endpoints) size() int {
+	return len(e.list)
+}
+
+// start re-checks the endpoints periodically, until the context is done
+func (e *endpoints) start(interval time.Duration) {
+	if e.size() < 2 {
+		return
+	}
+	async.Interval(e.ctx, interval, func() {
+		e.selectEndpoint()
+	})
+}
+
+// selectEndpoint activates the first healthy endpoint by priority.
+// if none is healthy, the first connected endpoint is used and false is returned
+func (e *endpoints) selectEndpoint() bool {
+	e.selectLock.Lock()
+	defer e.selectLock.Unlock()
+
+	selected, healthy := -1, false
+	for i, ep := range e.list {
+		c := e.endpointClient(ep)
+		if c == nil {
+			continue
+		}
+		if selected < 0 {
+			selected = i
+		}
+		if status, _ := nodeHealth(e.ctx, c); status == statusOK {
+			selected, healthy = i, true
+			break
+		}
+	}
+	if selected < 0 {
+		return false
+	}
+	e.setActive(selected)
+	return healthy
+}
+
+// endpointClient returns the client of the given endpoint, and connects if needed
+func (e *endpoints) endpointClient(ep *beaconEndpoint) eth2client.Service {
+	e.lock.RLock()
+	c := ep.client
+	e.lock.RUnlock()
+	if c != nil {
+		return c
+	}
+	c, err := e.connect(e.ctx, ep.address)
+	if err != nil {
+		e.logger.Debug("could not connect to beacon node", zap.String("address", ep.address), zap.Error(err))
+		return nil
+	}
+	e.lock.Lock()
+	ep.client = c
+	e.lock.Unlock()
+	return c
+}
+
+func (e *endpoints) setActive(i int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.active == i {
+		return
+	}
+	if e.active >= 0 {
+		e.logger.Warn("switching beacon node endpoint",
+			zap.String("from", e.list[e.active].address), zap.String("to", e.list[i].address))
+		metricsBeaconActiveEndpoint.WithLabelValues(e.list[e.active].address).Set(0)
+	}
+	e.active = i
+	metricsBeaconActiveEndpoint.WithLabelValues(e.list[i].address).Set(1)
+}
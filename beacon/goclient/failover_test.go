@@ -0,0 +1,98 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	require.Equal(t, []string{"localhost:5052"}, parseEndpoints("localhost:5052"))
+	require.Equal(t, []string{"primary:5052", "secondary:5052"}, parseEndpoints(" primary:5052, secondary:5052,"))
+	require.Len(t, parseEndpoints(""), 0)
+}
+
+func TestEndpoints_Failover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	primary := &mockBeaconService{unhealthy: 1}
+	secondary := &mockBeaconService{}
+	connect := func(ctx context.Context, address string) (eth2client.Service, error) {
+		switch address {
+		case "primary":
+			return primary, nil
+		case "secondary":
+			return secondary, nil
+		}
+		return nil, errors.New("unknown address")
+	}
+
+	eps, err := newEndpoints(ctx, zap.L(), []string{"primary", "secondary"}, connect)
+	require.NoError(t, err)
+	require.Equal(t, "secondary", eps.activeAddress())
+	require.Equal(t, secondary, eps.client())
+
+	gc := &goClient{ctx: ctx, logger: zap.L(), endpoints: eps}
+	require.Len(t, gc.HealthCheck(), 0)
+
+	// secondary is down -> the primary is still unhealthy so the first connected endpoint is used
+	atomic.StoreInt32(&secondary.unhealthy, 1)
+	require.False(t, eps.selectEndpoint())
+	require.Equal(t, "primary", eps.activeAddress())
+	require.Len(t, gc.HealthCheck(), 1)
+
+	// primary is back
+	atomic.StoreInt32(&primary.unhealthy, 0)
+	require.True(t, eps.selectEndpoint())
+	require.Equal(t, "primary", eps.activeAddress())
+	require.Len(t, gc.HealthCheck(), 0)
+}
+
+func TestEndpoints_ConnectFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	secondary := &mockBeaconService{}
+	primaryUp := int32(0)
+	connect := func(ctx context.Context, address string) (eth2client.Service, error) {
+		if address == "primary" {
+			if atomic.LoadInt32(&primaryUp) == 0 {
+				return nil, errors.New("connection refused")
+			}
+			return &mockBeaconService{}, nil
+		}
+		return secondary, nil
+	}
+
+	eps, err := newEndpoints(ctx, zap.L(), []string{"primary", "secondary"}, connect)
+	require.NoError(t, err)
+	require.Equal(t, "secondary", eps.activeAddress())
+
+	// primary is reachable again and will be selected on the next check
+	atomic.StoreInt32(&primaryUp, 1)
+	require.True(t, eps.selectEndpoint())
+	require.Equal(t, "primary", eps.activeAddress())
+
+	_, err = newEndpoints(ctx, zap.L(), []string{"unknown"}, func(ctx context.Context, address string) (eth2client.Service, error) {
+		return nil, errors.New("connection refused")
+	})
+	require.EqualError(t, err, "could not connect to any beacon node")
+}
+
+// newTestEndpoints creates endpoints with the given connected clients, the first one is active
+func newTestEndpoints(clients ...eth2client.Service) *endpoints {
+	eps := &endpoints{
+		ctx:    context.Background(),
+		logger: zap.L(),
+	}
+	for _, c := range clients {
+		eps.list = append(eps.list, &beaconEndpoint{address: "test", client: c})
+	}
+	return eps
+}
@@ -53,9 +53,13 @@ type goClient struct {
 	ctx            context.Context
 	logger         *zap.Logger
 	network        beaconprotocol.Network
-	client         client.Service
+	endpoints      *endpoints
 	indicesMapLock sync.Mutex
 	graffiti       []byte
+	requestTimeout time.Duration
+	requestRetries int
+	// compatibilityIssues are found once on startup and reported by the health check
+	compatibilityIssues []string
 }
 
 // verifies that the client implements HealthCheckAgent
@@ -66,90 +70,148 @@ func New(opt beaconprotocol.Options) (beaconprotocol.Beacon, error) {
 	logger := opt.Logger.With(zap.String("component", "goClient"), zap.String("network", opt.Network))
 	logger.Info("connecting to beacon client...")
 
-	httpClient, err := http.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		http.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		http.WithLogLevel(zerolog.DebugLevel),
-		http.WithTimeout(time.Second*5),
-	)
+	requestTimeout := opt.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = defaultRequestTimeout
+	}
+
+	connect := func(ctx context.Context, address string) (client.Service, error) {
+		return http.New(ctx,
+			// WithAddress supplies the address of the beacon node, in host:port format.
+			http.WithAddress(address),
+			// LogLevel supplies the level of logging to carry out.
+			http.WithLogLevel(zerolog.DebugLevel),
+			http.WithTimeout(requestTimeout),
+		)
+	}
+	eps, err := newEndpoints(opt.Context, logger, parseEndpoints(opt.BeaconNodeAddr), connect)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to create http client")
 	}
 
-	logger = logger.With(zap.String("name", httpClient.Name()), zap.String("address", httpClient.Address()))
+	logger = logger.With(zap.String("name", eps.client().Name()), zap.String("address", eps.activeAddress()))
 	logger.Info("successfully connected to beacon client")
 
 	network := beaconprotocol.NewNetwork(core.NetworkFromString(opt.Network))
+	if opt.EpochsPerSyncCommitteePeriod > 0 {
+		network.EpochsPerSyncCommitteePeriod = opt.EpochsPerSyncCommitteePeriod
+	}
 	_client := &goClient{
 		ctx:            opt.Context,
 		logger:         logger,
 		network:        network,
-		client:         httpClient,
+		endpoints:      eps,
 		indicesMapLock: sync.Mutex{},
 		graffiti:       opt.Graffiti,
+		requestTimeout: requestTimeout,
+		requestRetries: opt.RequestRetries,
+	}
+
+	version, issues := checkCompatibility(opt.Context, eps.client(), network)
+	if len(issues) > 0 {
+		logger.Error("beacon node is not compatible", zap.String("version", version), zap.Strings("issues", issues))
+	} else {
+		logger.Info("beacon node is compatible", zap.String("version", version))
 	}
+	_client.compatibilityIssues = issues
+
+	eps.start(endpointsCheckInterval)
 
 	return _client, nil
 }
 
-// HealthCheck provides health status of beacon node
+// client returns the client of the active beacon node endpoint
+func (gc *goClient) client() client.Service {
+	if gc.endpoints == nil {
+		return nil
+	}
+	return gc.endpoints.client()
+}
+
+// HealthCheck provides health status of beacon node,
+// in case the active endpoint is not healthy we try to fail over to another endpoint
 func (gc *goClient) HealthCheck() []string {
-	if gc.client == nil {
+	if gc.client() == nil {
 		return []string{"not connected to beacon node"}
 	}
-	if provider, isProvider := gc.client.(eth2client.NodeSyncingProvider); isProvider {
-		ctx, cancel := context.WithTimeout(gc.ctx, healthCheckTimeout)
+	if len(gc.compatibilityIssues) > 0 {
+		metricsBeaconNodeStatus.Set(float64(statusUnknown))
+		return gc.compatibilityIssues
+	}
+	status, issues := nodeHealth(gc.ctx, gc.client())
+	if status != statusOK && gc.endpoints.size() > 1 && gc.endpoints.selectEndpoint() {
+		gc.logger.Warn("beacon node is not healthy, failed over to another endpoint",
+			zap.Strings("issues", issues), zap.String("address", gc.endpoints.activeAddress()))
+		status, issues = nodeHealth(gc.ctx, gc.client())
+	}
+	metricsBeaconNodeStatus.Set(float64(status))
+	return issues
+}
+
+// nodeHealth checks the sync state of the given beacon node
+func nodeHealth(ctx context.Context, svc client.Service) (beaconNodeStatus, []string) {
+	if provider, isProvider := svc.(eth2client.NodeSyncingProvider); isProvider {
+		ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
 		defer cancel()
 		syncState, err := provider.NodeSyncing(ctx)
 		if err != nil {
-			metricsBeaconNodeStatus.Set(float64(statusUnknown))
-			return []string{"could not get beacon node sync state"}
+			return statusUnknown, []string{"could not get beacon node sync state"}
 		}
 		if syncState != nil && syncState.IsSyncing {
-			metricsBeaconNodeStatus.Set(float64(statusSyncing))
-			return []string{fmt.Sprintf("beacon node is currently syncing: head=%d, distance=%d",
+			return statusSyncing, []string{fmt.Sprintf("beacon node is currently syncing: head=%d, distance=%d",
 				syncState.HeadSlot, syncState.SyncDistance)}
 		}
 	}
-	metricsBeaconNodeStatus.Set(float64(statusOK))
-	return []string{}
+	return statusOK, []string{}
 }
 
 func (gc *goClient) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*spectypes.Duty, error) {
-	if provider, isProvider := gc.client.(eth2client.AttesterDutiesProvider); isProvider {
-		attesterDuties, err := provider.AttesterDuties(gc.ctx, epoch, validatorIndices)
-		if err != nil {
-			return nil, err
-		}
-		var duties []*spectypes.Duty
-		for _, attesterDuty := range attesterDuties {
-			duties = append(duties, &spectypes.Duty{
-				Type:                    spectypes.BNRoleAttester,
-				PubKey:                  attesterDuty.PubKey,
-				Slot:                    attesterDuty.Slot,
-				ValidatorIndex:          attesterDuty.ValidatorIndex,
-				CommitteeIndex:          attesterDuty.CommitteeIndex,
-				CommitteeLength:         attesterDuty.CommitteeLength,
-				CommitteesAtSlot:        attesterDuty.CommitteesAtSlot,
-				ValidatorCommitteeIndex: attesterDuty.ValidatorCommitteeIndex,
-			})
+	var attesterDuties []*api.AttesterDuty
+	err := gc.doRequest("attester_duties", func(ctx context.Context) error {
+		// the provider is resolved on each attempt, as a failed attempt might switch the active endpoint
+		provider, isProvider := gc.client().(eth2client.AttesterDutiesProvider)
+		if !isProvider {
+			return errors.New("client does not support AttesterDutiesProvider")
 		}
-		return duties, nil
+		var err error
+		attesterDuties, err = provider.AttesterDuties(ctx, epoch, validatorIndices)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var duties []*spectypes.Duty
+	for _, attesterDuty := range attesterDuties {
+		duties = append(duties, &spectypes.Duty{
+			Type:                    spectypes.BNRoleAttester,
+			PubKey:                  attesterDuty.PubKey,
+			Slot:                    attesterDuty.Slot,
+			ValidatorIndex:          attesterDuty.ValidatorIndex,
+			CommitteeIndex:          attesterDuty.CommitteeIndex,
+			CommitteeLength:         attesterDuty.CommitteeLength,
+			CommitteesAtSlot:        attesterDuty.CommitteesAtSlot,
+			ValidatorCommitteeIndex: attesterDuty.ValidatorCommitteeIndex,
+		})
 	}
-	return nil, errors.New("client does not support AttesterDutiesProvider")
+	return duties, nil
 }
 
 // GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 func (gc *goClient) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
-	if provider, isProvider := gc.client.(eth2client.ValidatorsProvider); isProvider {
-		validatorsMap, err := provider.ValidatorsByPubKey(gc.ctx, "head", validatorPubKeys) // TODO maybe need to get the chainId (head) as var
-		if err != nil {
-			return nil, err
+	var validatorsMap map[spec.ValidatorIndex]*api.Validator
+	err := gc.doRequest("validators", func(ctx context.Context) error {
+		provider, isProvider := gc.client().(eth2client.ValidatorsProvider)
+		if !isProvider {
+			return errors.New("client does not support ValidatorsProvider")
 		}
-		return validatorsMap, nil
+		var err error
+		validatorsMap, err = provider.ValidatorsByPubKey(ctx, "head", validatorPubKeys) // TODO maybe need to get the chainId (head) as var
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("client does not support ValidatorsProvider")
+	return validatorsMap, nil
 }
 
 // waitOneThirdOrValidBlock waits until one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot)
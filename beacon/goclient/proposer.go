@@ -0,0 +1,20 @@
+package goclient
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/pkg/errors"
+)
+
+// SubmitProposalPreparation registers the fee recipients of the given validators in the beacon node
+func (gc *goClient) SubmitProposalPreparation(preparations []*api.ProposalPreparation) error {
+	return gc.doRequest("proposal_preparations", func(ctx context.Context) error {
+		submitter, isSubmitter := gc.client().(eth2client.ProposalPreparationsSubmitter)
+		if !isSubmitter {
+			return errors.New("client does not support ProposalPreparationsSubmitter")
+		}
+		return submitter.SubmitProposalPreparations(ctx, preparations)
+	})
+}
@@ -0,0 +1,66 @@
+package goclient
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	defaultRequestTimeout = 5 * time.Second
+)
+
+var (
+	metricsBeaconRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ssv:beacon:request_duration_seconds",
+		Help:    "Beacon node requests duration (seconds)",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10},
+	}, []string{"request"})
+	metricsBeaconRequestFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:beacon:request_failures",
+		Help: "Count of failed beacon node requests",
+	}, []string{"request"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsBeaconRequestDuration); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsBeaconRequestFailures); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
+
+// doRequest runs the given beacon request with a timeout,
+// failed requests are retried up to requestRetries times.
+// the request should resolve the client on each call, as a failed attempt might fail over to another endpoint
+func (gc *goClient) doRequest(name string, request func(ctx context.Context) error) error {
+	timeout := gc.requestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	var err error
+	for attempt := 0; attempt <= gc.requestRetries; attempt++ {
+		ctx, cancel := context.WithTimeout(gc.ctx, timeout)
+		start := time.Now()
+		err = request(ctx)
+		cancel()
+		metricsBeaconRequestDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		if err == nil {
+			return nil
+		}
+		metricsBeaconRequestFailures.WithLabelValues(name).Inc()
+		if gc.ctx.Err() != nil {
+			// parent context is done, no point in retrying
+			break
+		}
+		if gc.endpoints != nil && gc.endpoints.size() > 1 {
+			// the next requests will be sent to a healthy endpoint, if the active one is down
+			gc.endpoints.selectEndpoint()
+		}
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package goclient
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGoClient_RequestTimeout(t *testing.T) {
+	t.Run("timeout with retries", func(t *testing.T) {
+		svc := &mockBeaconService{delay: time.Second * 5}
+		gc := &goClient{
+			ctx:            context.Background(),
+			logger:         zap.L(),
+			endpoints:      newTestEndpoints(svc),
+			requestTimeout: 50 * time.Millisecond,
+			requestRetries: 2,
+		}
+		start := time.Now()
+		duties, err := gc.GetDuties(1, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Len(t, duties, 0)
+		require.Equal(t, int32(3), atomic.LoadInt32(&svc.calls))
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("request within timeout", func(t *testing.T) {
+		svc := &mockBeaconService{delay: 10 * time.Millisecond}
+		gc := &goClient{
+			ctx:            context.Background(),
+			logger:         zap.L(),
+			endpoints:      newTestEndpoints(svc),
+			requestTimeout: time.Second,
+			requestRetries: 2,
+		}
+		duties, err := gc.GetDuties(1, nil)
+		require.NoError(t, err)
+		require.Len(t, duties, 1)
+		require.Equal(t, int32(1), atomic.LoadInt32(&svc.calls))
+	})
+}
+
+func TestGoClient_RequestFailover(t *testing.T) {
+	primary := &mockBeaconService{delay: time.Second * 5, unhealthy: 1}
+	secondary := &mockBeaconService{}
+	gc := &goClient{
+		ctx:            context.Background(),
+		logger:         zap.L(),
+		endpoints:      newTestEndpoints(primary, secondary),
+		requestTimeout: 50 * time.Millisecond,
+		requestRetries: 1,
+	}
+	// the retry is sent to the endpoint that was selected after the first attempt failed
+	duties, err := gc.GetDuties(1, nil)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.Equal(t, int32(1), atomic.LoadInt32(&primary.calls))
+	require.Equal(t, int32(1), atomic.LoadInt32(&secondary.calls))
+}
@@ -1,6 +1,8 @@
 package goclient
 
 import (
+	"context"
+
 	eth2client "github.com/attestantio/go-eth2-client"
 	phase0spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -39,7 +41,7 @@ func (gc *goClient) GetDomain(data *phase0spec.AttestationData) ([]byte, error)
 
 // getDomainType returns domain type by role type
 func (gc *goClient) getDomainType(roleType spectypes.BeaconRole) (*phase0spec.DomainType, error) {
-	if provider, isProvider := gc.client.(eth2client.SpecProvider); isProvider {
+	if provider, isProvider := gc.client().(eth2client.SpecProvider); isProvider {
 		spec, err := provider.Spec(gc.ctx)
 		if err != nil {
 			return nil, err
@@ -68,14 +70,20 @@ func (gc *goClient) getDomainType(roleType spectypes.BeaconRole) (*phase0spec.Do
 
 // getDomainData return domain data by domain type
 func (gc *goClient) getDomainData(domainType *phase0spec.DomainType, epoch phase0spec.Epoch) (*phase0spec.Domain, error) { // TODO need to add cache (?)
-	if provider, isProvider := gc.client.(eth2client.DomainProvider); isProvider {
-		attestationData, err := provider.Domain(gc.ctx, *domainType, epoch)
-		if err != nil {
-			return nil, err
+	var attestationData phase0spec.Domain
+	err := gc.doRequest("domain", func(ctx context.Context) error {
+		provider, isProvider := gc.client().(eth2client.DomainProvider)
+		if !isProvider {
+			return errors.New("client does not support DomainProvider")
 		}
-		return &attestationData, nil
+		var err error
+		attestationData, err = provider.Domain(ctx, *domainType, epoch)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("client does not support DomainProvider")
+	return &attestationData, nil
 }
 
 // ComputeSigningRoot computes the root of the object by calculating the hash tree root of the signing data with the given domain.
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"encoding/hex"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// backupCmd is the command to create a point-in-time backup of the node storage
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "creates a backup of the node storage (shares, slashing protection, decided history), the node must be stopped while running this command",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+		filePath, err := flags.GetFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		db, _ := openNodeDB(cmd, logger, true)
+		defer db.Close()
+
+		checksum, err := storage.BackupToFile(db, filePath)
+		if err != nil {
+			logger.Fatal("failed to backup db", zap.Error(err))
+		}
+		logger.Info("created db backup", zap.String("file", filePath), zap.String("checksum", checksum))
+	},
+}
+
+// restoreCmd is the command to restore the node storage from a backup that was created by the backup command
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "restores the node storage from a backup, existing data is replaced while slashing protection is never lowered",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+		filePath, err := flags.GetFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		force, err := flags.GetForceFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get force flag value", zap.Error(err))
+		}
+		if err := storage.VerifyBackupFile(filePath); err != nil {
+			logger.Fatal("failed to verify backup", zap.Error(err))
+		}
+
+		// the backup is loaded into memory first, so its slashing protection can be compared with the current one
+		backupDB, err := storage.GetStorageFactory(basedb.Options{
+			Type:   "badger-memory",
+			Logger: logger,
+			Ctx:    cmd.Context(),
+		})
+		if err != nil {
+			logger.Fatal("failed to open in-memory db", zap.Error(err))
+		}
+		defer backupDB.Close()
+		if err := storage.RestoreFromFile(backupDB, filePath); err != nil {
+			logger.Fatal("failed to load backup", zap.Error(err))
+		}
+
+		db, network := openNodeDB(cmd, logger, false)
+		defer db.Close()
+
+		current, err := ekm.GetAllSlashingProtection(db, network)
+		if err != nil {
+			logger.Fatal("failed to get current slashing protection", zap.Error(err))
+		}
+		restored, err := ekm.GetAllSlashingProtection(backupDB, network)
+		if err != nil {
+			logger.Fatal("failed to get backup slashing protection", zap.Error(err))
+		}
+		var outdated []string
+		for pk, sp := range current {
+			lower, err := restored[pk].IsLowerThan(sp)
+			if err != nil {
+				logger.Fatal("failed to compare slashing protection", zap.Error(err), zap.String("sharePubKey", pk))
+			}
+			if lower {
+				outdated = append(outdated, pk)
+			}
+		}
+		if len(outdated) > 0 {
+			if !force {
+				logger.Fatal("backup is older than the current slashing protection, use --force to restore anyway",
+					zap.Strings("sharePubKeys", outdated))
+			}
+			logger.Warn("backup is older than the current slashing protection, current records will be kept",
+				zap.Strings("sharePubKeys", outdated))
+		}
+
+		if err := storage.RestoreFromFile(db, filePath); err != nil {
+			logger.Fatal("failed to restore db", zap.Error(err))
+		}
+		// re-apply the current records, restoring merges them so the highest values are kept
+		for pk, sp := range current {
+			sharePubKey, err := hex.DecodeString(pk)
+			if err != nil {
+				logger.Fatal("failed to decode share public key", zap.Error(err), zap.String("sharePubKey", pk))
+			}
+			if err := ekm.RestoreSlashingProtection(db, network, sharePubKey, sp); err != nil {
+				logger.Fatal("failed to restore slashing protection", zap.Error(err), zap.String("sharePubKey", pk))
+			}
+		}
+		logger.Info("restored db backup", zap.String("file", filePath))
+	},
+}
+
+func init() {
+	flags.AddDBPathFlag(backupCmd)
+	flags.AddNetworkFlag(backupCmd)
+	flags.AddFileFlag(backupCmd, "Path of the backup file to create")
+
+	flags.AddDBPathFlag(restoreCmd)
+	flags.AddNetworkFlag(restoreCmd)
+	flags.AddFileFlag(restoreCmd, "Path of the backup file to restore")
+	flags.AddForceFlag(restoreCmd, "Restore even if the backup slashing protection is older than the current one")
+
+	RootCmd.AddCommand(backupCmd)
+	RootCmd.AddCommand(restoreCmd)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +18,10 @@ type GlobalConfig struct {
 	LogLevel       string `yaml:"LogLevel" env:"LOG_LEVEL" env-default:"info" env-description:"Defines logger's log level'"`
 	LogFormat      string `yaml:"LogFormat" env:"LOG_FORMAT" env-default:"console" env-description:"Defines logger's encoding, valid values are 'console' (default) and 'json''"`
 	LogLevelFormat string `yaml:"LogLevelFormat" env:"LOG_LEVEL_FORMAT" env-default:"capitalColor" env-description:"Defines logger's level format, valid values are 'capitalColor' (default), 'capital' or 'lowercase''"`
+	// log sampling of high-frequency debug logs
+	LogSamplingTick       time.Duration `yaml:"LogSamplingTick" env:"LOG_SAMPLING_TICK" env-default:"1s" env-description:"Interval of sampling for high-frequency debug logs"`
+	LogSamplingInitial    int           `yaml:"LogSamplingInitial" env:"LOG_SAMPLING_INITIAL" env-default:"100" env-description:"Amount of high-frequency debug logs with the same message that are logged in every interval, 0 disables sampling"`
+	LogSamplingThereafter int           `yaml:"LogSamplingThereafter" env:"LOG_SAMPLING_THEREAFTER" env-default:"100" env-description:"Once the initial amount is reached, every Nth high-frequency debug log with the same message is logged"`
 }
 
 // ProcessArgs processes and handles CLI arguments
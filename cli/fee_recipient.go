@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// setFeeRecipientCmd is the command to override the fee recipient of a validator
+var setFeeRecipientCmd = &cobra.Command{
+	Use:   "set-fee-recipient",
+	Short: "sets the fee recipient of the given validator, the node must be stopped while running this command",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+		pkHex, err := flags.GetValidatorPublicKeyFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get validator public key flag value", zap.Error(err))
+		}
+		pk, err := hex.DecodeString(strings.TrimPrefix(pkHex, "0x"))
+		if err != nil {
+			logger.Fatal("failed to decode validator public key", zap.Error(err))
+		}
+		feeRecipientHex, err := flags.GetFeeRecipientFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get fee recipient flag value", zap.Error(err))
+		}
+		var feeRecipient common.Address
+		if len(feeRecipientHex) > 0 {
+			if !common.IsHexAddress(feeRecipientHex) {
+				logger.Fatal("invalid fee recipient address", zap.String("feeRecipient", feeRecipientHex))
+			}
+			feeRecipient = common.HexToAddress(feeRecipientHex)
+		}
+		db, _ := openNodeDB(cmd, logger, false)
+		defer db.Close()
+
+		collection := validator.NewCollection(validator.CollectionOptions{DB: db, Logger: logger})
+		if err := collection.SetValidatorFeeRecipient(pk, feeRecipient); err != nil {
+			logger.Fatal("failed to update validator", zap.Error(err))
+		}
+		logger.Info("updated validator fee recipient", zap.String("pubKey", hex.EncodeToString(pk)),
+			zap.String("feeRecipient", feeRecipient.Hex()))
+	},
+}
+
+func init() {
+	flags.AddDBPathFlag(setFeeRecipientCmd)
+	flags.AddNetworkFlag(setFeeRecipientCmd)
+	flags.AddValidatorPublicKeyFlag(setFeeRecipientCmd)
+	flags.AddFeeRecipientFlag(setFeeRecipientCmd)
+
+	RootCmd.AddCommand(setFeeRecipientCmd)
+}
@@ -0,0 +1,22 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	forceFlag = "force"
+)
+
+// AddForceFlag adds the force flag to the command
+func AddForceFlag(c *cobra.Command, description string) {
+	cliflag.AddPersistentBoolFlag(c, forceFlag, false, description, false)
+}
+
+// GetForceFlagValue gets the force flag from the command
+func GetForceFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(forceFlag)
+}
@@ -0,0 +1,44 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	hostAddressFlag = "host-address"
+	tcpPortFlag     = "tcp-port"
+	udpPortFlag     = "udp-port"
+)
+
+// AddHostAddressFlag adds the host address flag to the command
+func AddHostAddressFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, hostAddressFlag, "", "External ip of the node, detected if not provided", false)
+}
+
+// GetHostAddressFlagValue gets the host address flag from the command
+func GetHostAddressFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(hostAddressFlag)
+}
+
+// AddTCPPortFlag adds the tcp port flag to the command
+func AddTCPPortFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, tcpPortFlag, 13001, "TCP port for p2p transport", false)
+}
+
+// GetTCPPortFlagValue gets the tcp port flag from the command
+func GetTCPPortFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(tcpPortFlag)
+}
+
+// AddUDPPortFlag adds the udp port flag to the command
+func AddUDPPortFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, udpPortFlag, 12001, "UDP port for discovery", false)
+}
+
+// GetUDPPortFlagValue gets the udp port flag from the command
+func GetUDPPortFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(udpPortFlag)
+}
@@ -0,0 +1,44 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	dbPathFlag  = "db-path"
+	networkFlag = "network"
+	fileFlag    = "file"
+)
+
+// AddDBPathFlag adds the db path flag to the command
+func AddDBPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbPathFlag, "./data/db", "Path of the node storage", false)
+}
+
+// GetDBPathFlagValue gets the db path flag from the command
+func GetDBPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbPathFlag)
+}
+
+// AddNetworkFlag adds the eth2 network flag to the command
+func AddNetworkFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, networkFlag, "prater", "ETH2 network of the node", false)
+}
+
+// GetNetworkFlagValue gets the eth2 network flag from the command
+func GetNetworkFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(networkFlag)
+}
+
+// AddFileFlag adds the file flag to the command
+func AddFileFlag(c *cobra.Command, description string) {
+	cliflag.AddPersistentStringFlag(c, fileFlag, "", description, true)
+}
+
+// GetFileFlagValue gets the file flag from the command
+func GetFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(fileFlag)
+}
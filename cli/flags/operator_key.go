@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	passwordFlag = "password"
+	persistFlag  = "persist"
+)
+
+// AddPasswordFlag adds the password flag to the command
+func AddPasswordFlag(c *cobra.Command, description string) {
+	cliflag.AddPersistentStringFlag(c, passwordFlag, "", description, false)
+}
+
+// GetPasswordFlagValue gets the password flag from the command
+func GetPasswordFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(passwordFlag)
+}
+
+// AddPersistFlag adds the persist flag to the command
+func AddPersistFlag(c *cobra.Command, description string) {
+	cliflag.AddPersistentBoolFlag(c, persistFlag, false, description, false)
+}
+
+// GetPersistFlagValue gets the persist flag from the command
+func GetPersistFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(persistFlag)
+}
@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	configPathFlag = "config"
+	applyFlag      = "apply"
+)
+
+// AddConfigPathFlag adds the node config path flag to the command
+func AddConfigPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, configPathFlag, "./config/config.yaml", "Path of the node configuration file", false)
+}
+
+// GetConfigPathFlagValue gets the node config path flag from the command
+func GetConfigPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(configPathFlag)
+}
+
+// AddApplyFlag adds the apply flag to the command
+func AddApplyFlag(c *cobra.Command, description string) {
+	cliflag.AddPersistentBoolFlag(c, applyFlag, false, description, false)
+}
+
+// GetApplyFlagValue gets the apply flag from the command
+func GetApplyFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(applyFlag)
+}
@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	validatorPublicKeyFlag = "validator-public-key"
+	feeRecipientFlag       = "fee-recipient"
+)
+
+// AddValidatorPublicKeyFlag adds the validator public key flag to the command
+func AddValidatorPublicKeyFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, validatorPublicKeyFlag, "", "Hex encoded public key of the validator", true)
+}
+
+// GetValidatorPublicKeyFlagValue gets the validator public key flag from the command
+func GetValidatorPublicKeyFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(validatorPublicKeyFlag)
+}
+
+// AddFeeRecipientFlag adds the fee recipient flag to the command
+func AddFeeRecipientFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, feeRecipientFlag, "", "Fee recipient address of block proposals, empty value removes the override", false)
+}
+
+// GetFeeRecipientFlagValue gets the fee recipient flag from the command
+func GetFeeRecipientFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(feeRecipientFlag)
+}
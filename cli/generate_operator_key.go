@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/base64"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+// generateOperatorKeyCmd is the command to generate an operator key w/o starting the node
+var generateOperatorKeyCmd = &cobra.Command{
+	Use:   "generate-operator-key",
+	Short: "generates ssv operator key, optionally encrypted with a password and persisted to the node storage",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+		password, err := flags.GetPasswordFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get password flag value", zap.Error(err))
+		}
+		persist, err := flags.GetPersistFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get persist flag value", zap.Error(err))
+		}
+
+		pk, sk, err := rsaencryption.GenerateKeys()
+		if err != nil {
+			logger.Fatal("failed to generate operator key", zap.Error(err))
+		}
+
+		if persist {
+			db, _ := openNodeDB(cmd, logger, false)
+			defer db.Close()
+			nodeStorage := operatorstorage.NewNodeStorage(db, logger)
+			// an existing key is never overridden, as it would break the shares of the operator
+			if _, found, err := nodeStorage.GetPrivateKey(); err != nil || found {
+				logger.Fatal("operator key already exists in the node storage", zap.Error(err))
+			}
+			// the node reads an unencrypted key, therefore the password is not applied on the stored key
+			if err := nodeStorage.SetupPrivateKey(false, base64.StdEncoding.EncodeToString(sk)); err != nil {
+				logger.Fatal("failed to persist operator key", zap.Error(err))
+			}
+		}
+
+		if len(password) > 0 {
+			if sk, err = rsaencryption.EncryptPrivateKey(sk, password); err != nil {
+				logger.Fatal("failed to encrypt operator key", zap.Error(err))
+			}
+		}
+		logger.Info("generated public key (base64)", zap.String("pk", base64.StdEncoding.EncodeToString(pk)))
+		logger.Info("generated private key (base64)", zap.String("sk", base64.StdEncoding.EncodeToString(sk)),
+			zap.Bool("encrypted", len(password) > 0), zap.Bool("persisted", persist))
+	},
+}
+
+func init() {
+	flags.AddPasswordFlag(generateOperatorKeyCmd, "Password to encrypt the printed private key")
+	flags.AddPersistFlag(generateOperatorKeyCmd, "Whether to save the key into the node storage")
+	flags.AddDBPathFlag(generateOperatorKeyCmd)
+	flags.AddNetworkFlag(generateOperatorKeyCmd)
+
+	RootCmd.AddCommand(generateOperatorKeyCmd)
+}
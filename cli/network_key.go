@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"net"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	ssv_identity "github.com/bloxapp/ssv/identity"
+	"github.com/bloxapp/ssv/network/commons"
+	"github.com/bloxapp/ssv/network/discovery"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// networkKeyCmd is the command to generate (or read the existing) network key and print the node identity
+var networkKeyCmd = &cobra.Command{
+	Use:   "network-key",
+	Short: "generates a network key if none exists in the node storage, and prints the peer id and ENR of the node",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+		hostAddress, err := flags.GetHostAddressFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get host address flag value", zap.Error(err))
+		}
+		tcpPort, err := flags.GetTCPPortFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get tcp port flag value", zap.Error(err))
+		}
+		udpPort, err := flags.GetUDPPortFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get udp port flag value", zap.Error(err))
+		}
+		ipAddr := net.ParseIP(hostAddress)
+		if len(hostAddress) == 0 {
+			if ipAddr, err = commons.IPAddr(); err != nil {
+				logger.Fatal("failed to get ip address", zap.Error(err))
+			}
+		}
+		if ipAddr == nil {
+			logger.Fatal("invalid host address", zap.String("address", hostAddress))
+		}
+
+		db, _ := openNodeDB(cmd, logger, false)
+		defer db.Close()
+		netPrivKey, err := ssv_identity.NewIdentityStore(db, logger).SetupNetworkKey("")
+		if err != nil {
+			logger.Fatal("failed to setup network private key", zap.Error(err))
+		}
+
+		node, err := discovery.NodeRecord(netPrivKey, ipAddr, int(udpPort), int(tcpPort))
+		if err != nil {
+			logger.Fatal("failed to create node record", zap.Error(err))
+		}
+		peerID, err := discovery.PeerID(node)
+		if err != nil {
+			logger.Fatal("failed to get peer id", zap.Error(err))
+		}
+		logger.Info("node identity", zap.String("peerID", peerID.String()), zap.String("enr", node.String()))
+	},
+}
+
+func init() {
+	flags.AddDBPathFlag(networkKeyCmd)
+	flags.AddNetworkFlag(networkKeyCmd)
+	flags.AddHostAddressFlag(networkKeyCmd)
+	flags.AddTCPPortFlag(networkKeyCmd)
+	flags.AddUDPPortFlag(networkKeyCmd)
+
+	RootCmd.AddCommand(networkKeyCmd)
+}
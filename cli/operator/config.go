@@ -0,0 +1,89 @@
+package operator
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+const maxPort = 65535
+
+// Validate checks the required fields and the constraints between fields of the config,
+// all the problems that were found are returned in a single error
+func (c *config) Validate() error {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch c.DBOptions.Type {
+	case "badger-db":
+		if len(c.DBOptions.Path) == 0 {
+			addProblem("db path is required for %s", c.DBOptions.Type)
+		}
+	case "badger-memory":
+	default:
+		addProblem("unsupported db type %q", c.DBOptions.Type)
+	}
+
+	if core.NetworkFromString(c.ETH2Options.Network) == "" {
+		addProblem("unknown eth2 network %q", c.ETH2Options.Network)
+	}
+	if len(strings.TrimSpace(c.ETH2Options.BeaconNodeAddr)) == 0 {
+		addProblem("beacon node address is required")
+	} else {
+		for _, addr := range strings.Split(c.ETH2Options.BeaconNodeAddr, ",") {
+			if len(strings.TrimSpace(addr)) == 0 {
+				addProblem("beacon node address list %q contains an empty address", c.ETH2Options.BeaconNodeAddr)
+				break
+			}
+		}
+	}
+
+	if len(c.ETH1Options.ETH1Addr) == 0 {
+		addProblem("eth1 node address is required")
+	}
+	if !common.IsHexAddress(c.ETH1Options.RegistryContractAddr) {
+		addProblem("invalid registry contract address %q", c.ETH1Options.RegistryContractAddr)
+	}
+	if len(c.ETH1Options.ETH1SyncOffset) > 0 {
+		if _, ok := new(big.Int).SetString(c.ETH1Options.ETH1SyncOffset, 16); !ok {
+			addProblem("invalid eth1 sync offset %q, expected a hex block number", c.ETH1Options.ETH1SyncOffset)
+		}
+	}
+
+	if len(c.OperatorPrivateKey) > 0 {
+		if _, err := base64.StdEncoding.DecodeString(c.OperatorPrivateKey); err != nil {
+			addProblem("operator private key is not base64 encoded")
+		}
+		if c.GenerateOperatorPrivateKey {
+			addProblem("operator private key can't be passed together with generating a new one")
+		}
+	}
+	if feeRecipient := c.SSVOptions.ValidatorOptions.DefaultFeeRecipient; len(feeRecipient) > 0 && !common.IsHexAddress(feeRecipient) {
+		addProblem("invalid default fee recipient %q", feeRecipient)
+	}
+
+	if c.MetricsAPIPort < 0 || c.MetricsAPIPort > maxPort {
+		addProblem("invalid metrics api port %d", c.MetricsAPIPort)
+	}
+	if c.WsAPIPort < 0 || c.WsAPIPort > maxPort {
+		addProblem("invalid ws api port %d", c.WsAPIPort)
+	}
+	if c.MetricsAPIPort > 0 && c.MetricsAPIPort == c.WsAPIPort {
+		addProblem("metrics api and ws api can't use the same port %d", c.MetricsAPIPort)
+	}
+	if c.EnableProfile && c.MetricsAPIPort == 0 {
+		addProblem("profiling is served by the metrics api, which requires a metrics api port")
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() config {
+	c := config{}
+	c.DBOptions.Type = "badger-db"
+	c.DBOptions.Path = "./data/db"
+	c.ETH2Options.Network = "prater"
+	c.ETH2Options.BeaconNodeAddr = "http://localhost:5052,http://localhost:5053"
+	c.ETH1Options.ETH1Addr = "ws://localhost:8546"
+	c.ETH1Options.RegistryContractAddr = "0xb9e155e65B5c4D66df28Da8E9a0957f06F11Bc04"
+	c.ETH1Options.ETH1SyncOffset = "6F31E9"
+	c.MetricsAPIPort = 15000
+	c.WsAPIPort = 16000
+	return c
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		c := validConfig()
+		require.NoError(t, c.Validate())
+	})
+
+	tests := []struct {
+		name   string
+		modify func(c *config)
+		errs   []string
+	}{
+		{
+			name: "unknown network",
+			modify: func(c *config) {
+				c.ETH2Options.Network = "unknown"
+			},
+			errs: []string{`unknown eth2 network "unknown"`},
+		},
+		{
+			name: "missing beacon node address",
+			modify: func(c *config) {
+				c.ETH2Options.BeaconNodeAddr = ""
+			},
+			errs: []string{"beacon node address is required"},
+		},
+		{
+			name: "empty failover beacon node address",
+			modify: func(c *config) {
+				c.ETH2Options.BeaconNodeAddr = "http://localhost:5052,"
+			},
+			errs: []string{"contains an empty address"},
+		},
+		{
+			name: "bad registry address",
+			modify: func(c *config) {
+				c.ETH1Options.RegistryContractAddr = "0x1234"
+			},
+			errs: []string{`invalid registry contract address "0x1234"`},
+		},
+		{
+			name: "conflicting operator key options",
+			modify: func(c *config) {
+				c.OperatorPrivateKey = "not base64!"
+				c.GenerateOperatorPrivateKey = true
+			},
+			errs: []string{
+				"operator private key is not base64 encoded",
+				"operator private key can't be passed together with generating a new one",
+			},
+		},
+		{
+			name: "same ports",
+			modify: func(c *config) {
+				c.WsAPIPort = c.MetricsAPIPort
+			},
+			errs: []string{"metrics api and ws api can't use the same port 15000"},
+		},
+		{
+			name: "profiling w/o metrics",
+			modify: func(c *config) {
+				c.EnableProfile = true
+				c.MetricsAPIPort = 0
+			},
+			errs: []string{"profiling is served by the metrics api"},
+		},
+		{
+			name: "multiple problems are aggregated",
+			modify: func(c *config) {
+				c.DBOptions.Type = "leveldb"
+				c.ETH1Options.ETH1Addr = ""
+				c.ETH1Options.ETH1SyncOffset = "xyz"
+				c.SSVOptions.ValidatorOptions.DefaultFeeRecipient = "0xinvalid"
+			},
+			errs: []string{
+				`unsupported db type "leveldb"`,
+				"eth1 node address is required",
+				`invalid eth1 sync offset "xyz"`,
+				`invalid default fee recipient "0xinvalid"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := validConfig()
+			test.modify(&c)
+			err := c.Validate()
+			require.Error(t, err)
+			for _, e := range test.errs {
+				require.Contains(t, err.Error(), e)
+			}
+		})
+	}
+}
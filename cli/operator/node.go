@@ -6,6 +6,9 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/types"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/bloxapp/eth2-key-manager/core"
@@ -80,6 +83,9 @@ var StartNodeCmd = &cobra.Command{
 				log.Fatalf("could not read share config %s", err)
 			}
 		}
+		if err := cfg.Validate(); err != nil {
+			log.Fatalf("%s", err)
+		}
 		loggerLevel, errLogLevel := logex.GetLoggerLevelValue(cfg.LogLevel)
 		Logger := logex.Build(commons.GetBuildData(), loggerLevel, &logex.EncodingConfig{
 			Format:       cfg.GlobalConfig.LogFormat,
@@ -88,6 +94,11 @@ var StartNodeCmd = &cobra.Command{
 		if errLogLevel != nil {
 			Logger.Warn(fmt.Sprintf("Default log level set to %s", loggerLevel), zap.Error(errLogLevel))
 		}
+		logex.SetSampling(&logex.SamplingConfig{
+			Tick:       cfg.LogSamplingTick,
+			Initial:    cfg.LogSamplingInitial,
+			Thereafter: cfg.LogSamplingThereafter,
+		})
 
 		cfg.DBOptions.Logger = Logger
 		cfg.DBOptions.Ctx = cmd.Context()
@@ -96,16 +107,6 @@ var StartNodeCmd = &cobra.Command{
 			Logger.Fatal("failed to create db!", zap.Error(err))
 		}
 
-		migrationOpts := migrations.Options{
-			Db:     db,
-			Logger: Logger,
-			DbPath: cfg.DBOptions.Path,
-		}
-		err = migrations.Run(cmd.Context(), migrationOpts)
-		if err != nil {
-			Logger.Fatal("failed to run migrations", zap.Error(err))
-		}
-
 		if len(cfg.P2pNetworkConfig.NetworkID) == 0 {
 			cfg.P2pNetworkConfig.NetworkID = string(types.GetDefaultDomain())
 		} else {
@@ -120,10 +121,22 @@ var StartNodeCmd = &cobra.Command{
 		currentEpoch := slots.EpochsSinceGenesis(time.Unix(int64(eth2Network.MinGenesisTime()), 0))
 		ssvForkVersion := forksprotocol.GetCurrentForkVersion(currentEpoch)
 		Logger.Info("using ssv fork version", zap.String("version", string(ssvForkVersion)))
+
+		migrationOpts := migrations.Options{
+			Db:          db,
+			Logger:      Logger,
+			DbPath:      cfg.DBOptions.Path,
+			ForkVersion: ssvForkVersion,
+		}
+		err = migrations.Run(cmd.Context(), migrationOpts)
+		if err != nil {
+			Logger.Fatal("failed to run migrations", zap.Error(err))
+		}
+
 		// TODO Not refactored yet Start (refactor in exporter as well):
 		cfg.ETH2Options.Context = cmd.Context()
 		cfg.ETH2Options.Logger = Logger
-		cfg.ETH2Options.Graffiti = []byte("SSV.Network")
+		cfg.ETH2Options.Graffiti = commons.BuildGraffiti("SSV.Network", cfg.ETH2Options.GraffitiWithVersion)
 		cfg.ETH2Options.DB = db
 		beaconClient, err := goclient.New(cfg.ETH2Options)
 		if err != nil {
@@ -131,9 +144,13 @@ var StartNodeCmd = &cobra.Command{
 				zap.String("addr", cfg.ETH2Options.BeaconNodeAddr))
 		}
 
-		keyManager, err := ekm.NewETHKeyManagerSigner(db, beaconClient, eth2Network, types.GetDefaultDomain())
-		if err != nil {
-			Logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+		// key manager is not needed in read-only mode as validators are not signing
+		var keyManager spectypes.KeyManager
+		if !cfg.SSVOptions.ValidatorOptions.ReadOnly {
+			keyManager, err = ekm.NewETHKeyManagerSigner(db, beaconClient, eth2Network, types.GetDefaultDomain())
+			if err != nil {
+				Logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+			}
 		}
 
 		nodeStorage := operatorstorage.NewNodeStorage(db, Logger)
@@ -159,10 +176,14 @@ var StartNodeCmd = &cobra.Command{
 			subnets := getNodeSubnets(Logger, db, ssvForkVersion, operatorPubKey)
 			cfg.P2pNetworkConfig.Subnets = subnets.String()
 		}
+		if len(cfg.P2pNetworkConfig.StaticSubnets) > 0 {
+			cfg.P2pNetworkConfig.Subnets = mergeStaticSubnets(Logger, ssvForkVersion, cfg.P2pNetworkConfig.Subnets, cfg.P2pNetworkConfig.StaticSubnets)
+		}
 
 		cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
 		cfg.P2pNetworkConfig.Logger = Logger
 		cfg.P2pNetworkConfig.ForkVersion = ssvForkVersion
+		cfg.P2pNetworkConfig.MaxValueSize = cfg.SSVOptions.ValidatorOptions.MaxValueSize
 		cfg.P2pNetworkConfig.OperatorID = format.OperatorID(operatorPubKey)
 
 		p2pNet := p2pv1.New(cmd.Context(), &cfg.P2pNetworkConfig)
@@ -243,6 +264,7 @@ var StartNodeCmd = &cobra.Command{
 		if err := p2pNet.Start(); err != nil {
 			Logger.Fatal("failed to start network", zap.Error(err))
 		}
+		drainOnShutdown(Logger, operatorNode, cfg.SSVOptions.DrainTimeout)
 		if err := operatorNode.Start(); err != nil {
 			Logger.Fatal("failed to start SSV node", zap.Error(err))
 		}
@@ -263,6 +285,26 @@ func startMetricsHandler(ctx context.Context, logger *zap.Logger, port int, enab
 	}
 }
 
+// drainOnShutdown enables drain mode once a shutdown signal is received, so running duties can complete,
+// and exits once the given timeout has passed or another signal is received
+func drainOnShutdown(logger *zap.Logger, node operator.Node, timeout time.Duration) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		logger.Info("shutdown signal was received, draining", zap.String("signal", sig.String()),
+			zap.Duration("timeout", timeout))
+		node.SetDrainMode(true)
+		select {
+		case <-time.After(timeout):
+		case sig = <-signals:
+			logger.Warn("another shutdown signal was received, exiting", zap.String("signal", sig.String()))
+		}
+		logger.Info("exiting")
+		os.Exit(0)
+	}()
+}
+
 // getNodeSubnets reads all shares and calculates the subnets for this node
 // note that we'll trigger another update once finished processing registry events
 func getNodeSubnets(logger *zap.Logger, db basedb.IDb, ssvForkVersion forksprotocol.ForkVersion, operatorPubKey string) records.Subnets {
@@ -292,3 +334,19 @@ func getNodeSubnets(logger *zap.Logger, db basedb.IDb, ssvForkVersion forksproto
 	}
 	return subnets
 }
+
+// mergeStaticSubnets merges the configured static subnets into the given subnets string
+func mergeStaticSubnets(logger *zap.Logger, ssvForkVersion forksprotocol.ForkVersion, subnetsStr string, staticSubnets []int) string {
+	f := forksfactory.NewFork(ssvForkVersion)
+	static, err := records.StaticSubnets(f.Subnets(), staticSubnets)
+	if err != nil {
+		logger.Warn("could not parse static subnets", zap.Error(err))
+		return subnetsStr
+	}
+	subnets, err := records.Subnets{}.FromString(subnetsStr)
+	if err != nil {
+		logger.Warn("could not parse subnets", zap.Error(err))
+		return subnetsStr
+	}
+	return records.MergeSubnets(subnets, static).String()
+}
@@ -0,0 +1,68 @@
+package operator
+
+import (
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	forksfactory "github.com/bloxapp/ssv/network/forks/factory"
+	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/network/records"
+	networktesting "github.com/bloxapp/ssv/network/testing"
+	"github.com/bloxapp/ssv/operator/validator"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	testingprotocol "github.com/bloxapp/ssv/protocol/v1/testing"
+)
+
+func TestNodeSubnets_SyntheticFork(t *testing.T) {
+	const operatorPubKey = "operator-pk"
+	const syntheticForkVersion = forksprotocol.ForkVersion("synthetic")
+
+	sks, nodes := testingprotocol.GenerateBLSKeys(1, 2, 3, 4)
+	db := testingprotocol.NewInMemDb()
+	collection := validator.NewCollection(validator.CollectionOptions{DB: db, Logger: zap.L()})
+	subnets := make(map[string]int)
+	for oid, subnet := range map[spectypes.OperatorID]int{1: 1, 2: 3, 3: 2} {
+		share := &beacon.Share{
+			NodeID:    1,
+			PublicKey: sks[oid].GetPublicKey(),
+			Committee: nodes,
+		}
+		if oid != 3 {
+			share.Operators = [][]byte{[]byte(operatorPubKey)}
+		}
+		require.NoError(t, collection.SaveValidatorShare(share))
+		subnets[share.PublicKey.SerializeToHexStr()] = subnet
+	}
+
+	t.Run("synthetic fork", func(t *testing.T) {
+		fork := networktesting.NewSyntheticFork()
+		fork.SubnetsCount = 4
+		fork.SubnetOf = func(validatorPKHex string) int {
+			subnet, ok := subnets[validatorPKHex]
+			if !ok {
+				return -1
+			}
+			return subnet
+		}
+		networktesting.RegisterSyntheticFork(t, syntheticForkVersion, fork)
+		require.Equal(t, fork, forksfactory.NewFork(syntheticForkVersion))
+
+		nodeSubnets := getNodeSubnets(zap.L(), db, syntheticForkVersion, operatorPubKey)
+		require.Equal(t, records.Subnets{0, 1, 0, 1}, nodeSubnets)
+
+		merged := mergeStaticSubnets(zap.L(), syntheticForkVersion, nodeSubnets.String(), []int{0})
+		require.Equal(t, records.Subnets{1, 1, 0, 1}.String(), merged)
+
+		// static subnets are validated against the subnets count of the fork
+		require.Equal(t, nodeSubnets.String(),
+			mergeStaticSubnets(zap.L(), syntheticForkVersion, nodeSubnets.String(), []int{4}))
+	})
+
+	// the synthetic fork is unregistered once the test is done
+	require.IsType(t, &genesis.ForkGenesis{}, forksfactory.NewFork(syntheticForkVersion))
+	require.Len(t, getNodeSubnets(zap.L(), db, syntheticForkVersion, operatorPubKey), 128)
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// pauseValidatorCmd is the command to locally pause a validator, duties of paused validators are skipped
+var pauseValidatorCmd = &cobra.Command{
+	Use:   "pause-validator",
+	Short: "pauses the given validator so its duties are skipped, the node must be stopped while running this command",
+	Run: func(cmd *cobra.Command, args []string) {
+		setValidatorPaused(cmd, true)
+	},
+}
+
+// resumeValidatorCmd is the command to resume a validator that was paused by pause-validator
+var resumeValidatorCmd = &cobra.Command{
+	Use:   "resume-validator",
+	Short: "resumes the given paused validator, the node must be stopped while running this command",
+	Run: func(cmd *cobra.Command, args []string) {
+		setValidatorPaused(cmd, false)
+	},
+}
+
+func setValidatorPaused(cmd *cobra.Command, paused bool) {
+	logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+	pkHex, err := flags.GetValidatorPublicKeyFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get validator public key flag value", zap.Error(err))
+	}
+	pk, err := hex.DecodeString(strings.TrimPrefix(pkHex, "0x"))
+	if err != nil {
+		logger.Fatal("failed to decode validator public key", zap.Error(err))
+	}
+	db, _ := openNodeDB(cmd, logger, false)
+	defer db.Close()
+
+	collection := validator.NewCollection(validator.CollectionOptions{DB: db, Logger: logger})
+	if err := collection.SetValidatorPaused(pk, paused); err != nil {
+		logger.Fatal("failed to update validator", zap.Error(err))
+	}
+	logger.Info("updated validator", zap.String("pubKey", hex.EncodeToString(pk)), zap.Bool("paused", paused))
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{pauseValidatorCmd, resumeValidatorCmd} {
+		flags.AddDBPathFlag(cmd)
+		flags.AddNetworkFlag(cmd)
+		flags.AddValidatorPublicKeyFlag(cmd)
+		RootCmd.AddCommand(cmd)
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/goeth"
+	qbftstorage "github.com/bloxapp/ssv/ibft/storage"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validator"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// reconcileSharesConfig is the part of the node config that is used by reconcile-shares
+type reconcileSharesConfig struct {
+	ETH1Options eth1.Options `yaml:"eth1"`
+}
+
+// reconcileSharesCmd is the command to compare the stored shares with the contract state
+var reconcileSharesCmd = &cobra.Command{
+	Use:   "reconcile-shares",
+	Short: "re-syncs contract events and reports the differences with the stored shares, fixes storage only if --apply is passed",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+		threshold.Init()
+
+		apply, err := flags.GetApplyFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get apply flag value", zap.Error(err))
+		}
+		configPath, err := flags.GetConfigPathFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get config path flag value", zap.Error(err))
+		}
+		// eth1 options are taken from the node config
+		var cfg reconcileSharesConfig
+		if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+			logger.Fatal("failed to read node config", zap.Error(err))
+		}
+
+		db, network := openNodeDB(cmd, logger, !apply)
+		defer db.Close()
+		nodeStorage := operatorstorage.NewNodeStorage(db, logger)
+		operatorKey, found, err := nodeStorage.GetPrivateKey()
+		if err != nil || !found {
+			logger.Fatal("failed to get operator private key", zap.Error(err))
+		}
+		operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorKey)
+		if err != nil {
+			logger.Fatal("failed to extract operator public key", zap.Error(err))
+		}
+
+		// operators are synced into a temporary in-memory storage
+		tmpDB, err := storage.GetStorageFactory(basedb.Options{
+			Type:   "badger-memory",
+			Logger: logger,
+			Ctx:    cmd.Context(),
+		})
+		if err != nil {
+			logger.Fatal("failed to create temporary db", zap.Error(err))
+		}
+		defer tmpDB.Close()
+		reconciler := validator.NewSharesReconciler(logger, operatorPubKey,
+			registrystorage.NewOperatorsStorage(tmpDB, logger, []byte("operator")))
+
+		if len(cfg.ETH1Options.RegistryContractABI) > 0 {
+			if err := eth1.LoadABI(cfg.ETH1Options.RegistryContractABI); err != nil {
+				logger.Fatal("failed to load ABI JSON", zap.Error(err))
+			}
+		}
+		eth1Client, err := goeth.NewEth1Client(goeth.ClientOptions{
+			Ctx:                  cmd.Context(),
+			Logger:               logger,
+			NodeAddr:             cfg.ETH1Options.ETH1Addr,
+			ConnectionTimeout:    cfg.ETH1Options.ETH1ConnectionTimeout,
+			ContractABI:          eth1.ContractABI(cfg.ETH1Options.AbiVersion),
+			RegistryContractAddr: cfg.ETH1Options.RegistryContractAddr,
+			AbiVersion:           cfg.ETH1Options.AbiVersion,
+		})
+		if err != nil {
+			logger.Fatal("failed to create eth1 client", zap.Error(err))
+		}
+		if err := reconciler.Sync(eth1Client, eth1.HexStringToSyncOffset(cfg.ETH1Options.ETH1SyncOffset)); err != nil {
+			logger.Fatal("failed to sync contract events", zap.Error(err))
+		}
+
+		collection := validator.NewCollection(validator.CollectionOptions{DB: db, Logger: logger})
+		diff, err := reconciler.Diff(collection)
+		if err != nil {
+			logger.Fatal("failed to diff shares", zap.Error(err))
+		}
+		logger.Info("shares reconciliation",
+			zap.Strings("missing", diff.Missing),
+			zap.Strings("extra", diff.Extra),
+			zap.Strings("liquidatedMismatch", diff.LiquidatedMismatch))
+		if !apply || diff.Empty() {
+			return
+		}
+
+		keyManager, err := ekm.NewETHKeyManagerSigner(db, nil, network, types.GetDefaultDomain())
+		if err != nil {
+			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+		}
+		ibftStorage := qbftstorage.New(db, logger, spectypes.BNRoleAttester.String(), forksprotocol.GenesisForkVersion)
+		if err := reconciler.Apply(diff, collection, ibftStorage, keyManager, nodeStorage.GetPrivateKey); err != nil {
+			logger.Fatal("failed to apply shares reconciliation", zap.Error(err))
+		}
+		logger.Info("applied shares reconciliation")
+	},
+}
+
+func init() {
+	flags.AddDBPathFlag(reconcileSharesCmd)
+	flags.AddNetworkFlag(reconcileSharesCmd)
+	flags.AddConfigPathFlag(reconcileSharesCmd)
+	flags.AddApplyFlag(reconcileSharesCmd, "Whether to fix the stored shares according to the contract")
+
+	RootCmd.AddCommand(reconcileSharesCmd)
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/ekm"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/operator/validator"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/types"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// exportSharesCmd is the command to export the shares of the operator into a portable file
+var exportSharesCmd = &cobra.Command{
+	Use:   "export-shares",
+	Short: "exports the operator shares into a file, share keys are encrypted with the operator key",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+		threshold.Init()
+
+		filePath, err := flags.GetFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		db, network := openNodeDB(cmd, logger, true)
+		defer db.Close()
+
+		operatorKey, found, err := operatorstorage.NewNodeStorage(db, logger).GetPrivateKey()
+		if err != nil || !found {
+			logger.Fatal("failed to get operator private key", zap.Error(err))
+		}
+		collection := validator.NewCollection(validator.CollectionOptions{DB: db, Logger: logger})
+		export, err := validator.ExportShares(collection, db, network, operatorKey)
+		if err != nil {
+			logger.Fatal("failed to export shares", zap.Error(err))
+		}
+		raw, err := json.Marshal(export)
+		if err != nil {
+			logger.Fatal("failed to marshal shares", zap.Error(err))
+		}
+		if err := ioutil.WriteFile(filePath, raw, 0600); err != nil {
+			logger.Fatal("failed to write shares file", zap.Error(err))
+		}
+		logger.Info("exported shares", zap.Int("count", len(export.Shares)), zap.String("file", filePath))
+	},
+}
+
+// importSharesCmd is the command to import shares from a file that was created by export-shares
+var importSharesCmd = &cobra.Command{
+	Use:   "import-shares",
+	Short: "imports operator shares from a file, the node must be set up with the same operator key",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+		threshold.Init()
+
+		filePath, err := flags.GetFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		raw, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			logger.Fatal("failed to read shares file", zap.Error(err))
+		}
+		var export validator.SharesExport
+		if err := json.Unmarshal(raw, &export); err != nil {
+			logger.Fatal("failed to unmarshal shares", zap.Error(err))
+		}
+		db, network := openNodeDB(cmd, logger, false)
+		defer db.Close()
+
+		operatorKey, found, err := operatorstorage.NewNodeStorage(db, logger).GetPrivateKey()
+		if err != nil || !found {
+			logger.Fatal("failed to get operator private key", zap.Error(err))
+		}
+		keyManager, err := ekm.NewETHKeyManagerSigner(db, nil, network, types.GetDefaultDomain())
+		if err != nil {
+			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+		}
+		collection := validator.NewCollection(validator.CollectionOptions{DB: db, Logger: logger})
+		shares, err := validator.ImportShares(collection, keyManager, db, network, operatorKey, &export)
+		if err != nil {
+			logger.Fatal("failed to import shares", zap.Error(err))
+		}
+		logger.Info("imported shares", zap.Int("count", len(shares)), zap.String("file", filePath))
+	},
+}
+
+// openNodeDB opens the node storage and returns it with the configured eth2 network,
+// diagnostic commands should open it in read-only mode
+func openNodeDB(cmd *cobra.Command, logger *zap.Logger, readOnly bool) (basedb.IDb, beaconprotocol.Network) {
+	dbPath, err := flags.GetDBPathFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get db path flag value", zap.Error(err))
+	}
+	networkName, err := flags.GetNetworkFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get network flag value", zap.Error(err))
+	}
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:     "badger-db",
+		Path:     dbPath,
+		Logger:   logger,
+		Ctx:      cmd.Context(),
+		ReadOnly: readOnly,
+	})
+	if err != nil {
+		logger.Fatal("failed to open db", zap.Error(err), zap.String("path", dbPath))
+	}
+	return db, beaconprotocol.NewNetwork(core.NetworkFromString(networkName))
+}
+
+func init() {
+	flags.AddDBPathFlag(exportSharesCmd)
+	flags.AddNetworkFlag(exportSharesCmd)
+	flags.AddFileFlag(exportSharesCmd, "Path of the exported shares file")
+
+	flags.AddDBPathFlag(importSharesCmd)
+	flags.AddNetworkFlag(importSharesCmd)
+	flags.AddFileFlag(importSharesCmd, "Path of the shares file to import")
+
+	RootCmd.AddCommand(exportSharesCmd)
+	RootCmd.AddCommand(importSharesCmd)
+}
@@ -1,8 +1,10 @@
 package ekm
 
 import (
+	"bytes"
 	"crypto/rsa"
 	"encoding/hex"
+	"encoding/json"
 	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -70,6 +72,190 @@ func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beaconprotocol.SigningUt
 	}, nil
 }
 
+// ListShareKeys returns the secret keys of all the shares that were saved by the key manager into the given db
+func ListShareKeys(db basedb.IDb, network beaconprotocol.Network) ([]*bls.SecretKey, error) {
+	store := newSignerStorage(db, network)
+	accounts, err := store.ListAccounts()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list accounts")
+	}
+	var res []*bls.SecretKey
+	for _, acc := range accounts {
+		pubKey := acc.ValidatorPublicKey()
+		sk, err := store.RetrieveShareKey(pubKey)
+		if err != nil {
+			return nil, err
+		}
+		if sk == nil {
+			// shares that were added before share keys were stored separately
+			if sk, err = legacyShareKey(acc); err != nil {
+				return nil, errors.Wrapf(err, "could not get share key of %s", hex.EncodeToString(pubKey))
+			}
+		}
+		if !bytes.Equal(sk.GetPublicKey().Serialize(), pubKey) {
+			return nil, errors.Errorf("share key doesn't match account %s", hex.EncodeToString(pubKey))
+		}
+		res = append(res, sk)
+	}
+	return res, nil
+}
+
+// legacyShareKey extracts the share key from the account serialization,
+// the result must be verified against the account public key
+func legacyShareKey(acc core.ValidatorAccount) (*bls.SecretKey, error) {
+	raw, err := json.Marshal(acc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal account")
+	}
+	var data struct {
+		ValidationKey struct {
+			PrivKey string `json:"privKey"`
+		} `json:"validationKey"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal account")
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.SetHexString(data.ValidationKey.PrivKey); err != nil {
+		return nil, errors.Wrap(err, "could not decode share key")
+	}
+	return sk, nil
+}
+
+// SlashingProtection holds the (SSZ encoded) slashing protection records of a share
+type SlashingProtection struct {
+	HighestAttestation []byte `json:"highestAttestation"`
+	HighestProposal    []byte `json:"highestProposal,omitempty"`
+}
+
+// GetSlashingProtection returns the slashing protection records of the given share
+func GetSlashingProtection(db basedb.IDb, network beaconprotocol.Network, sharePubKey []byte) (*SlashingProtection, error) {
+	store := newSignerStorage(db, network)
+	att := store.RetrieveHighestAttestation(sharePubKey)
+	if att == nil {
+		return nil, errors.New("could not find highest attestation")
+	}
+	res := &SlashingProtection{}
+	var err error
+	if res.HighestAttestation, err = att.MarshalSSZ(); err != nil {
+		return nil, errors.Wrap(err, "could not marshal highest attestation")
+	}
+	if block := store.RetrieveHighestProposal(sharePubKey); block != nil {
+		if res.HighestProposal, err = block.MarshalSSZ(); err != nil {
+			return nil, errors.Wrap(err, "could not marshal highest proposal")
+		}
+	}
+	return res, nil
+}
+
+// RestoreSlashingProtection saves the given slashing protection records of a share.
+// records are merged with the existing ones, so the highest values are kept
+func RestoreSlashingProtection(db basedb.IDb, network beaconprotocol.Network, sharePubKey []byte, sp *SlashingProtection) error {
+	if sp == nil || len(sp.HighestAttestation) == 0 {
+		return errors.New("missing highest attestation")
+	}
+	store := newSignerStorage(db, network)
+
+	att := &eth.AttestationData{}
+	if err := att.UnmarshalSSZ(sp.HighestAttestation); err != nil {
+		return errors.Wrap(err, "could not unmarshal highest attestation")
+	}
+	if current := store.RetrieveHighestAttestation(sharePubKey); current != nil {
+		if current.Slot > att.Slot {
+			att.Slot = current.Slot
+		}
+		if current.Source.Epoch > att.Source.Epoch {
+			att.Source = current.Source
+		}
+		if current.Target.Epoch > att.Target.Epoch {
+			att.Target = current.Target
+		}
+	}
+	if err := store.SaveHighestAttestation(sharePubKey, att); err != nil {
+		return errors.Wrap(err, "could not save highest attestation")
+	}
+
+	if len(sp.HighestProposal) == 0 {
+		return nil
+	}
+	block := &eth.BeaconBlock{}
+	if err := block.UnmarshalSSZ(sp.HighestProposal); err != nil {
+		return errors.Wrap(err, "could not unmarshal highest proposal")
+	}
+	if current := store.RetrieveHighestProposal(sharePubKey); current != nil && current.Slot >= block.Slot {
+		return nil
+	}
+	if err := store.SaveHighestProposal(sharePubKey, block); err != nil {
+		return errors.Wrap(err, "could not save highest proposal")
+	}
+	return nil
+}
+
+// GetAllSlashingProtection returns the slashing protection records of all shares, by hex encoded share public key
+func GetAllSlashingProtection(db basedb.IDb, network beaconprotocol.Network) (map[string]*SlashingProtection, error) {
+	store := newSignerStorage(db, network)
+	res := make(map[string]*SlashingProtection)
+	err := db.GetAll(store.objPrefix(highestAttPrefix), func(i int, obj basedb.Obj) error {
+		res[hex.EncodeToString(obj.Key)] = &SlashingProtection{HighestAttestation: obj.Value}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get highest attestations")
+	}
+	err = db.GetAll(store.objPrefix(highestProposalPrefix), func(i int, obj basedb.Obj) error {
+		pk := hex.EncodeToString(obj.Key)
+		if sp, ok := res[pk]; ok {
+			sp.HighestProposal = obj.Value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get highest proposals")
+	}
+	return res, nil
+}
+
+// IsLowerThan returns true if any of the records is lower than the corresponding record in other
+func (sp *SlashingProtection) IsLowerThan(other *SlashingProtection) (bool, error) {
+	if other == nil {
+		return false, nil
+	}
+	if sp == nil {
+		return true, nil
+	}
+	if len(other.HighestAttestation) > 0 {
+		if len(sp.HighestAttestation) == 0 {
+			return true, nil
+		}
+		att, otherAtt := &eth.AttestationData{}, &eth.AttestationData{}
+		if err := att.UnmarshalSSZ(sp.HighestAttestation); err != nil {
+			return false, errors.Wrap(err, "could not unmarshal highest attestation")
+		}
+		if err := otherAtt.UnmarshalSSZ(other.HighestAttestation); err != nil {
+			return false, errors.Wrap(err, "could not unmarshal highest attestation")
+		}
+		if att.Source.Epoch < otherAtt.Source.Epoch || att.Target.Epoch < otherAtt.Target.Epoch {
+			return true, nil
+		}
+	}
+	if len(other.HighestProposal) > 0 {
+		if len(sp.HighestProposal) == 0 {
+			return true, nil
+		}
+		block, otherBlock := &eth.BeaconBlock{}, &eth.BeaconBlock{}
+		if err := block.UnmarshalSSZ(sp.HighestProposal); err != nil {
+			return false, errors.Wrap(err, "could not unmarshal highest proposal")
+		}
+		if err := otherBlock.UnmarshalSSZ(other.HighestProposal); err != nil {
+			return false, errors.Wrap(err, "could not unmarshal highest proposal")
+		}
+		if block.Slot < otherBlock.Slot {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func newBeaconSigner(wallet core.Wallet, store core.SlashingStore, network beaconprotocol.Network) (signer.ValidatorSigner, error) {
 	slashingProtection := slashingprotection.NewNormalProtection(store)
 	return signer.NewSimpleSigner(wallet, slashingProtection, network.Network), nil
@@ -182,6 +368,9 @@ func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 			return errors.Wrap(err, "could not save share")
 		}
 	}
+	if err := km.storage.SaveShareKey(shareKey); err != nil {
+		return errors.Wrap(err, "could not save share key")
+	}
 	return nil
 }
 
@@ -198,6 +387,13 @@ func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 			return errors.Wrap(err, "could not delete share")
 		}
 	}
+	pk, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not decode share public key")
+	}
+	if err := km.storage.DeleteShareKey(pk); err != nil {
+		return errors.Wrap(err, "could not delete share key")
+	}
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"sync"
@@ -23,6 +24,7 @@ const (
 	accountsPath          = "accounts_%s"
 	highestAttPrefix      = prefix + "highest_att-"
 	highestProposalPrefix = prefix + "highest_prop-"
+	shareKeyPrefix        = prefix + "share_key-"
 )
 
 type signerStorage struct {
@@ -43,6 +45,12 @@ func (s *signerStorage) objPrefix(obj string) []byte {
 	return []byte(string(s.network.Network) + obj)
 }
 
+// SlashingProtectionPrefixes returns the storage prefixes of the slashing protection records
+func SlashingProtectionPrefixes(network beacon.Network) [][]byte {
+	s := newSignerStorage(nil, network)
+	return [][]byte{s.objPrefix(highestAttPrefix), s.objPrefix(highestProposalPrefix)}
+}
+
 // Name returns storage name.
 func (s *signerStorage) Name() string {
 	return "SSV Storage"
@@ -99,7 +107,7 @@ func (s *signerStorage) ListAccounts() ([]core.ValidatorAccount, error) {
 
 	ret := make([]core.ValidatorAccount, 0)
 
-	err := s.db.GetAll(s.objPrefix(accountsPrefix), func(i int, obj basedb.Obj) error {
+	err := s.db.GetAllPaged(s.objPrefix(accountsPrefix), basedb.DefaultPageSize, func(i int, obj basedb.Obj) error {
 		acc, err := s.decodeAccount(obj.Value)
 		if err != nil {
 			return errors.Wrap(err, "failed to list accounts")
@@ -244,3 +252,38 @@ func (s *signerStorage) RetrieveHighestProposal(pubKey []byte) *eth.BeaconBlock
 	}
 	return ret
 }
+
+// SaveShareKey saves the secret key of a share, indexed by its public key
+func (s *signerStorage) SaveShareKey(shareKey *bls.SecretKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Set(s.objPrefix(shareKeyPrefix), shareKey.GetPublicKey().Serialize(), shareKey.Serialize())
+}
+
+// RetrieveShareKey returns the secret key of the given share public key, nil if not found
+func (s *signerStorage) RetrieveShareKey(pubKey []byte) (*bls.SecretKey, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	obj, found, err := s.db.Get(s.objPrefix(shareKeyPrefix), pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get share key")
+	}
+	if !found || len(obj.Value) == 0 {
+		return nil, nil
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(obj.Value); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize share key")
+	}
+	return sk, nil
+}
+
+// DeleteShareKey deletes the secret key of the given share public key
+func (s *signerStorage) DeleteShareKey(pubKey []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(s.objPrefix(shareKeyPrefix), pubKey)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/bloxapp/ssv/utils/threshold"
 	"github.com/google/uuid"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	types "github.com/prysmaticlabs/eth2-types"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -396,3 +397,45 @@ func TestSavingHighestAttestation(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllSlashingProtection(t *testing.T) {
+	network := beaconprotocol.NewNetwork(core.PraterNetwork)
+	db := getStorage(t)
+	defer db.Close()
+
+	newAtt := func(source, target uint64) []byte {
+		att := &eth.AttestationData{
+			Slot:            types.Slot(target * 32),
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &eth.Checkpoint{Epoch: types.Epoch(source), Root: make([]byte, 32)},
+			Target:          &eth.Checkpoint{Epoch: types.Epoch(target), Root: make([]byte, 32)},
+		}
+		raw, err := att.MarshalSSZ()
+		require.NoError(t, err)
+		return raw
+	}
+
+	pk := _byteArray("a9cf360aa15fb1d1d30ee2b578dc5884823c19661886ae8e892775ccb3bd96b7d7345569a2aa0b14e4d015c54a6a0c54")
+	current := &SlashingProtection{HighestAttestation: newAtt(9, 10)}
+	require.NoError(t, RestoreSlashingProtection(db, network, pk, current))
+
+	all, err := GetAllSlashingProtection(db, network)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.Equal(t, current.HighestAttestation, all[hex.EncodeToString(pk)].HighestAttestation)
+
+	older := &SlashingProtection{HighestAttestation: newAtt(8, 9)}
+	lower, err := older.IsLowerThan(current)
+	require.NoError(t, err)
+	require.True(t, lower)
+
+	newer := &SlashingProtection{HighestAttestation: newAtt(9, 11)}
+	lower, err = newer.IsLowerThan(current)
+	require.NoError(t, err)
+	require.False(t, lower)
+
+	var missing *SlashingProtection
+	lower, err = missing.IsLowerThan(current)
+	require.NoError(t, err)
+	require.True(t, lower)
+}
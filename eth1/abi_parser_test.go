@@ -47,6 +47,38 @@ func TestParseOperatorRegistrationEvent(t *testing.T) {
 	})
 }
 
+func TestParseOperatorRemovalEvent(t *testing.T) {
+	var rawOperatorRemoval = `{
+  "address": "0x2EAD684aa2E10E31370830F00E0812bE6205F5f9",
+  "topics": [
+	"0x10b90e3d042178ee9b3e99a849224b4bf4145b9855274073c0c6bca9c5113b7b",
+	"0x00000000000000000000000097a6c1f3aab5427b901fb135ed492749191c0f1f"
+  ],
+  "data": "0x0000000000000000000000000000000000000000000000000000000000000005",
+  "blockNumber": "0x6E1080",
+  "transactionHash": "0x79478d46847aca9aa93f351c4b9c2126739a746b916da6445c0e64ab227fd017"
+}`
+
+	t.Run("v2 operator removed", func(t *testing.T) {
+		vLogOperatorRemoval, contractAbi := unmarshalLog(t, rawOperatorRemoval, V2)
+		abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V2)
+		parsed, err := abiParser.ParseOperatorRemovalEvent(*vLogOperatorRemoval, contractAbi)
+		require.NoError(t, err)
+		require.NotNil(t, parsed)
+		require.Equal(t, uint32(5), parsed.OperatorId)
+		require.Equal(t, "0x97a6C1f3aaB5427B901fb135ED492749191C0f1F", parsed.OwnerAddress.Hex())
+	})
+
+	t.Run("v2 operator removed w/o topics", func(t *testing.T) {
+		vLogOperatorRemoval, contractAbi := unmarshalLog(t, rawOperatorRemoval, V2)
+		vLogOperatorRemoval.Topics = vLogOperatorRemoval.Topics[:1]
+		abiParser := NewParser(logex.Build("test", zap.InfoLevel, nil), V2)
+		_, err := abiParser.ParseOperatorRemovalEvent(*vLogOperatorRemoval, contractAbi)
+		var malformedEventErr *abiparser.MalformedEventError
+		require.True(t, errors.As(err, &malformedEventErr))
+	})
+}
+
 func TestParseValidatorRegistrationEvent(t *testing.T) {
 	var rawValidatorRegistration = `{
   "address": "0x2EAD684aa2E10E31370830F00E0812bE6205F5f9",
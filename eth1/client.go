@@ -33,3 +33,13 @@ type Client interface {
 	Start() error
 	Sync(fromBlock *big.Int) error
 }
+
+// EventsSubscriber subscribes the given channel to eth1 events
+type EventsSubscriber func(ch chan<- *Event) event.Subscription
+
+// FeedSubscriber returns an EventsSubscriber that subscribes to the current events feed of the given client
+func FeedSubscriber(client Client) EventsSubscriber {
+	return func(ch chan<- *Event) event.Subscription {
+		return client.EventsFeed().Subscribe(ch)
+	}
+}
@@ -3,6 +3,7 @@ package forksfactory
 import (
 	"github.com/bloxapp/ssv/ibft/storage/forks"
 	"github.com/bloxapp/ssv/ibft/storage/forks/genesis"
+	"github.com/bloxapp/ssv/ibft/storage/forks/legacy"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 )
 
@@ -11,6 +12,9 @@ func NewFork(forkVersion forksprotocol.ForkVersion) forks.Fork {
 	switch forkVersion {
 	case forksprotocol.GenesisForkVersion:
 		return &genesis.ForkGenesis{}
+	case forksprotocol.ForkVersionEmpty:
+		// records that were saved before the fork version was stored
+		return &legacy.ForkLegacy{}
 	default:
 		return nil
 	}
@@ -0,0 +1,103 @@
+package legacy
+
+import (
+	"encoding/json"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// legacy message types, as defined by the round states of the v0 protocol
+const (
+	legacyPrePrepare  = 1
+	legacyPrepare     = 2
+	legacyCommit      = 3
+	legacyChangeRound = 4
+)
+
+type legacyMessage struct {
+	Type      int32  `json:"type"`
+	Round     uint64 `json:"round"`
+	Lambda    []byte `json:"lambda"`
+	SeqNumber uint64 `json:"seq_number"`
+	Value     []byte `json:"value"`
+}
+
+type legacySignedMessage struct {
+	Message   *legacyMessage `json:"message"`
+	Signature []byte         `json:"signature"`
+	SignerIds []uint64       `json:"signer_ids"`
+}
+
+// ForkLegacy decodes records that were saved in the v0 format, before the fork version was stored.
+// it is used only for migrating old records, therefore encoding is not supported
+type ForkLegacy struct {
+}
+
+// EncodeSignedMsg is not supported for legacy records
+func (f ForkLegacy) EncodeSignedMsg(msg *specqbft.SignedMessage) ([]byte, error) {
+	return nil, errors.New("legacy format is read only")
+}
+
+// DecodeSignedMsg decodes a v0 signed message, the identifier is left empty as v0 lambda can't be converted
+func (f ForkLegacy) DecodeSignedMsg(data []byte) (*specqbft.SignedMessage, error) {
+	legacyMsg := &legacySignedMessage{}
+	if err := json.Unmarshal(data, legacyMsg); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal legacy signed message")
+	}
+	if legacyMsg.Message == nil || len(legacyMsg.SignerIds) == 0 {
+		return nil, errors.New("not a legacy signed message")
+	}
+	msgType, err := toMessageType(legacyMsg.Message.Type)
+	if err != nil {
+		return nil, err
+	}
+	data, err = encodeData(msgType, legacyMsg.Message.Value)
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]spectypes.OperatorID, len(legacyMsg.SignerIds))
+	for i, id := range legacyMsg.SignerIds {
+		signers[i] = spectypes.OperatorID(id)
+	}
+	return &specqbft.SignedMessage{
+		Signature: legacyMsg.Signature,
+		Signers:   signers,
+		Message: &specqbft.Message{
+			MsgType: msgType,
+			Height:  specqbft.Height(legacyMsg.Message.SeqNumber),
+			Round:   specqbft.Round(legacyMsg.Message.Round),
+			Data:    data,
+		},
+	}, nil
+}
+
+func toMessageType(t int32) (specqbft.MessageType, error) {
+	switch t {
+	case legacyPrePrepare:
+		return specqbft.ProposalMsgType, nil
+	case legacyPrepare:
+		return specqbft.PrepareMsgType, nil
+	case legacyCommit:
+		return specqbft.CommitMsgType, nil
+	case legacyChangeRound:
+		return specqbft.RoundChangeMsgType, nil
+	default:
+		return 0, errors.Errorf("unknown legacy message type %d", t)
+	}
+}
+
+// encodeData wraps the legacy value with the data structure of the given message type
+func encodeData(msgType specqbft.MessageType, value []byte) ([]byte, error) {
+	switch msgType {
+	case specqbft.ProposalMsgType:
+		return (&specqbft.ProposalData{Data: value}).Encode()
+	case specqbft.PrepareMsgType:
+		return (&specqbft.PrepareData{Data: value}).Encode()
+	case specqbft.CommitMsgType:
+		return (&specqbft.CommitData{Data: value}).Encode()
+	default:
+		return (&specqbft.RoundChangeData{PreparedValue: value}).Encode()
+	}
+}
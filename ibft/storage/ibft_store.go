@@ -258,6 +258,10 @@ func (i *ibftStorage) CleanLastChangeRound(identifier []byte) error {
 	return nil
 }
 
+// CleanAllChangeRound removes the change round messages of all instances.
+//
+// Deprecated: change round messages are persisted across restarts and are cleaned per instance
+// by CleanLastChangeRound, therefore this function must not be called on startup.
 func (i *ibftStorage) CleanAllChangeRound() error {
 	i.forkLock.RLock()
 	defer i.forkLock.RUnlock()
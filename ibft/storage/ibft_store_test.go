@@ -119,6 +119,43 @@ func TestIbftStorage_CleanLastChangeRound(t *testing.T) {
 
 }
 
+func TestLastChangeRoundSurvivesRestart(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
+	options := basedb.Options{
+		Type:   "badger-db",
+		Logger: logex.GetLogger(),
+		Path:   t.TempDir(),
+	}
+	db, err := ssvstorage.GetStorageFactory(options)
+	require.NoError(t, err)
+	storage := New(db, logex.GetLogger(), "test", forksprotocol.GenesisForkVersion)
+
+	for s := spectypes.OperatorID(1); s <= 3; s++ {
+		require.NoError(t, storage.SaveLastChangeRoundMsg(&specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{s},
+			Message: &specqbft.Message{
+				MsgType:    specqbft.RoundChangeMsgType,
+				Height:     1,
+				Round:      2,
+				Identifier: identifier[:],
+			},
+		}))
+	}
+	db.Close()
+
+	// re-opening the storage must not clean change round state
+	db, err = ssvstorage.GetStorageFactory(options)
+	require.NoError(t, err)
+	defer db.Close()
+	storage = New(db, logex.GetLogger(), "test", forksprotocol.GenesisForkVersion)
+
+	res, err := storage.GetLastChangeRoundMsg(identifier[:])
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	require.Equal(t, specqbft.Round(2), res[0].Message.Round)
+}
+
 func TestSaveAndFetchLastChangeRound(t *testing.T) {
 	identifier := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
 	storage, err := newTestIbftStorage(logex.GetLogger(), "test", forksprotocol.GenesisForkVersion)
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"bytes"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	forksfactory "github.com/bloxapp/ssv/ibft/storage/forks/factory"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	// identifierSize is the size of the message identifier that prefixes the keys of the stored instances
+	identifierSize = 52
+)
+
+var forkVersionPrefix = []byte("qbft_store_fork_version/")
+
+// GetStoredForkVersion returns the fork version of the records in the store with the given prefix,
+// an empty version is returned for records that were saved before the version was stored
+func GetStoredForkVersion(db basedb.IDb, prefix string) (forksprotocol.ForkVersion, error) {
+	obj, found, err := db.Get(forkVersionPrefix, []byte(prefix))
+	if err != nil {
+		return forksprotocol.ForkVersionEmpty, errors.Wrap(err, "could not get stored fork version")
+	}
+	if !found {
+		return forksprotocol.ForkVersionEmpty, nil
+	}
+	return forksprotocol.ForkVersion(obj.Value), nil
+}
+
+// MigrateDecided re-encodes the decided records of the store with the given prefix from the stored fork version
+// into the given fork version. records that are already in the given fork format are kept as is.
+// returns the number of re-encoded records
+func MigrateDecided(db basedb.IDb, logger *zap.Logger, prefix string, forkVersion forksprotocol.ForkVersion) (int, error) {
+	storedVersion, err := GetStoredForkVersion(db, prefix)
+	if err != nil {
+		return 0, err
+	}
+	if storedVersion == forkVersion {
+		return 0, nil
+	}
+	source, current := forksfactory.NewFork(storedVersion), forksfactory.NewFork(forkVersion)
+	if source == nil || current == nil {
+		return 0, errors.Errorf("could not migrate fork version %q into %q", storedVersion, forkVersion)
+	}
+	logger = logger.With(zap.String("prefix", prefix), zap.String("from", string(storedVersion)),
+		zap.String("to", string(forkVersion)))
+
+	migrated := 0
+	var items []basedb.Item
+	// re-encoded records are saved every page, which bounds the amount of records that are held in memory
+	flush := func() error {
+		if len(items) == 0 {
+			return nil
+		}
+		if err := db.SetItems(items); err != nil {
+			return errors.Wrap(err, "could not save re-encoded records")
+		}
+		migrated += len(items)
+		logger.Debug("re-encoded decided records", zap.Int("count", len(items)))
+		items = nil
+		return nil
+	}
+	err = db.GetAllPaged([]byte(prefix), basedb.DefaultPageSize, func(i int, obj basedb.Obj) error {
+		if !isDecidedKey(obj.Key) {
+			return nil
+		}
+		// records that were saved with the current fork are kept as is
+		if msg, err := current.DecodeSignedMsg(obj.Value); err == nil && isValidSignedMsg(msg) {
+			return nil
+		}
+		msg, err := source.DecodeSignedMsg(obj.Value)
+		if err != nil {
+			return errors.Wrapf(err, "could not decode record %x", obj.Key)
+		}
+		// the identifier is taken from the key, as old formats might not hold it
+		msg.Message.Identifier = make([]byte, identifierSize)
+		copy(msg.Message.Identifier, obj.Key[:identifierSize])
+		value, err := current.EncodeSignedMsg(msg)
+		if err != nil {
+			return errors.Wrapf(err, "could not encode record %x", obj.Key)
+		}
+		items = append(items, basedb.Item{Prefix: []byte(prefix), Key: obj.Key, Value: value})
+		if len(items) < basedb.DefaultPageSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return migrated, err
+	}
+	if err := flush(); err != nil {
+		return migrated, err
+	}
+
+	if err := db.Set(forkVersionPrefix, []byte(prefix), []byte(forkVersion)); err != nil {
+		return migrated, errors.Wrap(err, "could not save fork version")
+	}
+	return migrated, nil
+}
+
+// isDecidedKey returns true for keys of decided and highest decided records
+func isDecidedKey(key []byte) bool {
+	if len(key) <= identifierSize {
+		return false
+	}
+	id := key[identifierSize:]
+	return bytes.HasPrefix(id, []byte(decidedKey)) || bytes.Equal(id, []byte(highestKey))
+}
+
+func isValidSignedMsg(msg *specqbft.SignedMessage) bool {
+	return msg != nil && msg.Message != nil && len(msg.Signers) > 0 && len(msg.Message.Identifier) > 0
+}
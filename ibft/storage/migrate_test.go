@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// legacyDecidedFixture is a v0 commit message of height 5, with "data" as value
+const legacyDecidedFixture = `{"message":{"type":3,"round":1,"lambda":"cGtfQVRURVNURVI=","seq_number":5,"value":"ZGF0YQ=="},"signature":"c2ln","signer_ids":[1,2,3]}`
+
+func TestMigrateDecided(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	prefix := "test"
+	msgID := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
+	store := New(db, logex.GetLogger(), prefix, forksprotocol.GenesisForkVersion)
+
+	// legacy records, saved before the fork version was stored
+	legacyStore := store.(*ibftStorage)
+	require.NoError(t, legacyStore.save([]byte(legacyDecidedFixture), decidedKey, msgID[:], uInt64ToByteSlice(5)))
+	require.NoError(t, legacyStore.save([]byte(legacyDecidedFixture), highestKey, msgID[:]))
+	// a record that was already saved in the current format
+	require.NoError(t, store.SaveDecided(&specqbft.SignedMessage{
+		Signature: []byte("sig"),
+		Signers:   []spectypes.OperatorID{1, 2, 3},
+		Message: &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     4,
+			Round:      1,
+			Identifier: msgID[:],
+		},
+	}))
+
+	storedVersion, err := GetStoredForkVersion(db, prefix)
+	require.NoError(t, err)
+	require.Equal(t, forksprotocol.ForkVersionEmpty, storedVersion)
+
+	n, err := MigrateDecided(db, logex.GetLogger(), prefix, forksprotocol.GenesisForkVersion)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	storedVersion, err = GetStoredForkVersion(db, prefix)
+	require.NoError(t, err)
+	require.Equal(t, forksprotocol.GenesisForkVersion, storedVersion)
+
+	decided, err := store.GetDecided(msgID[:], 4, 5)
+	require.NoError(t, err)
+	require.Len(t, decided, 2)
+	require.Equal(t, specqbft.Height(4), decided[0].Message.Height)
+	migrated := decided[1]
+	require.Equal(t, specqbft.Height(5), migrated.Message.Height)
+	require.Equal(t, specqbft.CommitMsgType, migrated.Message.MsgType)
+	require.Equal(t, specqbft.Round(1), migrated.Message.Round)
+	require.Equal(t, msgID[:], migrated.Message.Identifier)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3}, migrated.Signers)
+	require.Equal(t, spectypes.Signature("sig"), migrated.Signature)
+	commitData, err := migrated.Message.GetCommitData()
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), commitData.Data)
+
+	last, err := store.GetLastDecided(msgID[:])
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	require.Equal(t, specqbft.Height(5), last.Message.Height)
+	require.Equal(t, msgID[:], last.Message.Identifier)
+
+	// the migration is not applied again once the fork version is stored
+	n, err = MigrateDecided(db, logex.GetLogger(), prefix, forksprotocol.GenesisForkVersion)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+}
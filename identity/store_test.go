@@ -3,13 +3,16 @@ package p2p
 import (
 	"encoding/hex"
 	"github.com/bloxapp/ssv/network/commons"
+	"github.com/bloxapp/ssv/network/discovery"
 	ssvstorage "github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils/logex"
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"net"
 	"testing"
 )
 
@@ -111,3 +114,32 @@ func TestSetupPrivateKey(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkKeyPeerID(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logex.Build("test", zapcore.DebugLevel, nil),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	// generate a new key, and read it again as the node would do on startup
+	generated, err := NewIdentityStore(db, zap.L()).SetupNetworkKey("")
+	require.NoError(t, err)
+	privKey, err := NewIdentityStore(db, zap.L()).SetupNetworkKey("")
+	require.NoError(t, err)
+	require.Equal(t, gcrypto.FromECDSA(generated), gcrypto.FromECDSA(privKey))
+
+	node, err := discovery.NodeRecord(privKey, net.ParseIP("127.0.0.1"), 12001, 13001)
+	require.NoError(t, err)
+	printedID, err := discovery.PeerID(node)
+	require.NoError(t, err)
+
+	// the node uses the libp2p identity of the network key
+	libPrivKey, err := commons.ConvertToInterfacePrivkey(privKey)
+	require.NoError(t, err)
+	nodeID, err := peer.IDFromPrivateKey(libPrivKey)
+	require.NoError(t, err)
+	require.Equal(t, nodeID, printedID)
+}
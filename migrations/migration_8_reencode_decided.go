@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
+	qbftstorage "github.com/bloxapp/ssv/ibft/storage"
+)
+
+// migrationReencodeDecided re-encodes decided records that were saved in an old format into the current fork format
+var migrationReencodeDecided = Migration{
+	Name: "migration_8_reencode_decided",
+	Run: func(ctx context.Context, opt Options, key []byte) error {
+		n, err := qbftstorage.MigrateDecided(opt.Db, opt.Logger, spectypes.BNRoleAttester.String(), opt.forkVersion())
+		if err != nil {
+			return err
+		}
+		opt.Logger.Info("re-encoded decided records", zap.Int("count", n))
+		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+	},
+}
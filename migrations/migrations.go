@@ -9,6 +9,7 @@ import (
 
 	operatorstorage "github.com/bloxapp/ssv/operator/storage"
 	validatorstorage "github.com/bloxapp/ssv/operator/validator"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/eth1"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
@@ -26,6 +27,7 @@ var (
 		migrationCleanValidatorRegistryData,
 		migrationCleanSyncOffset,
 		migrationCleanOperatorRemovalCorruptions,
+		migrationReencodeDecided,
 	}
 )
 
@@ -52,6 +54,8 @@ type Options struct {
 	Db     basedb.IDb
 	Logger *zap.Logger
 	DbPath string
+	// ForkVersion is the current fork version, genesis is used if not set
+	ForkVersion forksprotocol.ForkVersion
 }
 
 func (o *Options) getRegistryStores() []eth1.RegistryStore {
@@ -68,6 +72,13 @@ func (o Options) nodeStorage() operatorstorage.Storage {
 	return operatorstorage.NewNodeStorage(o.Db, o.Logger)
 }
 
+func (o Options) forkVersion() forksprotocol.ForkVersion {
+	if o.ForkVersion == forksprotocol.ForkVersionEmpty {
+		return forksprotocol.GenesisForkVersion
+	}
+	return o.ForkVersion
+}
+
 // Run executes the migrations.
 func (m Migrations) Run(ctx context.Context, opt Options) error {
 	opt.Logger.Info("Running migrations:")
@@ -1,10 +1,13 @@
 package discovery
 
 import (
+	"context"
+	"github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/network/records"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
 	"go.uber.org/zap"
+	"time"
 )
 
 // limitNodeFilter checks if limit exceeded
@@ -12,15 +15,35 @@ func (dvs *DiscV5Service) limitNodeFilter(node *enode.Node) bool {
 	return !dvs.conns.Limit(libp2pnetwork.DirOutbound)
 }
 
-//// forkVersionFilter checks if the node has the same fork version
-//func (dvs *DiscV5Service) forkVersionFilter(node *enode.Node) bool {
-//	forkv, err := records.GetForkVersionEntry(node.Record())
-//	if err != nil {
-//		dvs.logger.Warn("could not read fork version from node record", zap.Error(err))
-//		return false
-//	}
-//	return dvs.forkv == forkv
-//}
+// waitForCapacity blocks while the node is at outbound capacity, as there is no point in dialing new peers.
+// it checks capacity every interval, and returns false if the context was canceled while waiting
+func waitForCapacity(ctx context.Context, conns peers.ConnectionIndex, interval time.Duration) bool {
+	if !conns.AtCapacity(libp2pnetwork.DirOutbound) {
+		return true
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-t.C:
+			if !conns.AtCapacity(libp2pnetwork.DirOutbound) {
+				return true
+			}
+		}
+	}
+}
+
+// forkVersionFilter checks if the node has the same fork version
+func (dvs *DiscV5Service) forkVersionFilter(node *enode.Node) bool {
+	forkv, err := records.GetForkVersionEntry(node.Record())
+	if err != nil {
+		dvs.logger.Debug("could not read fork version from node record", zap.Error(err))
+		return false
+	}
+	return dvs.forkv == forkv
+}
 
 // badNodeFilter checks if the node was pruned or have a bad score
 func (dvs *DiscV5Service) badNodeFilter(node *enode.Node) bool {
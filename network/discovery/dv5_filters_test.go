@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network/records"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+)
+
+func TestWaitForCapacity(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conns := &mockConnIndex{}
+	require.True(t, waitForCapacity(ctx, conns, time.Millisecond*10))
+
+	t.Run("paused while at capacity", func(t *testing.T) {
+		atomic.StoreInt32(&conns.atCapacity, 1)
+		resumed := make(chan bool, 1)
+		go func() {
+			resumed <- waitForCapacity(ctx, conns, time.Millisecond*10)
+		}()
+		select {
+		case <-resumed:
+			t.Fatal("dialing should be paused while at outbound capacity")
+		case <-time.After(time.Millisecond * 100):
+		}
+		// free a slot
+		atomic.StoreInt32(&conns.atCapacity, 0)
+		select {
+		case ok := <-resumed:
+			require.True(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("dialing should resume once slots are freed")
+		}
+	})
+
+	t.Run("canceled while at capacity", func(t *testing.T) {
+		atomic.StoreInt32(&conns.atCapacity, 1)
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		require.False(t, waitForCapacity(ctx, conns, time.Millisecond*10))
+	})
+}
+
+type mockConnIndex struct {
+	atCapacity int32
+}
+
+func (c *mockConnIndex) Connectedness(id peer.ID) libp2pnetwork.Connectedness {
+	return libp2pnetwork.NotConnected
+}
+
+func (c *mockConnIndex) CanConnect(id peer.ID) bool {
+	return true
+}
+
+func (c *mockConnIndex) Limit(dir libp2pnetwork.Direction) bool {
+	return false
+}
+
+func (c *mockConnIndex) AtCapacity(dir libp2pnetwork.Direction) bool {
+	return dir == libp2pnetwork.DirOutbound && atomic.LoadInt32(&c.atCapacity) == 1
+}
+
+func (c *mockConnIndex) IsBad(id peer.ID) bool {
+	return false
+}
+
+func TestForkVersionFilter(t *testing.T) {
+	dvs := &DiscV5Service{
+		logger: zap.L(),
+		forkv:  forksprotocol.GenesisForkVersion,
+	}
+
+	sameFork := localNodeMock(t)
+	require.NoError(t, records.SetForkVersionEntry(sameFork, forksprotocol.GenesisForkVersion.String()))
+	require.True(t, dvs.forkVersionFilter(sameFork.Node()))
+
+	otherFork := localNodeMock(t)
+	require.NoError(t, records.SetForkVersionEntry(otherFork, "v1"))
+	require.False(t, dvs.forkVersionFilter(otherFork.Node()))
+
+	// once we upgrade, the genesis peer is ignored while the peer of the new fork is accepted
+	dvs.forkv = forksprotocol.ForkVersion("v1")
+	require.False(t, dvs.forkVersionFilter(sameFork.Node()))
+	require.True(t, dvs.forkVersionFilter(otherFork.Node()))
+}
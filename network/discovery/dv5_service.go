@@ -136,10 +136,20 @@ func (dvs *DiscV5Service) Node(info peer.AddrInfo) (*enode.Node, error) {
 }
 
 // Bootstrap start looking for new nodes, note that this function blocks.
+// nodes that advertise a different fork version are ignored, to avoid cross-fork connections.
 // if we reached peers limit, make sure to accept peers with more than 1 shared subnet,
 // which lets other components to determine whether we'll want to connect to this node or not.
+// found nodes are batched and passed to the handler ordered by the amount of subnets they share with this node.
 func (dvs *DiscV5Service) Bootstrap(handler HandleNewPeer) error {
+	batch := newPeersBatch(peersBatchSize, peersBatchTimeout, func() []byte {
+		return dvs.subnets
+	}, handler)
+	defer batch.stop()
 	dvs.discover(dvs.ctx, func(e PeerEvent) {
+		if !dvs.forkVersionFilter(e.Node) {
+			metricRejectedNodes.Inc()
+			return
+		}
 		nodeSubnets, err := records.GetSubnetsEntry(e.Node.Record())
 		if err != nil {
 			dvs.logger.Debug("could not read subnets", zap.String("enr", e.Node.String()))
@@ -158,8 +168,8 @@ func (dvs *DiscV5Service) Bootstrap(handler HandleNewPeer) error {
 			}
 		}
 		metricFoundNodes.Inc()
-		handler(e)
-	}, defaultDiscoveryInterval) //, dvs.forkVersionFilter) //, dvs.badNodeFilter)
+		batch.add(e, nodeSubnets)
+	}, defaultDiscoveryInterval) //, dvs.badNodeFilter)
 
 	return nil
 }
@@ -207,6 +217,7 @@ func (dvs *DiscV5Service) initDiscV5Listener(discOpts *Options) error {
 // interval enables to control the rate of new nodes that we find.
 // filters will be applied on each new node before the handler is called,
 // enabling to apply custom access control for different scenarios.
+// the search is paused while the node is at outbound capacity, and resumes once slots are freed.
 func (dvs *DiscV5Service) discover(ctx context.Context, handler HandleNewPeer, interval time.Duration, filters ...NodeFilter) {
 	iterator := dvs.dv5Listener.RandomNodes()
 	for _, f := range filters {
@@ -224,6 +235,9 @@ func (dvs *DiscV5Service) discover(ctx context.Context, handler HandleNewPeer, i
 
 	for ctx.Err() == nil {
 		wait()
+		if !waitForCapacity(ctx, dvs.conns, interval) {
+			return
+		}
 		exists := iterator.Next()
 		if !exists {
 			continue
@@ -96,22 +96,3 @@ package discovery
 //		Port:       port,
 //	})
 //}
-//
-//type mockConnIndex struct {
-//}
-//
-//func (c *mockConnIndex) Connectedness(id peer.ID) libp2pnetwork.Connectedness {
-//	return libp2pnetwork.NotConnected
-//}
-//
-//func (c *mockConnIndex) CanConnect(id peer.ID) bool {
-//	return true
-//}
-//
-//func (c *mockConnIndex) Limit(dir libp2pnetwork.Direction) bool {
-//	return false
-//}
-//
-//func (c *mockConnIndex) IsBad(id peer.ID) bool {
-//	return false
-//}
@@ -30,6 +30,15 @@ func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.I
 	return localNode, nil
 }
 
+// NodeRecord creates the (undecorated) node record of the given network key, w/o persisting it
+func NodeRecord(privKey *ecdsa.PrivateKey, ipAddr net.IP, udpPort, tcpPort int) (*enode.Node, error) {
+	localNode, err := createLocalNode(privKey, "", ipAddr, udpPort, tcpPort)
+	if err != nil {
+		return nil, err
+	}
+	return localNode.Node(), nil
+}
+
 // addAddresses adds configured address and/or dns if configured
 func addAddresses(localNode *enode.LocalNode, hostAddr, hostDNS string) error {
 	if len(hostAddr) > 0 {
@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bloxapp/ssv/network/records"
+)
+
+const (
+	// peersBatchSize is the amount of discovered peers that are collected before prioritizing them
+	peersBatchSize = 8
+	// peersBatchTimeout is the max time that a discovered peer waits in the batch
+	peersBatchTimeout = 5 * time.Second
+)
+
+type batchedPeer struct {
+	e       PeerEvent
+	subnets []byte
+}
+
+// peersBatch collects discovered peers and hands them over ordered by the amount of subnets
+// they share with this node, so peers that share subnets are dialed before non-overlapping ones.
+// the batch is flushed once it is full or once the first batched peer waited for the given timeout
+type peersBatch struct {
+	size    int
+	timeout time.Duration
+	// mySubnets returns the current subnets of this node
+	mySubnets func() []byte
+	handler   HandleNewPeer
+
+	lock  sync.Mutex
+	peers []batchedPeer
+	timer *time.Timer
+	// round is incremented on every flush, so a timer of a previous batch won't flush the current one
+	round uint64
+}
+
+func newPeersBatch(size int, timeout time.Duration, mySubnets func() []byte, handler HandleNewPeer) *peersBatch {
+	return &peersBatch{
+		size:      size,
+		timeout:   timeout,
+		mySubnets: mySubnets,
+		handler:   handler,
+	}
+}
+
+// add adds the given peer, the batch is flushed to the handler once it is full or timed out
+func (b *peersBatch) add(e PeerEvent, nodeSubnets []byte) {
+	b.lock.Lock()
+	b.peers = append(b.peers, batchedPeer{e: e, subnets: nodeSubnets})
+	full := len(b.peers) >= b.size || b.timeout <= 0
+	if !full && len(b.peers) == 1 {
+		round := b.round
+		b.timer = time.AfterFunc(b.timeout, func() {
+			b.flushRound(round)
+		})
+	}
+	b.lock.Unlock()
+
+	if full {
+		b.flush()
+	}
+}
+
+// flushRound flushes the batch if it is still in the given round
+func (b *peersBatch) flushRound(round uint64) {
+	b.lock.Lock()
+	current := b.round == round
+	b.lock.Unlock()
+	if current {
+		b.flush()
+	}
+}
+
+// flush passes the batched peers to the handler, ordered by the amount of shared subnets
+func (b *peersBatch) flush() {
+	batch := b.reset()
+	if len(batch) == 0 {
+		return
+	}
+	mySubnets := b.mySubnets()
+	if len(mySubnets) > 0 {
+		shared := make([]int, len(batch))
+		for i, p := range batch {
+			shared[i] = len(records.SharedSubnets(mySubnets, p.subnets, 0))
+		}
+		sort.Stable(&byShared{batch, shared})
+	}
+	for _, p := range batch {
+		b.handler(p.e)
+	}
+}
+
+// stop drops the batched peers, it should be called once discovery is done
+func (b *peersBatch) stop() {
+	_ = b.reset()
+}
+
+// reset starts a new round and returns the peers of the previous one
+func (b *peersBatch) reset() []batchedPeer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	batch := b.peers
+	b.peers = nil
+	b.round++
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	return batch
+}
+
+// byShared sorts peers by the amount of shared subnets, in descending order
+type byShared struct {
+	peers  []batchedPeer
+	shared []int
+}
+
+func (s *byShared) Len() int           { return len(s.peers) }
+func (s *byShared) Less(i, j int) bool { return s.shared[i] > s.shared[j] }
+func (s *byShared) Swap(i, j int) {
+	s.peers[i], s.peers[j] = s.peers[j], s.peers[i]
+	s.shared[i], s.shared[j] = s.shared[j], s.shared[i]
+}
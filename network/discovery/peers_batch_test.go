@@ -0,0 +1,90 @@
+package discovery
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeersBatch(t *testing.T) {
+	mySubnets := make([]byte, 128)
+	mySubnets[1] = 1
+	mySubnets[2] = 1
+	mySubnets[3] = 1
+
+	peerSubnets := func(subnets ...int) []byte {
+		s := make([]byte, 128)
+		for _, subnet := range subnets {
+			s[subnet] = 1
+		}
+		return s
+	}
+
+	var lock sync.Mutex
+	var dialed []peer.ID
+	handler := func(e PeerEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+		dialed = append(dialed, e.AddrInfo.ID)
+	}
+	getDialed := func() []peer.ID {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]peer.ID{}, dialed...)
+	}
+	resetDialed := func() {
+		lock.Lock()
+		defer lock.Unlock()
+		dialed = nil
+	}
+
+	batch := newPeersBatch(4, time.Minute, func() []byte {
+		return mySubnets
+	}, handler)
+	batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "no-overlap"}}, peerSubnets(10, 20))
+	batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "one-shared"}}, peerSubnets(1, 20))
+	batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "no-subnets"}}, nil)
+	require.Len(t, getDialed(), 0)
+	batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "three-shared"}}, peerSubnets(1, 2, 3))
+
+	require.Equal(t, []peer.ID{"three-shared", "one-shared", "no-overlap", "no-subnets"}, getDialed())
+
+	t.Run("flush without timeout", func(t *testing.T) {
+		resetDialed()
+		batch := newPeersBatch(4, 0, func() []byte {
+			return mySubnets
+		}, handler)
+		batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "p1"}}, peerSubnets(10))
+		require.Equal(t, []peer.ID{"p1"}, getDialed())
+	})
+
+	t.Run("flush on timeout", func(t *testing.T) {
+		resetDialed()
+		batch := newPeersBatch(4, time.Millisecond*50, func() []byte {
+			return mySubnets
+		}, handler)
+		defer batch.stop()
+		// no other peer is discovered after these ones
+		batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "p1"}}, peerSubnets(10))
+		batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "p2"}}, peerSubnets(1))
+		require.Len(t, getDialed(), 0)
+		require.Eventually(t, func() bool {
+			return len(getDialed()) == 2
+		}, time.Second, time.Millisecond*10)
+		require.Equal(t, []peer.ID{"p2", "p1"}, getDialed())
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		resetDialed()
+		batch := newPeersBatch(4, time.Millisecond*20, func() []byte {
+			return mySubnets
+		}, handler)
+		batch.add(PeerEvent{AddrInfo: peer.AddrInfo{ID: "p1"}}, peerSubnets(10))
+		batch.stop()
+		<-time.After(time.Millisecond * 100)
+		require.Len(t, getDialed(), 0)
+	})
+}
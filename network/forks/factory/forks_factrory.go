@@ -1,13 +1,42 @@
 package factory
 
 import (
+	"sync"
+
 	"github.com/bloxapp/ssv/network/forks"
 	"github.com/bloxapp/ssv/network/forks/genesis"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 )
 
+var (
+	registeredLock sync.RWMutex
+	registered     = make(map[forksprotocol.ForkVersion]forks.Fork)
+)
+
+// RegisterFork registers a fork for the given version, it takes precedence over the built-in forks.
+// it is meant to be used in tests in order to pin an arbitrary fork, returns a function that unregisters the fork
+func RegisterFork(forkVersion forksprotocol.ForkVersion, fork forks.Fork) func() {
+	registeredLock.Lock()
+	defer registeredLock.Unlock()
+
+	registered[forkVersion] = fork
+	return func() {
+		registeredLock.Lock()
+		defer registeredLock.Unlock()
+
+		delete(registered, forkVersion)
+	}
+}
+
 // NewFork returns a new fork instance from the given version
 func NewFork(forkVersion forksprotocol.ForkVersion) forks.Fork {
+	registeredLock.RLock()
+	fork, ok := registered[forkVersion]
+	registeredLock.RUnlock()
+	if ok {
+		return fork
+	}
+
 	switch forkVersion {
 	case forksprotocol.GenesisForkVersion:
 		return &genesis.ForkGenesis{}
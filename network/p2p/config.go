@@ -36,21 +36,29 @@ type Config struct {
 
 	RequestTimeout   time.Duration `yaml:"RequestTimeout" env:"P2P_REQUEST_TIMEOUT"  env-default:"7s"`
 	MaxBatchResponse uint64        `yaml:"MaxBatchResponse" env:"P2P_MAX_BATCH_RESPONSE" env-default:"25" env-description:"Maximum number of returned objects in a batch"`
+	MaxMessageSize   int           `yaml:"MaxMessageSize" env:"P2P_MAX_MESSAGE_SIZE" env-default:"262144" env-description:"Maximum size in bytes of incoming pubsub and stream messages"`
 	MaxPeers         int           `yaml:"MaxPeers" env:"P2P_MAX_PEERS" env-default:"60" env-description:"Connected peers limit for connections"`
 	TopicMaxPeers    int           `yaml:"TopicMaxPeers" env:"P2P_TOPIC_MAX_PEERS" env-default:"5" env-description:"Connected peers limit per pubsub topic"`
+	HandshakeTimeout time.Duration `yaml:"HandshakeTimeout" env:"P2P_HANDSHAKE_TIMEOUT" env-default:"30s" env-description:"Timeout for handshaking with new peers"`
 
 	// Subnets is a static bit list of subnets that this node will register upon start.
 	Subnets string `yaml:"Subnets" env:"SUBNETS" env-description:"Hex string that represents the subnets that this node will join upon start"`
+	// StaticSubnets is a list of subnets that this node will always join, in addition to the subnets of its validators
+	StaticSubnets []int `yaml:"StaticSubnets" env:"STATIC_SUBNETS" env-description:"Comma separated list of subnets that this node will always join"`
 	// PubSubScoring is a flag to turn on/off pubsub scoring
 	PubSubScoring bool `yaml:"PubSubScoring" env:"PUBSUB_SCORING" env-default:"true" env-description:"Flag to turn on/off pubsub scoring"`
 	// P2pLog is a flag to turn on/off network logs
 	P2pLog bool `yaml:"P2pLog" env:"P2P_LOG" env-description:"Flag to turn on/off network debug logs"`
+	// PubSubIPWhitelist is a list of CIDRs that are excluded from IP colocation penalty
+	PubSubIPWhitelist []string `yaml:"PubSubIPWhitelist" env:"PUBSUB_IP_WHITELIST" env-description:"Comma separated list of CIDRs that are excluded from pubsub IP colocation penalty"`
 	// PubSubTrace is a flag to turn on/off pubsub tracing in logs
 	PubSubTrace bool `yaml:"PubSubTrace" env:"PUBSUB_TRACE" env-description:"Flag to turn on/off pubsub tracing in logs"`
 	// DiscoveryTrace is a flag to turn on/off discovery tracing in logs
 	DiscoveryTrace bool `yaml:"DiscoveryTrace" env:"DISCOVERY_TRACE" env-description:"Flag to turn on/off discovery tracing in logs"`
 	// NetworkID is the network of this node
 	NetworkID string `yaml:"NetworkID" env:"NETWORK_ID" env-description:"Network ID is the network of this node"`
+	// MinPeerVersion is the minimum version of peers, peers running an older version are disconnected
+	MinPeerVersion string `yaml:"MinPeerVersion" env:"P2P_MIN_PEER_VERSION" env-description:"Minimum SSV version of peers (e.g. v0.3.0), peers running older versions are disconnected"`
 	// NetworkPrivateKey is used for network identity, MUST be injected
 	NetworkPrivateKey *ecdsa.PrivateKey
 	// OperatorPublicKey is used for operator identity, optional
@@ -63,11 +71,17 @@ type Config struct {
 	ForkVersion forksprotocol.ForkVersion
 	// Logger to used by network services
 	Logger *zap.Logger
+	// MaxValueSize is the max size (bytes) of a proposed value, larger proposals are rejected by the topic validator.
+	// 0 means the default size
+	MaxValueSize int
 
 	PubsubMsgCacheTTL         time.Duration `yaml:"PubsubMsgCacheTTL" env:"PUBSUB_MSG_CACHE_TTL" env-description:"How long a message ID will be remembered as seen"`
 	PubsubOutQueueSize        int           `yaml:"PubsubOutQueueSize" env:"PUBSUB_OUT_Q_SIZE" env-description:"The size that we assign to the outbound pubsub message queue"`
 	PubsubValidationQueueSize int           `yaml:"PubsubValidationQueueSize" env:"PUBSUB_VAL_Q_SIZE" env-description:"The size that we assign to the pubsub validation queue"`
 	PubsubValidateThrottle    int           `yaml:"PubsubPubsubValidateThrottle" env:"PUBSUB_VAL_THROTTLE" env-description:"The amount of goroutines used for pubsub msg validation"`
+	PubsubD                   int           `yaml:"PubsubD" env:"PUBSUB_D" env-description:"The target mesh degree of gossipsub topics"`
+	PubsubDlo                 int           `yaml:"PubsubDlo" env:"PUBSUB_D_LOW" env-description:"The low watermark of gossipsub topics mesh"`
+	PubsubDhi                 int           `yaml:"PubsubDhi" env:"PUBSUB_D_HIGH" env-description:"The high watermark of gossipsub topics mesh"`
 
 	GetValidatorStats network.GetValidatorStats
 }
@@ -1,6 +1,7 @@
 package p2pv1
 
 import (
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -28,6 +29,14 @@ var (
 		Name: "ssv:network:router:in",
 		Help: "Counts incoming messages",
 	}, []string{"identifier", "mt"})
+	metricsStreamOversizedMsgs = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:network:streams:oversized",
+		Help: "Counts stream messages that were rejected due to their size",
+	})
+	metricsHistoryPeers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:network:history_peers",
+		Help: "Count connected peers that support decided history sync",
+	})
 )
 
 func init() {
@@ -43,6 +52,12 @@ func init() {
 	if err := prometheus.Register(metricsRouterIncoming); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsStreamOversizedMsgs); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsHistoryPeers); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 var unknown = "unknown"
@@ -54,6 +69,20 @@ func (n *p2pNetwork) reportAllPeers() {
 	MetricsAllConnectedPeers.Set(float64(len(pids)))
 }
 
+// reportHistoryPeers reports the amount of connected peers that support decided history sync
+func (n *p2pNetwork) reportHistoryPeers() {
+	protocolID, _ := n.fork.ProtocolID(p2pprotocol.DecidedHistoryProtocol)
+	filter := n.peersWithProtocolsFilter(string(protocolID))
+	count := 0
+	for _, pid := range n.host.Network().Peers() {
+		if filter(pid) {
+			count++
+		}
+	}
+	n.logger.Debug("history peers status", zap.Int("count", count))
+	metricsHistoryPeers.Set(float64(count))
+}
+
 func (n *p2pNetwork) reportPeerIdentities() {
 	pids := n.host.Network().Peers()
 	for _, pid := range pids {
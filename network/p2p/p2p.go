@@ -41,6 +41,7 @@ const (
 	peersReportingInterval          = 60 * time.Second
 	peerIdentitiesReportingInterval = 5 * time.Minute
 	topicsReportingInterval         = 180 * time.Second
+	historyPeersReportingInterval   = 60 * time.Second
 )
 
 // p2pNetwork implements network.P2PNetwork
@@ -69,6 +70,7 @@ type p2pNetwork struct {
 
 	backoffConnector *libp2pdisc.BackoffConnector
 	subnets          []byte
+	staticSubnets    []byte
 	libConnManager   connmgrcore.ConnManager
 }
 
@@ -140,6 +142,8 @@ func (n *p2pNetwork) Start() error {
 
 	async.Interval(n.ctx, topicsReportingInterval, n.reportTopics)
 
+	async.Interval(n.ctx, historyPeersReportingInterval, n.reportHistoryPeers)
+
 	if err := n.registerInitialTopics(); err != nil {
 		return err
 	}
@@ -225,6 +229,7 @@ func (n *p2pNetwork) UpdateSubnets() {
 		copy(last, n.subnets)
 	}
 	newSubnets := make([]byte, n.fork.Subnets())
+	copy(newSubnets, n.staticSubnets)
 	for pkHex, state := range n.activeValidators {
 		if state == validatorStateInactive {
 			continue
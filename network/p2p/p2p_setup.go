@@ -8,6 +8,9 @@ import (
 	"github.com/bloxapp/ssv/network/records"
 	"github.com/bloxapp/ssv/network/streams"
 	"github.com/bloxapp/ssv/network/topics"
+	"github.com/bloxapp/ssv/network/topics/params"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/proposal"
 	commons2 "github.com/bloxapp/ssv/utils/commons"
 	"github.com/libp2p/go-libp2p"
 	connmgr "github.com/libp2p/go-libp2p-connmgr"
@@ -80,6 +83,9 @@ func (n *p2pNetwork) initCfg() {
 	if len(n.cfg.UserAgent) == 0 {
 		n.cfg.UserAgent = userAgent(n.cfg.UserAgent)
 	}
+	if n.cfg.MaxValueSize <= 0 {
+		n.cfg.MaxValueSize = qbft.DefaultMaxValueSize
+	}
 	if len(n.cfg.Subnets) > 0 {
 		s := make(records.Subnets, 0)
 		subnets, err := s.FromString(strings.Replace(n.cfg.Subnets, "0x", "", 1))
@@ -89,6 +95,15 @@ func (n *p2pNetwork) initCfg() {
 		}
 		n.subnets = subnets
 	}
+	if len(n.cfg.StaticSubnets) > 0 {
+		staticSubnets, err := records.StaticSubnets(n.fork.Subnets(), n.cfg.StaticSubnets)
+		if err != nil {
+			n.logger.Warn("could not parse static subnets", zap.Error(err))
+		} else {
+			n.staticSubnets = staticSubnets
+			n.subnets = records.MergeSubnets(n.subnets, staticSubnets)
+		}
+	}
 	if n.cfg.MaxPeers <= 0 {
 		n.cfg.MaxPeers = minPeersBuffer
 	}
@@ -189,6 +204,13 @@ func (n *p2pNetwork) setupPeerServices() error {
 	filters := []connections.HandshakeFilter{
 		connections.NetworkIDFilter(n.cfg.NetworkID),
 	}
+	if len(n.cfg.MinPeerVersion) > 0 {
+		minVersion, err := commons2.ParseVersion(n.cfg.MinPeerVersion)
+		if err != nil {
+			return errors.Wrap(err, "could not parse min peer version")
+		}
+		filters = append(filters, connections.MinVersionFilter(minVersion))
+	}
 	handshaker := connections.NewHandshaker(n.ctx, &connections.HandshakerCfg{
 		Logger:          n.logger,
 		Streams:         n.streamCtrl,
@@ -203,7 +225,7 @@ func (n *p2pNetwork) setupPeerServices() error {
 	n.host.SetStreamHandler(peers.NodeInfoProtocol, handshaker.Handler())
 	n.logger.Debug("handshaker is ready")
 
-	n.connHandler = connections.NewConnHandler(n.ctx, n.logger, handshaker, subnetsProvider, n.idx, n.idx)
+	n.connHandler = connections.NewConnHandler(n.ctx, n.logger, handshaker, n.cfg.HandshakeTimeout, subnetsProvider, n.idx, n.idx)
 	n.host.Network().Notify(n.connHandler.Handle())
 	n.logger.Debug("connection handler is ready")
 	return nil
@@ -268,7 +290,7 @@ func (n *p2pNetwork) setupPubsub() error {
 		TraceLog: n.cfg.PubSubTrace,
 		MsgValidatorFactory: func(s string) topics.MsgValidatorFunc {
 			logger := n.logger.With(zap.String("who", "MsgValidator"))
-			return topics.NewSSVMsgValidator(logger, n.fork, n.host.ID())
+			return topics.NewMaxSizeMsgValidator(n.cfg.MaxMessageSize, topics.NewSSVMsgValidator(logger, n.fork, n.host.ID(), proposal.MsgValidator(n.cfg.MaxValueSize)))
 		},
 		MsgHandler: n.handlePubsubMessages,
 		ScoreIndex: n.idx,
@@ -278,10 +300,22 @@ func (n *p2pNetwork) setupPubsub() error {
 		ValidateThrottle:    n.cfg.PubsubValidateThrottle,
 		MsgIDCacheTTL:       n.cfg.PubsubMsgCacheTTL,
 		GetValidatorStats:   n.cfg.GetValidatorStats,
+		MeshDegree: params.MeshDegree{
+			D:   n.cfg.PubsubD,
+			Dlo: n.cfg.PubsubDlo,
+			Dhi: n.cfg.PubsubDhi,
+		},
 	}
 
 	if !n.cfg.PubSubScoring {
 		cfg.ScoreIndex = nil
+	} else if len(n.cfg.PubSubIPWhitelist) > 0 {
+		ipWhitelist, err := topics.ParseIPWhitelist(n.cfg.PubSubIPWhitelist)
+		if err != nil {
+			return errors.Wrap(err, "could not parse pubsub IP whitelist")
+		}
+		cfg.Scoring = topics.DefaultScoringConfig()
+		cfg.Scoring.IPWhilelist = ipWhitelist
 	}
 
 	if n.fork.MsgID() != nil {
@@ -12,8 +12,10 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/network/forks"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
+	protocolsync "github.com/bloxapp/ssv/protocol/v1/sync"
 )
 
 // LastDecided fetches last decided from a random set of peers
@@ -81,6 +83,89 @@ func (n *p2pNetwork) GetHistory(mid spectypes.MessageID, from, to specqbft.Heigh
 	return results, currentEnd, nil
 }
 
+// SyncDecidedByRange fetches the decided messages of the given (inclusive) range from a set of peers that supports history,
+// in batches of MaxBatchResponse. the fetched messages are passed to the message router, duplicates are routed once
+func (n *p2pNetwork) SyncDecidedByRange(mid spectypes.MessageID, from, to specqbft.Height) error {
+	if from > to {
+		return errors.Errorf("invalid range [%d, %d]", from, to)
+	}
+	if !n.isReady() {
+		return p2pprotocol.ErrNetworkIsNotReady
+	}
+	if n.msgRouter == nil {
+		return errors.New("msg router is not configured")
+	}
+	protocolID, peerCount := n.fork.ProtocolID(p2pprotocol.DecidedHistoryProtocol)
+	peers, err := n.getSubsetOfPeers(mid.GetPubKey(), peerCount, n.peersWithProtocolsFilter(string(protocolID)))
+	if err != nil {
+		return errors.Wrap(err, "could not get subset of peers")
+	}
+	maxBatchRes := specqbft.Height(n.cfg.MaxBatchResponse)
+
+	msgID := n.fork.MsgID()
+	distinct := make(map[string]bool)
+	for batchStart := from; ; {
+		batchEnd := to
+		if to-batchStart > maxBatchRes {
+			batchEnd = batchStart + maxBatchRes
+		}
+		results, err := n.makeSyncRequest(peers, mid, protocolID, &message.SyncMessage{
+			Params: &message.SyncParams{
+				Height:     []specqbft.Height{batchStart, batchEnd},
+				Identifier: mid,
+			},
+			Protocol: message.DecidedHistoryType,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not sync decided range [%d, %d]", batchStart, batchEnd)
+		}
+		for _, res := range results {
+			n.routeSyncResult(mid, res, msgID, distinct)
+		}
+		if batchEnd >= to {
+			return nil
+		}
+		batchStart = batchEnd + 1
+	}
+}
+
+// routeSyncResult passes the decided messages of the given sync result to the message router,
+// messages that were already routed (according to distinct) are skipped
+func (n *p2pNetwork) routeSyncResult(mid spectypes.MessageID, res p2pprotocol.SyncResult, msgID forks.MsgIDFunc, distinct map[string]bool) {
+	logger := n.logger.With(zap.String("identifier", mid.String()), zap.String("peer", res.Sender))
+	if res.Msg == nil {
+		return
+	}
+	syncMsg, err := protocolsync.ExtractSyncMsg(res.Msg)
+	if err != nil {
+		logger.Debug("could not extract sync message", zap.Error(err))
+		return
+	}
+	if syncMsg == nil { // not found
+		return
+	}
+	for _, signedMsg := range syncMsg.Data {
+		if signedMsg == nil || signedMsg.Message == nil {
+			continue
+		}
+		data, err := signedMsg.Encode()
+		if err != nil {
+			logger.Debug("could not encode decided message", zap.Error(err))
+			continue
+		}
+		id := msgID(data)
+		if distinct[id] {
+			continue
+		}
+		distinct[id] = true
+		n.msgRouter.Route(spectypes.SSVMessage{
+			MsgType: spectypes.SSVDecidedMsgType,
+			MsgID:   mid,
+			Data:    data,
+		})
+	}
+}
+
 // LastChangeRound fetches last change round message from a random set of peers
 func (n *p2pNetwork) LastChangeRound(mid spectypes.MessageID, height specqbft.Height) ([]p2pprotocol.SyncResult, error) {
 	if !n.isReady() {
@@ -127,7 +212,7 @@ func (n *p2pNetwork) registerHandlers(pid libp2p_protocol.ID, handlers ...p2ppro
 			n.logger.Warn("could not handle stream", zap.Error(err))
 			return
 		}
-		smsg, err := n.fork.DecodeNetworkMsg(req)
+		smsg, err := n.decodeStreamMsg(req)
 		if err != nil {
 			n.logger.Warn("could not decode msg from stream", zap.Error(err))
 			return
@@ -149,6 +234,15 @@ func (n *p2pNetwork) registerHandlers(pid libp2p_protocol.ID, handlers ...p2ppro
 	})
 }
 
+// decodeStreamMsg decodes the given stream message, oversized messages are rejected before decoding
+func (n *p2pNetwork) decodeStreamMsg(req []byte) (*spectypes.SSVMessage, error) {
+	if n.cfg.MaxMessageSize > 0 && len(req) > n.cfg.MaxMessageSize {
+		metricsStreamOversizedMsgs.Inc()
+		return nil, errors.Errorf("message size %d exceeds the limit of %d bytes", len(req), n.cfg.MaxMessageSize)
+	}
+	return n.fork.DecodeNetworkMsg(req)
+}
+
 // getSubsetOfPeers returns a subset of the peers from that topic
 func (n *p2pNetwork) getSubsetOfPeers(vpk spectypes.ValidatorPK, peerCount int, filter func(peer.ID) bool) (peers []peer.ID, err error) {
 	var ps []peer.ID
@@ -202,7 +296,7 @@ func (n *p2pNetwork) makeSyncRequest(peers []peer.ID, mid spectypes.MessageID, p
 	distinct := make(map[string]bool)
 	for _, pid := range peers {
 		logger := plogger.With(zap.String("peer", pid.String()))
-		raw, err := n.streamCtrl.Request(pid, protocol, encoded)
+		raw, err := n.streamCtrl.Request(n.ctx, pid, protocol, encoded)
 		if err != nil {
 			logger.Debug("could not make stream request", zap.Error(err))
 			continue
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/protocol/v1/message"
 	protcolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"sync"
 	"sync/atomic"
@@ -17,6 +18,7 @@ import (
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -32,6 +34,24 @@ func TestGetMaxPeers(t *testing.T) {
 	require.Equal(t, 16, n.getMaxPeers(n.fork.DecidedTopic()))
 }
 
+func TestDecodeStreamMsg(t *testing.T) {
+	n := &p2pNetwork{
+		cfg:  &Config{MaxMessageSize: 1024},
+		fork: forksfactory.NewFork(forksprotocol.GenesisForkVersion),
+	}
+
+	msg, err := dummyMsg("b768cdc2b2e0a859052bf04d1cd66383c96d95096a5287d08151494ce709556ba39c1300fbb902a0e2ebb7c31dc4e400", 1)
+	require.NoError(t, err)
+	raw, err := n.fork.EncodeNetworkMsg(msg)
+	require.NoError(t, err)
+	decoded, err := n.decodeStreamMsg(raw)
+	require.NoError(t, err)
+	require.Equal(t, msg.MsgID, decoded.MsgID)
+
+	_, err = n.decodeStreamMsg(make([]byte, 1025))
+	require.EqualError(t, err, "message size 1025 exceeds the limit of 1024 bytes")
+}
+
 func TestP2pNetwork_SubscribeBroadcast(t *testing.T) {
 	n := 4
 	ctx, cancel := context.WithCancel(context.Background())
@@ -141,6 +161,148 @@ func TestP2pNetwork_Stream(t *testing.T) {
 	require.GreaterOrEqual(t, msgCounter, int64(9))
 }
 
+func TestP2pNetwork_HistoryPeers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pks := []string{"b768cdc2b2e0a859052bf04d1cd66383c96d95096a5287d08151494ce709556ba39c1300fbb902a0e2ebb7c31dc4e400"}
+	ln, _, err := createNetworkAndSubscribe(ctx, t, 4, forksprotocol.GenesisForkVersion, pks...)
+	require.NoError(t, err)
+
+	// only 2 of the other nodes support decided history
+	for _, node := range ln.Nodes[1:3] {
+		node.RegisterHandlers(&protcolp2p.SyncHandler{
+			Protocol: protcolp2p.DecidedHistoryProtocol,
+			Handler: func(message *spectypes.SSVMessage) (*spectypes.SSVMessage, error) {
+				return message, nil
+			},
+		})
+	}
+
+	node := ln.Nodes[0].(*p2pNetwork)
+	require.Eventually(t, func() bool {
+		node.reportHistoryPeers()
+		return testutil.ToFloat64(metricsHistoryPeers) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestP2pNetwork_SyncDecidedByRange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pkHex := "b768cdc2b2e0a859052bf04d1cd66383c96d95096a5287d08151494ce709556ba39c1300fbb902a0e2ebb7c31dc4e400"
+	ln, _, err := createNetworkAndSubscribe(ctx, t, 4, forksprotocol.GenesisForkVersion, pkHex)
+	require.NoError(t, err)
+	pk, err := hex.DecodeString(pkHex)
+	require.NoError(t, err)
+	mid := spectypes.NewMsgID(pk, spectypes.BNRoleAttester)
+
+	// the other nodes respond with the same decided messages of the requested range
+	var requests int64
+	for _, node := range ln.Nodes[1:] {
+		registerDecidedHistoryHandler(node, mid, &requests)
+	}
+
+	node := ln.Nodes[0].(*p2pNetwork)
+	router := &decidedRouter{heights: make(map[specqbft.Height]int)}
+	node.UseMessageRouter(router)
+
+	from, to := specqbft.Height(0), specqbft.Height(59)
+	require.Eventually(t, func() bool {
+		router.reset()
+		if err := node.SyncDecidedByRange(mid, from, to); err != nil {
+			return false
+		}
+		return router.distinctHeights() == int(to-from+1)
+	}, 5*time.Second, 100*time.Millisecond)
+	// each message is routed once, although it was received from several peers
+	require.Equal(t, int(to-from+1), router.routed())
+	require.Greater(t, atomic.LoadInt64(&requests), int64(1))
+
+	require.Error(t, node.SyncDecidedByRange(mid, to, from))
+	require.ErrorIs(t, (&p2pNetwork{}).SyncDecidedByRange(mid, from, to), protcolp2p.ErrNetworkIsNotReady)
+}
+
+// decidedRouter counts the routed decided messages by height
+type decidedRouter struct {
+	lock    sync.Mutex
+	heights map[specqbft.Height]int
+}
+
+func (r *decidedRouter) Route(msg spectypes.SSVMessage) {
+	if msg.MsgType != spectypes.SSVDecidedMsgType {
+		return
+	}
+	signedMsg := &specqbft.SignedMessage{}
+	if err := signedMsg.Decode(msg.Data); err != nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.heights[signedMsg.Message.Height]++
+}
+
+func (r *decidedRouter) reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.heights = make(map[specqbft.Height]int)
+}
+
+func (r *decidedRouter) distinctHeights() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.heights)
+}
+
+func (r *decidedRouter) routed() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	total := 0
+	for _, n := range r.heights {
+		total += n
+	}
+	return total
+}
+
+func registerDecidedHistoryHandler(node network.P2PNetwork, mid spectypes.MessageID, counter *int64) {
+	node.RegisterHandlers(&protcolp2p.SyncHandler{
+		Protocol: protcolp2p.DecidedHistoryProtocol,
+		Handler: func(msg *spectypes.SSVMessage) (*spectypes.SSVMessage, error) {
+			atomic.AddInt64(counter, 1)
+			sm := &message.SyncMessage{}
+			if err := sm.Decode(msg.Data); err != nil {
+				return nil, err
+			}
+			from, to := sm.Params.Height[0], sm.Params.Height[1]
+			results := make([]*specqbft.SignedMessage, 0, to-from+1)
+			for h := from; h <= to; h++ {
+				results = append(results, &specqbft.SignedMessage{
+					Signature: []byte("xxx"),
+					Signers:   []spectypes.OperatorID{1, 2, 3},
+					Message: &specqbft.Message{
+						MsgType:    specqbft.CommitMsgType,
+						Height:     h,
+						Round:      1,
+						Identifier: mid[:],
+						Data:       []byte("dummy decided message"),
+					},
+				})
+			}
+			sm.Data = results
+			sm.Status = message.StatusSuccess
+			data, err := sm.Encode()
+			if err != nil {
+				return nil, err
+			}
+			return &spectypes.SSVMessage{
+				MsgType: message.SSVSyncMsgType,
+				MsgID:   mid,
+				Data:    data,
+			}, nil
+		},
+	})
+}
+
 func registerHandler(node network.P2PNetwork, mid spectypes.MessageID, height specqbft.Height, round specqbft.Round, counter *int64) {
 	node.RegisterHandlers(&protcolp2p.SyncHandler{
 		Protocol: protcolp2p.LastChangeRoundProtocol,
@@ -17,27 +17,36 @@ type ConnHandler interface {
 	Handle() *libp2pnetwork.NotifyBundle
 }
 
+// defaultHandshakeTimeout is the default time we wait for a handshake to complete
+const defaultHandshakeTimeout = 30 * time.Second
+
 // connHandler implements ConnHandler
 type connHandler struct {
 	ctx    context.Context
 	logger *zap.Logger
 
-	handshaker      Handshaker
-	subnetsProvider SubnetsProvider
-	subnetsIndex    peers.SubnetsIndex
-	connIdx         peers.ConnectionIndex
+	handshaker       Handshaker
+	handshakeTimeout time.Duration
+	subnetsProvider  SubnetsProvider
+	subnetsIndex     peers.SubnetsIndex
+	connIdx          peers.ConnectionIndex
 }
 
-// NewConnHandler creates a new connection handler
-func NewConnHandler(ctx context.Context, logger *zap.Logger, handshaker Handshaker, subnetsProvider SubnetsProvider,
-	subnetsIndex peers.SubnetsIndex, connIdx peers.ConnectionIndex) ConnHandler {
+// NewConnHandler creates a new connection handler,
+// handshakeTimeout limits the time we wait for a handshake, a default timeout is used if not positive
+func NewConnHandler(ctx context.Context, logger *zap.Logger, handshaker Handshaker, handshakeTimeout time.Duration,
+	subnetsProvider SubnetsProvider, subnetsIndex peers.SubnetsIndex, connIdx peers.ConnectionIndex) ConnHandler {
+	if handshakeTimeout <= 0 {
+		handshakeTimeout = defaultHandshakeTimeout
+	}
 	return &connHandler{
-		ctx:             ctx,
-		logger:          logger.With(zap.String("who", "ConnHandler")),
-		handshaker:      handshaker,
-		subnetsProvider: subnetsProvider,
-		subnetsIndex:    subnetsIndex,
-		connIdx:         connIdx,
+		ctx:              ctx,
+		logger:           logger.With(zap.String("who", "ConnHandler")),
+		handshaker:       handshaker,
+		handshakeTimeout: handshakeTimeout,
+		subnetsProvider:  subnetsProvider,
+		subnetsIndex:     subnetsIndex,
+		connIdx:          connIdx,
 	}
 }
 
@@ -124,22 +133,46 @@ func (ch *connHandler) Handle() *libp2pnetwork.NotifyBundle {
 }
 
 func (ch *connHandler) handshake(conn libp2pnetwork.Conn) (bool, error) {
-	err := ch.handshaker.Handshake(conn)
+	err := ch.handshakeWithTimeout(conn)
 	if err != nil {
 		switch err {
 		case peers.ErrIndexingInProcess, errHandshakeInProcess:
 			// ignored errors
+			metricsHandshakes.WithLabelValues(handshakeResultInProcess).Inc()
 			return true, nil
-		case errPeerWasFiltered, errUnknownUserAgent, peerstore.ErrNotFound:
+		case errPeerWasFiltered:
+			// ignored errors but we still close connection
+			metricsHandshakes.WithLabelValues(handshakeResultFiltered).Inc()
+			return false, nil
+		case errUnknownUserAgent, peerstore.ErrNotFound:
 			// ignored errors but we still close connection
+			metricsHandshakes.WithLabelValues(handshakeResultUnknownAgent).Inc()
 			return false, nil
+		case errHandshakeTimeout:
+			metricsHandshakes.WithLabelValues(handshakeResultTimeout).Inc()
 		default:
+			metricsHandshakes.WithLabelValues(handshakeResultError).Inc()
 		}
 		return false, err
 	}
+	metricsHandshakes.WithLabelValues(handshakeResultSuccess).Inc()
 	return true, nil
 }
 
+// handshakeWithTimeout runs the handshake and returns errHandshakeTimeout if it didn't complete in time,
+// so a peer that stalls mid-handshake won't hold the connections queue.
+// the handshake is stopped once the timeout is reached, which resets any pending handshake stream
+func (ch *connHandler) handshakeWithTimeout(conn libp2pnetwork.Conn) error {
+	ctx, cancel := context.WithTimeout(ch.ctx, ch.handshakeTimeout)
+	defer cancel()
+
+	err := ch.handshaker.Handshake(ctx, conn)
+	if ctx.Err() == context.DeadlineExceeded {
+		return errHandshakeTimeout
+	}
+	return err
+}
+
 func (ch *connHandler) checkSubnets(conn libp2pnetwork.Conn) bool {
 	pid := conn.RemotePeer()
 	subnets := ch.subnetsIndex.GetPeerSubnets(pid)
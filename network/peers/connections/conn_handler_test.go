@@ -0,0 +1,46 @@
+package connections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConnHandler_HandshakeTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hs := &blockingHandshaker{done: make(chan struct{})}
+	ch := NewConnHandler(ctx, zap.L(), hs, time.Millisecond*50, nil, nil, nil).(*connHandler)
+
+	start := time.Now()
+	ok, err := ch.handshake(nil)
+	require.False(t, ok)
+	require.ErrorIs(t, err, errHandshakeTimeout)
+	require.Less(t, time.Since(start), time.Second)
+	// the handshake was stopped rather than left running in the background
+	select {
+	case <-hs.done:
+	default:
+		t.Fatal("handshake should be stopped once timed out")
+	}
+}
+
+// blockingHandshaker blocks until the handshake context is done
+type blockingHandshaker struct {
+	done chan struct{}
+}
+
+func (h *blockingHandshaker) Handshake(ctx context.Context, conn libp2pnetwork.Conn) error {
+	defer close(h.done)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (h *blockingHandshaker) Handler() libp2pnetwork.StreamHandler {
+	return func(stream libp2pnetwork.Stream) {}
+}
@@ -2,6 +2,7 @@ package connections
 
 import (
 	"github.com/bloxapp/ssv/network/records"
+	"github.com/bloxapp/ssv/utils/commons"
 	"github.com/pkg/errors"
 )
 
@@ -14,3 +15,24 @@ func NetworkIDFilter(networkID string) HandshakeFilter {
 		return true, nil
 	}
 }
+
+// MinVersionFilter determines whether we will connect to the given node by its version,
+// nodes that are running an older version or with an unparseable version are rejected
+func MinVersionFilter(minVersion commons.Version) HandshakeFilter {
+	return func(ni *records.NodeInfo) (bool, error) {
+		if ni.Metadata == nil {
+			metricsVersionRejected.Inc()
+			return false, errors.New("missing node version")
+		}
+		v, err := commons.ParseVersion(ni.Metadata.NodeVersion)
+		if err != nil {
+			metricsVersionRejected.Inc()
+			return false, errors.Wrap(err, "could not parse node version")
+		}
+		if v.Compare(minVersion) < 0 {
+			metricsVersionRejected.Inc()
+			return false, errors.Errorf("node version '%s' is older than the minimum version", ni.Metadata.NodeVersion)
+		}
+		return true, nil
+	}
+}
@@ -2,6 +2,7 @@ package connections
 
 import (
 	"github.com/bloxapp/ssv/network/records"
+	"github.com/bloxapp/ssv/utils/commons"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -21,3 +22,40 @@ func TestNetworkIDFilter(t *testing.T) {
 	require.Error(t, err)
 	require.False(t, ok)
 }
+
+func TestMinVersionFilter(t *testing.T) {
+	minVersion, err := commons.ParseVersion("v0.3.0")
+	require.NoError(t, err)
+	f := MinVersionFilter(minVersion)
+
+	tests := []struct {
+		name    string
+		version string
+		ok      bool
+	}{
+		{"too old", "v0.2.9", false},
+		{"same version", "v0.3.0", true},
+		{"compatible", "v0.3.1-rc1", true},
+		{"newer major", "v1.0.0", true},
+		{"unparseable", "latest", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, err := f(&records.NodeInfo{
+				Metadata: &records.NodeMetadata{NodeVersion: test.version},
+			})
+			require.Equal(t, test.ok, ok)
+			if test.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	t.Run("missing metadata", func(t *testing.T) {
+		ok, err := f(&records.NodeInfo{})
+		require.Error(t, err)
+		require.False(t, ok)
+	})
+}
@@ -31,6 +31,9 @@ var errPeerWasFiltered = errors.New("peer was filtered during handshake")
 // errUnknownUserAgent is thrown when a peer has an unknown user agent
 var errUnknownUserAgent = errors.New("user agent is unknown")
 
+// errHandshakeTimeout is thrown when a handshake didn't complete in time
+var errHandshakeTimeout = errors.New("handshake timeout")
+
 // HandshakeFilter can be used to filter nodes once we handshaked with them
 type HandshakeFilter func(info *records.NodeInfo) (bool, error)
 
@@ -43,7 +46,8 @@ type SubnetsProvider func() records.Subnets
 // NOTE: due to compatibility with v0,
 // we accept nodes with user agent as a fallback when the new protocol is not supported.
 type Handshaker interface {
-	Handshake(conn libp2pnetwork.Conn) error
+	// Handshake handshakes with the peer of the given conn, it stops once the given context is done
+	Handshake(ctx context.Context, conn libp2pnetwork.Conn) error
 	Handler() libp2pnetwork.StreamHandler
 }
 
@@ -163,8 +167,8 @@ func (h *handshaker) processIncomingNodeInfo(pid peer.ID, ni records.NodeInfo) e
 // preHandshake makes sure that we didn't reach peers limit and have exchanged framework information (libp2p)
 // with the peer on the other side of the connection.
 // it should enable us to know the supported protocols of peers we connect to
-func (h *handshaker) preHandshake(conn libp2pnetwork.Conn) error {
-	ctx, cancel := context.WithTimeout(h.ctx, time.Second*15)
+func (h *handshaker) preHandshake(ctx context.Context, conn libp2pnetwork.Conn) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*15)
 	defer cancel()
 	select {
 	case <-ctx.Done():
@@ -175,7 +179,7 @@ func (h *handshaker) preHandshake(conn libp2pnetwork.Conn) error {
 }
 
 // Handshake initiates handshake with the given conn
-func (h *handshaker) Handshake(conn libp2pnetwork.Conn) error {
+func (h *handshaker) Handshake(ctx context.Context, conn libp2pnetwork.Conn) error {
 	pid := conn.RemotePeer()
 	if _, loaded := h.pending.LoadOrStore(pid.String(), true); loaded {
 		return errHandshakeInProcess
@@ -186,13 +190,13 @@ func (h *handshaker) Handshake(conn libp2pnetwork.Conn) error {
 	if err != nil || ni != nil {
 		return err
 	}
-	if err := h.preHandshake(conn); err != nil {
+	if err := h.preHandshake(ctx, conn); err != nil {
 		return errors.Wrap(err, "could not perform pre-handshake")
 	}
-	ni, err = h.nodeInfoFromStream(conn)
+	ni, err = h.nodeInfoFromStream(ctx, conn)
 	if err != nil {
 		// fallbacks to user agent
-		ni, err = h.nodeInfoFromUserAgent(conn)
+		ni, err = h.nodeInfoFromUserAgent(ctx, conn)
 		if err != nil {
 			return err
 		}
@@ -243,7 +247,7 @@ func (h *handshaker) updateNodeSubnets(pid peer.ID, ni *records.NodeInfo) {
 	}
 }
 
-func (h *handshaker) nodeInfoFromStream(conn libp2pnetwork.Conn) (*records.NodeInfo, error) {
+func (h *handshaker) nodeInfoFromStream(ctx context.Context, conn libp2pnetwork.Conn) (*records.NodeInfo, error) {
 	res, err := h.net.Peerstore().FirstSupportedProtocol(conn.RemotePeer(), peers.NodeInfoProtocol)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not check supported protocols of peer %s",
@@ -256,7 +260,7 @@ func (h *handshaker) nodeInfoFromStream(conn libp2pnetwork.Conn) (*records.NodeI
 	if len(res) == 0 {
 		return nil, errors.Errorf("peer [%s] doesn't supports handshake protocol", conn.RemotePeer().String())
 	}
-	resBytes, err := h.streams.Request(conn.RemotePeer(), peers.NodeInfoProtocol, data)
+	resBytes, err := h.streams.Request(ctx, conn.RemotePeer(), peers.NodeInfoProtocol, data)
 	if err != nil {
 		return nil, err
 	}
@@ -268,14 +272,18 @@ func (h *handshaker) nodeInfoFromStream(conn libp2pnetwork.Conn) (*records.NodeI
 	return &ni, nil
 }
 
-func (h *handshaker) nodeInfoFromUserAgent(conn libp2pnetwork.Conn) (*records.NodeInfo, error) {
+func (h *handshaker) nodeInfoFromUserAgent(ctx context.Context, conn libp2pnetwork.Conn) (*records.NodeInfo, error) {
 	pid := conn.RemotePeer()
 	uaRaw, err := h.net.Peerstore().Get(pid, userAgentKey)
 	if err != nil {
 		if err == peerstore.ErrNotFound {
 			// if user agent wasn't found, retry libp2p identify after 100ms
-			time.Sleep(time.Millisecond * 100)
-			if err := h.preHandshake(conn); err != nil {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Millisecond * 100):
+			}
+			if err := h.preHandshake(ctx, conn); err != nil {
 				return nil, err
 			}
 			uaRaw, err = h.net.Peerstore().Get(pid, userAgentKey)
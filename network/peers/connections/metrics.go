@@ -19,6 +19,23 @@ var (
 		Name: "ssv:network:connections:filtered",
 		Help: "Counts opened/closed connections",
 	})
+	metricsHandshakes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:network:handshakes",
+		Help: "Counts handshakes by result",
+	}, []string{"result"})
+	metricsVersionRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:network:connections:version_rejected",
+		Help: "Counts peers that were rejected due to an incompatible version",
+	})
+)
+
+const (
+	handshakeResultSuccess      = "success"
+	handshakeResultTimeout      = "timeout"
+	handshakeResultFiltered     = "filtered"
+	handshakeResultUnknownAgent = "unknown-agent"
+	handshakeResultInProcess    = "in-process"
+	handshakeResultError        = "error"
 )
 
 func init() {
@@ -31,4 +48,10 @@ func init() {
 	if err := prometheus.Register(metricsFilteredConnections); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsHandshakes); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsVersionRejected); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
@@ -37,6 +37,9 @@ type ConnectionIndex interface {
 	CanConnect(id peer.ID) bool
 	// Limit checks if the node has reached peers limit
 	Limit(dir libp2pnetwork.Direction) bool
+	// AtCapacity checks if the node has no free slots for new connections,
+	// used to avoid dialing new peers that would be disconnected anyway
+	AtCapacity(dir libp2pnetwork.Direction) bool
 	// IsBad returns whether the given peer is bad
 	IsBad(id peer.ID) bool
 }
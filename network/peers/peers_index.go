@@ -109,6 +109,12 @@ func (pi *peersIndex) Limit(dir libp2pnetwork.Direction) bool {
 	return len(peers) > maxPeers
 }
 
+func (pi *peersIndex) AtCapacity(dir libp2pnetwork.Direction) bool {
+	maxPeers := pi.maxPeers("")
+	peers := pi.network.Peers()
+	return len(peers) >= maxPeers
+}
+
 func (pi *peersIndex) UpdateSelfRecord(newSelf *records.NodeInfo) {
 	pi.selfLock.Lock()
 	defer pi.selfLock.Unlock()
@@ -114,6 +114,38 @@ func (s Subnets) FromString(subnetsStr string) (Subnets, error) {
 	return data, nil
 }
 
+// StaticSubnets creates subnets of the given size, where only the given subnets are set
+func StaticSubnets(count int, subnets []int) (Subnets, error) {
+	s := make(Subnets, count)
+	for _, subnet := range subnets {
+		if subnet < 0 || subnet >= count {
+			return nil, errors.Errorf("invalid subnet %d, expected a value in [0, %d)", subnet, count)
+		}
+		s[subnet] = 1
+	}
+	return s, nil
+}
+
+// MergeSubnets returns the union of the two given subnets
+func MergeSubnets(a, b []byte) Subnets {
+	size := len(a)
+	if len(b) > size {
+		size = len(b)
+	}
+	merged := make(Subnets, size)
+	for subnet, val := range a {
+		if val > 0 {
+			merged[subnet] = 1
+		}
+	}
+	for subnet, val := range b {
+		if val > 0 {
+			merged[subnet] = 1
+		}
+	}
+	return merged
+}
+
 // SharedSubnets returns the shared subnets
 func SharedSubnets(a, b []byte, maxLen int) []int {
 	var shared []int
@@ -109,3 +109,27 @@ func TestDiffSubnets(t *testing.T) {
 	diff := DiffSubnets(s1, s2)
 	require.Len(t, diff, 128-62)
 }
+
+func TestMergeSubnets(t *testing.T) {
+	static, err := StaticSubnets(128, []int{0, 5, 127})
+	require.NoError(t, err)
+	require.Len(t, static, 128)
+
+	derived := make(Subnets, 128)
+	derived[5] = 1
+	derived[64] = 1
+
+	merged := MergeSubnets(derived, static)
+	require.Len(t, merged, 128)
+	require.Equal(t, []int{0, 5, 64, 127}, SharedSubnets(merged, merged, 0))
+
+	t.Run("no derived subnets", func(t *testing.T) {
+		merged := MergeSubnets(nil, static)
+		require.Equal(t, static.String(), merged.String())
+	})
+
+	t.Run("invalid static subnet", func(t *testing.T) {
+		_, err := StaticSubnets(128, []int{128})
+		require.Error(t, err)
+	})
+}
@@ -17,8 +17,9 @@ type StreamResponder func([]byte) error
 
 // StreamController simplifies the interaction with libp2p streams.
 type StreamController interface {
-	// Request sends a message to the given stream and returns the response
-	Request(peerID peer.ID, protocol protocol.ID, msg []byte) ([]byte, error)
+	// Request sends a message to the given stream and returns the response,
+	// the stream is reset once the given context is done
+	Request(ctx context.Context, peerID peer.ID, protocol protocol.ID, msg []byte) ([]byte, error)
 	// HandleStream is called at the beginning of stream handlers to create a wrapper stream and read first message
 	HandleStream(stream core.Stream) ([]byte, StreamResponder, func(), error)
 }
@@ -47,9 +48,10 @@ type streamCtrl struct {
 	requestTimeout time.Duration
 }
 
-// Request sends a message to the given stream and returns the response
-func (n *streamCtrl) Request(peerID peer.ID, protocol protocol.ID, data []byte) ([]byte, error) {
-	s, err := n.host.NewStream(n.ctx, peerID, protocol)
+// Request sends a message to the given stream and returns the response,
+// the stream is reset once the given context is done so pending reads/writes won't wait for the request timeout
+func (n *streamCtrl) Request(ctx context.Context, peerID peer.ID, protocol protocol.ID, data []byte) ([]byte, error) {
+	s, err := n.host.NewStream(ctx, peerID, protocol)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +59,15 @@ func (n *streamCtrl) Request(peerID peer.ID, protocol protocol.ID, data []byte)
 	defer func() {
 		_ = stream.Close()
 	}()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = s.Reset()
+		case <-done:
+		}
+	}()
 	metricsStreamOutgoingRequests.WithLabelValues(string(protocol)).Inc()
 	metricsStreamRequestsActive.WithLabelValues(string(protocol)).Inc()
 	defer metricsStreamRequestsActive.WithLabelValues(string(protocol)).Dec()
@@ -37,7 +37,7 @@ func TestStreamCtrl(t *testing.T) {
 		})
 		d, err := dummyMsg().Encode()
 		require.NoError(t, err)
-		res, err := ctrl1.Request(hosts[0].ID(), prot, d)
+		res, err := ctrl1.Request(context.Background(), hosts[0].ID(), prot, d)
 		require.NoError(t, err)
 		require.NotNil(t, res)
 		require.True(t, bytes.Equal(res, d))
@@ -56,11 +56,28 @@ func TestStreamCtrl(t *testing.T) {
 		})
 		d, err := dummyMsg().Encode()
 		require.NoError(t, err)
-		res, err := ctrl0.Request(hosts[0].ID(), prot, d)
+		res, err := ctrl0.Request(context.Background(), hosts[0].ID(), prot, d)
 		require.Error(t, err)
 		require.Nil(t, res)
 	})
 
+	t.Run("request cancellation", func(t *testing.T) {
+		ctrl1.(*streamCtrl).requestTimeout = time.Second * 5
+		hosts[2].SetStreamHandler(prot, func(stream libp2pnetwork.Stream) {
+			// never responds
+			<-time.After(time.Second * 2)
+			_ = stream.Close()
+		})
+		d, err := dummyMsg().Encode()
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+		defer cancel()
+		start := time.Now()
+		res, err := ctrl1.Request(ctx, hosts[2].ID(), prot, d)
+		require.Error(t, err)
+		require.Nil(t, res)
+		require.Less(t, time.Since(start), time.Second)
+	})
 }
 
 func dummyMsg() *spectypes.SSVMessage {
@@ -0,0 +1,80 @@
+package testing
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/protocol"
+
+	"github.com/bloxapp/ssv/network/forks"
+	forksfactory "github.com/bloxapp/ssv/network/forks/factory"
+	"github.com/bloxapp/ssv/network/forks/genesis"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
+)
+
+// SyntheticFork is a network fork with configurable subnets and protocols,
+// anything that was not configured falls back to the genesis fork
+type SyntheticFork struct {
+	forks.Fork
+
+	// SubnetsCount is the amount of subnets, 0 falls back to genesis
+	SubnetsCount int
+	// SubnetOf maps a validator public key (hex) to its subnet, nil falls back to genesis
+	SubnetOf func(validatorPKHex string) int
+	// Protocols maps sync protocols to their protocol ids, missing protocols fall back to genesis
+	Protocols map[p2pprotocol.SyncProtocol]protocol.ID
+	// PeersForSync is the amount of peers for distribution of the configured protocols
+	PeersForSync int
+}
+
+// NewSyntheticFork creates a new synthetic fork on top of genesis
+func NewSyntheticFork() *SyntheticFork {
+	return &SyntheticFork{Fork: genesis.New()}
+}
+
+// RegisterSyntheticFork registers the given fork for the given version,
+// the fork is unregistered once the test is done
+func RegisterSyntheticFork(t testing.TB, forkVersion forksprotocol.ForkVersion, fork *SyntheticFork) {
+	if fork.Fork == nil {
+		fork.Fork = genesis.New()
+	}
+	t.Cleanup(forksfactory.RegisterFork(forkVersion, fork))
+}
+
+// Subnets returns the configured subnets count
+func (f *SyntheticFork) Subnets() int {
+	if f.SubnetsCount > 0 {
+		return f.SubnetsCount
+	}
+	return f.Fork.Subnets()
+}
+
+// ValidatorSubnet returns the configured subnet of the given validator
+func (f *SyntheticFork) ValidatorSubnet(validatorPKHex string) int {
+	if f.SubnetOf != nil {
+		return f.SubnetOf(validatorPKHex)
+	}
+	return f.Fork.ValidatorSubnet(validatorPKHex)
+}
+
+// ValidatorTopicID maps the given validator public key to the topic of its configured subnet
+func (f *SyntheticFork) ValidatorTopicID(pk []byte) []string {
+	if f.SubnetOf == nil {
+		return f.Fork.ValidatorTopicID(pk)
+	}
+	subnet := f.ValidatorSubnet(hex.EncodeToString(pk))
+	if subnet < 0 {
+		return []string{genesis.UnknownSubnet}
+	}
+	return []string{strconv.Itoa(subnet)}
+}
+
+// ProtocolID returns the configured protocol id of the given protocol
+func (f *SyntheticFork) ProtocolID(prot p2pprotocol.SyncProtocol) (protocol.ID, int) {
+	if pid, ok := f.Protocols[prot]; ok {
+		return pid, f.PeersForSync
+	}
+	return f.Fork.ProtocolID(prot)
+}
@@ -29,6 +29,9 @@ type Controller interface {
 	Topics() []string
 	// Broadcast publishes the message on the given topic
 	Broadcast(topicName string, data []byte, timeout time.Duration) error
+	// PeerScores returns the latest snapshot of peers scores (overall and per topic),
+	// the snapshot is empty if scoring is disabled
+	PeerScores() map[peer.ID]*pubsub.PeerScoreSnapshot
 
 	io.Closer
 }
@@ -46,6 +49,7 @@ type topicsCtrl struct {
 	msgValidatorFactory func(string) MsgValidatorFunc
 	msgHandler          PubsubMessageHandler
 	subFilter           SubFilter
+	peerScores          func() map[peer.ID]*pubsub.PeerScoreSnapshot
 
 	containers map[string]*topicContainer
 	topicsLock *sync.RWMutex
@@ -53,10 +57,12 @@ type topicsCtrl struct {
 	fork forks.Fork
 }
 
-// NewTopicsController creates an instance of Controller
+// NewTopicsController creates an instance of Controller.
+// peerScores provides the latest snapshot of peers scores, it can be nil if scoring is disabled
 func NewTopicsController(ctx context.Context, logger *zap.Logger, msgHandler PubsubMessageHandler,
 	msgValidatorFactory func(string) MsgValidatorFunc, subFilter SubFilter, pubSub *pubsub.PubSub,
-	fork forks.Fork, scoreParams func(string) *pubsub.TopicScoreParams) Controller {
+	fork forks.Fork, scoreParams func(string) *pubsub.TopicScoreParams,
+	peerScores func() map[peer.ID]*pubsub.PeerScoreSnapshot) Controller {
 	ctrl := &topicsCtrl{
 		ctx:                 ctx,
 		logger:              logger,
@@ -68,7 +74,8 @@ func NewTopicsController(ctx context.Context, logger *zap.Logger, msgHandler Pub
 		topicsLock: &sync.RWMutex{},
 		containers: make(map[string]*topicContainer),
 
-		subFilter: subFilter,
+		subFilter:  subFilter,
+		peerScores: peerScores,
 
 		fork: fork,
 	}
@@ -107,11 +114,25 @@ func (ctrl *topicsCtrl) Topics() []string {
 	return topics
 }
 
+// PeerScores returns the latest snapshot of peers scores
+func (ctrl *topicsCtrl) PeerScores() map[peer.ID]*pubsub.PeerScoreSnapshot {
+	if ctrl.peerScores == nil {
+		return map[peer.ID]*pubsub.PeerScoreSnapshot{}
+	}
+	return ctrl.peerScores()
+}
+
 // Subscribe subscribes to the given topic, it can handle multiple concurrent calls.
 // it will create a single goroutine and channel for every topic
 func (ctrl *topicsCtrl) Subscribe(name string) error {
 	name = ctrl.fork.GetTopicFullName(name)
-	ctrl.subFilter.(Whitelist).Register(name)
+	if limiter, ok := ctrl.subFilter.(SubscriptionsLimiter); ok {
+		if err := limiter.TryRegister(name); err != nil {
+			return err
+		}
+	} else {
+		ctrl.subFilter.(Whitelist).Register(name)
+	}
 	ctrl.logger.Debug("subscribing to topic", zap.String("topic", name))
 	tc, err := ctrl.joinTopic(name)
 	if err == nil && tc != nil {
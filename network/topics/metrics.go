@@ -31,6 +31,22 @@ var (
 		Name: "ssv:p2p:pubsub:score:inspect",
 		Help: "Gauge for negative peer scores",
 	}, []string{"pid"})
+	metricStaticPeersConnected = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:static_peers:connected",
+		Help: "Count connected static peers",
+	})
+	metricStaticPeersTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:static_peers:total",
+		Help: "Count configured static peers",
+	})
+	metricSubscriptions = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:subscriptions",
+		Help: "Count current topic subscriptions",
+	})
+	metricSubscriptionsLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:subscriptions:limit",
+		Help: "The limit of topic subscriptions",
+	})
 )
 
 func init() {
@@ -52,6 +68,18 @@ func init() {
 	if err := prometheus.Register(metricPubsubPeerScoreInspect); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricStaticPeersConnected); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricStaticPeersTotal); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricSubscriptions); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricSubscriptionsLimit); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 type msgValidationResult string
@@ -59,6 +87,9 @@ type msgValidationResult string
 var (
 	validationResultNoData   msgValidationResult = "no_data"
 	validationResultEncoding msgValidationResult = "encoding"
+	validationResultSize     msgValidationResult = "size"
+	validationResultIgnored  msgValidationResult = "ignored"
+	validationResultInvalid  msgValidationResult = "invalid"
 )
 
 func reportValidationResult(result msgValidationResult) {
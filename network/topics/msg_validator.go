@@ -2,7 +2,9 @@ package topics
 
 import (
 	"context"
+	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/network/forks"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"go.uber.org/zap"
@@ -11,10 +13,14 @@ import (
 // MsgValidatorFunc represents a message validator
 type MsgValidatorFunc = func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult
 
+// MsgValidationHook validates a decoded message, e.g. by protocol rules that don't depend on state
+type MsgValidationHook func(msg *spectypes.SSVMessage) protocolp2p.MsgValidationResult
+
 // NewSSVMsgValidator creates a new msg validator that validates message structure,
 // and checks that the message was sent on the right topic.
+// decoded messages are passed to the given hooks, the first result that doesn't accept the message is returned.
 // TODO: enable post SSZ change, remove logs, break into smaller validators?
-func NewSSVMsgValidator(plogger *zap.Logger, fork forks.Fork, self peer.ID) func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+func NewSSVMsgValidator(plogger *zap.Logger, fork forks.Fork, self peer.ID, hooks ...MsgValidationHook) func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
 	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
 		topic := pmsg.GetTopic()
 		metricPubsubActiveMsgValidation.WithLabelValues(topic).Inc()
@@ -34,6 +40,16 @@ func NewSSVMsgValidator(plogger *zap.Logger, fork forks.Fork, self peer.ID) func
 			reportValidationResult(validationResultEncoding)
 			return pubsub.ValidationReject
 		}
+		for _, hook := range hooks {
+			if res := toPubsubValidationResult(hook(msg)); res != pubsub.ValidationAccept {
+				if res == pubsub.ValidationIgnore {
+					reportValidationResult(validationResultIgnored)
+				} else {
+					reportValidationResult(validationResultInvalid)
+				}
+				return res
+			}
+		}
 		pmsg.ValidatorData = *msg
 		return pubsub.ValidationAccept
 		// check decided topic
@@ -56,6 +72,31 @@ func NewSSVMsgValidator(plogger *zap.Logger, fork forks.Fork, self peer.ID) func
 	}
 }
 
+// NewMaxSizeMsgValidator wraps the given validator, messages that are larger than maxSize are rejected before decoding.
+// a non-positive maxSize disables the check
+func NewMaxSizeMsgValidator(maxSize int, validator MsgValidatorFunc) MsgValidatorFunc {
+	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+		if maxSize > 0 && len(pmsg.GetData()) > maxSize {
+			reportValidationResult(validationResultSize)
+			return pubsub.ValidationReject
+		}
+		return validator(ctx, p, pmsg)
+	}
+}
+
+// toPubsubValidationResult maps the given protocol validation result to pubsub validation result,
+// low severity rejections (e.g. late messages) are ignored so the sending peer won't be penalized
+func toPubsubValidationResult(res protocolp2p.MsgValidationResult) pubsub.ValidationResult {
+	switch res {
+	case protocolp2p.ValidationAccept:
+		return pubsub.ValidationAccept
+	case protocolp2p.ValidationIgnore, protocolp2p.ValidationRejectLow:
+		return pubsub.ValidationIgnore
+	default:
+		return pubsub.ValidationReject
+	}
+}
+
 //// CombineMsgValidators executes multiple validators
 //func CombineMsgValidators(validators ...MsgValidatorFunc) MsgValidatorFunc {
 //	return func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
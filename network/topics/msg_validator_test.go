@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/network/forks/genesis"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/bloxapp/ssv/utils/threshold"
 )
 
@@ -75,6 +76,62 @@ func TestMsgValidator(t *testing.T) {
 
 }
 
+func TestMsgValidator_Hooks(t *testing.T) {
+	pks := createSharePublicKeys(1)
+	f := genesis.ForkGenesis{}
+	msg, err := dummySSVConsensusMsg(pks[0], 15160)
+	require.NoError(t, err)
+	raw, err := msg.Encode()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		result   protocolp2p.MsgValidationResult
+		expected pubsub.ValidationResult
+	}{
+		{"accept", protocolp2p.ValidationAccept, pubsub.ValidationAccept},
+		{"ignore", protocolp2p.ValidationIgnore, pubsub.ValidationIgnore},
+		{"reject low is ignored", protocolp2p.ValidationRejectLow, pubsub.ValidationIgnore},
+		{"reject medium", protocolp2p.ValidationRejectMedium, pubsub.ValidationReject},
+		{"reject high", protocolp2p.ValidationRejectHigh, pubsub.ValidationReject},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var hooked *spectypes.SSVMessage
+			mv := NewSSVMsgValidator(zap.L(), &f, "xxxx", func(msg *spectypes.SSVMessage) protocolp2p.MsgValidationResult {
+				hooked = msg
+				return test.result
+			})
+			pmsg := newPBMsg(raw, "xxx", []byte{})
+			require.Equal(t, test.expected, mv(context.Background(), "xxxx", pmsg))
+			require.NotNil(t, hooked)
+			require.Equal(t, msg.MsgID, hooked.MsgID)
+			// only accepted messages are passed on
+			require.Equal(t, test.expected == pubsub.ValidationAccept, pmsg.ValidatorData != nil)
+		})
+	}
+}
+
+func TestMaxSizeMsgValidator(t *testing.T) {
+	called := false
+	mv := NewMaxSizeMsgValidator(64, func(ctx context.Context, p peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		called = true
+		return pubsub.ValidationAccept
+	})
+
+	t.Run("oversized message", func(t *testing.T) {
+		pmsg := newPBMsg(make([]byte, 65), "xxx", []byte{})
+		require.Equal(t, pubsub.ValidationReject, mv(context.Background(), "xxxx", pmsg))
+		require.False(t, called, "oversized message should be rejected before decoding")
+	})
+
+	t.Run("message within limit", func(t *testing.T) {
+		pmsg := newPBMsg(make([]byte, 64), "xxx", []byte{})
+		require.Equal(t, pubsub.ValidationAccept, mv(context.Background(), "xxxx", pmsg))
+		require.True(t, called)
+	})
+}
+
 func createSharePublicKeys(n int) []string {
 	threshold.Init()
 
@@ -2,6 +2,7 @@ package params
 
 import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
 	"time"
 )
 
@@ -43,3 +44,34 @@ func GossipSubParams() pubsub.GossipSubParams {
 
 	return params
 }
+
+// MeshDegree holds overrides for the mesh degree of gossipsub topics, zero values fallback to the defaults
+type MeshDegree struct {
+	D   int
+	Dlo int
+	Dhi int
+}
+
+// GossipSubParamsWithMesh creates a gossipsub parameter set with the given mesh degree overrides.
+// it returns an error if the resulting parameters doesn't satisfy gossipsub constraints (Dout < Dlo <= D <= Dhi)
+func GossipSubParamsWithMesh(mesh MeshDegree) (pubsub.GossipSubParams, error) {
+	params := GossipSubParams()
+	if mesh.D > 0 {
+		params.D = mesh.D
+	}
+	if mesh.Dlo > 0 {
+		params.Dlo = mesh.Dlo
+	}
+	if mesh.Dhi > 0 {
+		params.Dhi = mesh.Dhi
+	}
+	if params.Dlo > params.D || params.D > params.Dhi {
+		return params, errors.Errorf("invalid mesh degree: expected Dlo <= D <= Dhi, got Dlo=%d D=%d Dhi=%d",
+			params.Dlo, params.D, params.Dhi)
+	}
+	if params.Dout >= params.Dlo || params.Dout > params.D/2 {
+		return params, errors.Errorf("invalid mesh degree: expected Dout < Dlo and Dout <= D/2, got Dout=%d Dlo=%d D=%d",
+			params.Dout, params.Dlo, params.D)
+	}
+	return params, nil
+}
@@ -0,0 +1,48 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGossipSubParamsWithMesh(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		params, err := GossipSubParamsWithMesh(MeshDegree{})
+		require.NoError(t, err)
+		require.Equal(t, gsD, params.D)
+		require.Equal(t, gsDlo, params.Dlo)
+		require.Equal(t, gsDhi, params.Dhi)
+	})
+
+	t.Run("valid overrides", func(t *testing.T) {
+		params, err := GossipSubParamsWithMesh(MeshDegree{D: 6, Dlo: 4, Dhi: 10})
+		require.NoError(t, err)
+		require.Equal(t, 6, params.D)
+		require.Equal(t, 4, params.Dlo)
+		require.Equal(t, 10, params.Dhi)
+	})
+
+	t.Run("partial override", func(t *testing.T) {
+		params, err := GossipSubParamsWithMesh(MeshDegree{Dhi: 16})
+		require.NoError(t, err)
+		require.Equal(t, gsD, params.D)
+		require.Equal(t, 16, params.Dhi)
+	})
+
+	invalid := []struct {
+		name string
+		mesh MeshDegree
+	}{
+		{"Dlo greater than D", MeshDegree{D: 6, Dlo: 7, Dhi: 10}},
+		{"D greater than Dhi", MeshDegree{D: 12, Dlo: 6, Dhi: 10}},
+		{"D greater than default Dhi", MeshDegree{D: 14}},
+		{"Dlo not greater than Dout", MeshDegree{D: 4, Dlo: 2, Dhi: 6}},
+	}
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GossipSubParamsWithMesh(test.mesh)
+			require.Error(t, err)
+		})
+	}
+}
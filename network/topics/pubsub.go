@@ -33,6 +33,8 @@ var (
 	validateThrottle = 8192
 	// scoreInspectInterval is the interval for performing score inspect, which goes over all peers scores
 	scoreInspectInterval = time.Minute
+	// scoreSnapshotInterval is the interval for updating the snapshot of peers scores
+	scoreSnapshotInterval = 5 * time.Second
 	// msgIDCacheTTL specifies how long a message ID will be remembered as seen, 6.4m (as ETH 2.0)
 	msgIDCacheTTL = params.HeartbeatInterval * 550
 )
@@ -57,6 +59,8 @@ type PububConfig struct {
 	ValidationQueueSize int
 	OutboundQueueSize   int
 	MsgIDCacheTTL       time.Duration
+	// MeshDegree overrides the default mesh degree (D, Dlo, Dhi) of gossipsub
+	MeshDegree params.MeshDegree
 
 	GetValidatorStats network.GetValidatorStats
 }
@@ -110,6 +114,11 @@ func NewPubsub(ctx context.Context, cfg *PububConfig, fork forks.Fork) (*pubsub.
 		return nil, nil, err
 	}
 
+	gsParams, err := params.GossipSubParamsWithMesh(cfg.MeshDegree)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not create gossipsub params")
+	}
+
 	sf := newSubFilter(cfg.Logger, fork, subscriptionRequestLimit)
 	psOpts := []pubsub.Option{
 		pubsub.WithSeenMessagesTTL(cfg.MsgIDCacheTTL),
@@ -117,7 +126,7 @@ func NewPubsub(ctx context.Context, cfg *PububConfig, fork forks.Fork) (*pubsub.
 		pubsub.WithValidateQueueSize(cfg.ValidationQueueSize),
 		pubsub.WithValidateThrottle(cfg.ValidateThrottle),
 		pubsub.WithSubscriptionFilter(sf),
-		pubsub.WithGossipSubParams(params.GossipSubParams()),
+		pubsub.WithGossipSubParams(gsParams),
 		//pubsub.WithPeerFilter(func(pid peer.ID, topic string) bool {
 		//	cfg.Logger.Debug("pubsubTrace: filtering peer", zap.String("id", pid.String()), zap.String("topic", topic))
 		//	return true
@@ -129,12 +138,13 @@ func NewPubsub(ctx context.Context, cfg *PububConfig, fork forks.Fork) (*pubsub.
 	}
 
 	var topicScoreFactory func(string) *pubsub.TopicScoreParams
+	scores := newScoresSnapshot()
 	if cfg.ScoreIndex != nil {
 		cfg.initScoring()
-		inspector := scoreInspector(cfg.Logger.With(zap.String("who", "scoreInspector")), cfg.ScoreIndex)
+		inspector := scoreInspector(cfg.Logger.With(zap.String("who", "scoreInspector")), cfg.ScoreIndex, scores, scoreInspectInterval)
 		peerScoreParams := params.PeerScoreParams(cfg.Scoring.OneEpochDuration, cfg.MsgIDCacheTTL, cfg.Scoring.IPColocationWeight, 0, cfg.Scoring.IPWhilelist...)
 		psOpts = append(psOpts, pubsub.WithPeerScore(peerScoreParams, params.PeerScoreThresholds()),
-			pubsub.WithPeerScoreInspect(inspector, scoreInspectInterval))
+			pubsub.WithPeerScoreInspect(inspector, scoreSnapshotInterval))
 		async.Interval(ctx, time.Hour, func() {
 			// reset peer scores metric every hour because it has a label for peer ID which can grow infinitely
 			metricPubsubPeerScoreInspect.Reset()
@@ -154,6 +164,8 @@ func NewPubsub(ctx context.Context, cfg *PububConfig, fork forks.Fork) (*pubsub.
 
 	if len(cfg.StaticPeers) > 0 {
 		psOpts = append(psOpts, pubsub.WithDirectPeers(cfg.StaticPeers))
+		newStaticPeersReconnector(cfg.Logger, cfg.Host, cfg.StaticPeers, staticPeersMinBackoff, staticPeersMaxBackoff).
+			Start(ctx, staticPeersCheckInterval)
 	}
 
 	psOpts = append(psOpts, pubsub.WithEventTracer(newTracer(cfg.Logger, cfg.TraceLog)))
@@ -163,7 +175,7 @@ func NewPubsub(ctx context.Context, cfg *PububConfig, fork forks.Fork) (*pubsub.
 		return nil, nil, err
 	}
 
-	ctrl := NewTopicsController(ctx, cfg.Logger, cfg.MsgHandler, cfg.MsgValidatorFactory, sf, ps, fork, topicScoreFactory)
+	ctrl := NewTopicsController(ctx, cfg.Logger, cfg.MsgHandler, cfg.MsgValidatorFactory, sf, ps, fork, topicScoreFactory, scores.get)
 
 	return ps, ctrl, nil
 }
@@ -6,7 +6,11 @@ import (
 	"github.com/bloxapp/ssv/network/topics/params"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"net"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,10 +22,66 @@ func DefaultScoringConfig() *ScoringConfig {
 	}
 }
 
-// scoreInspector inspects scores and updates the score index accordingly
+// ParseIPWhitelist parses the given CIDR strings (IPv4 or IPv6) into IP networks,
+// that are used to whitelist IPs from colocation penalty
+func ParseIPWhitelist(cidrs []string) ([]*net.IPNet, error) {
+	var res []*net.IPNet
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if len(cidr) == 0 {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CIDR '%s' in IP whitelist", cidr)
+		}
+		res = append(res, ipNet)
+	}
+	return res, nil
+}
+
+// scoresSnapshot holds the latest snapshot of peers scores
+type scoresSnapshot struct {
+	lock   sync.RWMutex
+	scores map[peer.ID]*pubsub.PeerScoreSnapshot
+}
+
+func newScoresSnapshot() *scoresSnapshot {
+	return &scoresSnapshot{
+		scores: make(map[peer.ID]*pubsub.PeerScoreSnapshot),
+	}
+}
+
+func (s *scoresSnapshot) update(scores map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.scores = scores
+}
+
+// get returns a copy of the latest snapshot
+func (s *scoresSnapshot) get() map[peer.ID]*pubsub.PeerScoreSnapshot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	res := make(map[peer.ID]*pubsub.PeerScoreSnapshot, len(s.scores))
+	for pid, score := range s.scores {
+		res[pid] = score
+	}
+	return res
+}
+
+// scoreInspector inspects scores and updates the score index accordingly.
+// it updates the given snapshot on every call, while logs and metrics are reported once in reportInterval
 // TODO: finalize once validation is in place
-func scoreInspector(logger *zap.Logger, scoreIdx peers.ScoreIndex) pubsub.ExtendedPeerScoreInspectFn {
+func scoreInspector(logger *zap.Logger, scoreIdx peers.ScoreIndex, snapshot *scoresSnapshot, reportInterval time.Duration) pubsub.ExtendedPeerScoreInspectFn {
+	var lastReport time.Time
 	return func(scores map[peer.ID]*pubsub.PeerScoreSnapshot) {
+		snapshot.update(scores)
+		if time.Since(lastReport) < reportInterval {
+			return
+		}
+		lastReport = time.Now()
 		for pid, peerScores := range scores {
 			//scores := []*peers.NodeScore{
 			//	{
@@ -0,0 +1,107 @@
+package topics
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/bloxapp/ssv/network/peers"
+)
+
+func TestTopicsCtrl_PeerScores(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshotInterval := scoreSnapshotInterval
+	scoreSnapshotInterval = 100 * time.Millisecond
+	defer func() {
+		scoreSnapshotInterval = snapshotInterval
+	}()
+
+	f := genesis.New()
+	newScoredCtrl := func(h host.Host) Controller {
+		_, ctrl, err := NewPubsub(ctx, &PububConfig{
+			Logger: zap.L(),
+			Host:   h,
+			MsgHandler: func(topic string, msg *pubsub.Message) error {
+				return nil
+			},
+			ScoreIndex: &mockScoreIndex{},
+			Scoring: &ScoringConfig{
+				OneEpochDuration: time.Minute,
+			},
+		}, f)
+		require.NoError(t, err)
+		return ctrl
+	}
+
+	h1, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	h2, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	ctrl1 := newScoredCtrl(h1)
+	ctrl2 := newScoredCtrl(h2)
+	require.Empty(t, ctrl2.PeerScores())
+
+	require.NoError(t, h1.Connect(ctx, peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+	require.NoError(t, ctrl1.Subscribe("1"))
+	require.NoError(t, ctrl2.Subscribe("1"))
+
+	require.Eventually(t, func() bool {
+		_ = ctrl1.Broadcast("1", []byte("dummy"), time.Second)
+		_, ok := ctrl2.PeerScores()[h1.ID()]
+		return ok
+	}, time.Second*10, time.Millisecond*200)
+}
+
+type mockScoreIndex struct{}
+
+func (m *mockScoreIndex) Score(id peer.ID, scores ...*peers.NodeScore) error {
+	return nil
+}
+
+func (m *mockScoreIndex) GetScore(id peer.ID, names ...string) ([]peers.NodeScore, error) {
+	return nil, nil
+}
+
+func TestTopicsCtrl_PeerScoresNotProvided(t *testing.T) {
+	ctrl := NewTopicsController(context.Background(), zap.L(), nil, nil, nil, nil, genesis.New(), nil, nil)
+	scores := ctrl.PeerScores()
+	require.NotNil(t, scores)
+	require.Empty(t, scores)
+}
+
+func TestParseIPWhitelist(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ipNets, err := ParseIPWhitelist([]string{"10.0.0.0/8", " 192.168.1.0/24 ", ""})
+		require.NoError(t, err)
+		require.Len(t, ipNets, 2)
+		require.Equal(t, "10.0.0.0/8", ipNets[0].String())
+		require.Equal(t, "192.168.1.0/24", ipNets[1].String())
+		require.True(t, ipNets[1].Contains(net.ParseIP("192.168.1.17")))
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		ipNets, err := ParseIPWhitelist([]string{"2001:db8::/32"})
+		require.NoError(t, err)
+		require.Len(t, ipNets, 1)
+		require.True(t, ipNets[0].Contains(net.ParseIP("2001:db8::1")))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, cidr := range []string{"10.0.0.0", "10.0.0.0/33", "xxx/8", "2001:db8::/129"} {
+			_, err := ParseIPWhitelist([]string{"10.0.0.0/8", cidr})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), cidr)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package topics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/utils/async"
+)
+
+const (
+	// staticPeersCheckInterval is the interval for checking the connections of static peers
+	staticPeersCheckInterval = 15 * time.Second
+	// staticPeersMinBackoff is the initial time we wait before redialing a static peer
+	staticPeersMinBackoff = 15 * time.Second
+	// staticPeersMaxBackoff is the max time we wait before redialing a static peer
+	staticPeersMaxBackoff = 10 * time.Minute
+	// staticPeerDialTimeout is the timeout for dialing a static peer
+	staticPeerDialTimeout = 15 * time.Second
+)
+
+// staticPeerBackoff holds the backoff state of a static peer
+type staticPeerBackoff struct {
+	delay    time.Duration
+	nextDial time.Time
+}
+
+// staticPeersReconnector redials static peers that are disconnected, with an exponential backoff per peer
+type staticPeersReconnector struct {
+	logger *zap.Logger
+	host   host.Host
+	peers  []peer.AddrInfo
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	lock     sync.Mutex
+	backoffs map[peer.ID]*staticPeerBackoff
+}
+
+func newStaticPeersReconnector(logger *zap.Logger, h host.Host, peers []peer.AddrInfo, minBackoff, maxBackoff time.Duration) *staticPeersReconnector {
+	return &staticPeersReconnector{
+		logger:     logger.With(zap.String("who", "staticPeersReconnector")),
+		host:       h,
+		peers:      peers,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+		backoffs:   make(map[peer.ID]*staticPeerBackoff),
+	}
+}
+
+// Start checks the static peers every interval, until the context is done
+func (r *staticPeersReconnector) Start(ctx context.Context, interval time.Duration) {
+	async.Interval(ctx, interval, func() {
+		r.reconnect(ctx)
+	})
+}
+
+// reconnect redials the disconnected static peers whose backoff has elapsed, and updates metrics
+func (r *staticPeersReconnector) reconnect(ctx context.Context) {
+	connected := 0
+	for _, ai := range r.peers {
+		if r.host.Network().Connectedness(ai.ID) == libp2pnetwork.Connected {
+			r.resetBackoff(ai.ID)
+			connected++
+			continue
+		}
+		if !r.shouldDial(ai.ID) {
+			continue
+		}
+		dialCtx, cancel := context.WithTimeout(ctx, staticPeerDialTimeout)
+		err := r.host.Connect(dialCtx, ai)
+		cancel()
+		if err != nil {
+			r.logger.Debug("could not redial static peer", zap.String("peerID", ai.ID.String()), zap.Error(err))
+			continue
+		}
+		r.logger.Debug("redialed static peer", zap.String("peerID", ai.ID.String()))
+		r.resetBackoff(ai.ID)
+		connected++
+	}
+	metricStaticPeersConnected.Set(float64(connected))
+	metricStaticPeersTotal.Set(float64(len(r.peers)))
+}
+
+// shouldDial returns whether the backoff of the given peer has elapsed, and if so schedules the next dial
+func (r *staticPeersReconnector) shouldDial(id peer.ID) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	b, ok := r.backoffs[id]
+	if !ok {
+		b = &staticPeerBackoff{}
+		r.backoffs[id] = b
+	}
+	now := time.Now()
+	if now.Before(b.nextDial) {
+		return false
+	}
+	if b.delay == 0 {
+		b.delay = r.minBackoff
+	} else {
+		b.delay *= 2
+		if b.delay > r.maxBackoff {
+			b.delay = r.maxBackoff
+		}
+	}
+	b.nextDial = now.Add(b.delay)
+	return true
+}
+
+func (r *staticPeersReconnector) resetBackoff(id peer.ID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.backoffs, id)
+}
@@ -0,0 +1,59 @@
+package topics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStaticPeersReconnector(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	defer func() { _ = h.Close() }()
+	staticPeer, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	defer func() { _ = staticPeer.Close() }()
+
+	ai := peer.AddrInfo{ID: staticPeer.ID(), Addrs: staticPeer.Addrs()}
+	r := newStaticPeersReconnector(zap.L(), h, []peer.AddrInfo{ai}, time.Millisecond*10, time.Millisecond*50)
+	r.Start(ctx, time.Millisecond*20)
+
+	isConnected := func() bool {
+		return h.Network().Connectedness(staticPeer.ID()) == libp2pnetwork.Connected
+	}
+	require.Eventually(t, isConnected, time.Second*5, time.Millisecond*20)
+
+	// drop the static peer and expect it to be redialed
+	require.NoError(t, h.Network().ClosePeer(staticPeer.ID()))
+	require.Eventually(t, isConnected, time.Second*5, time.Millisecond*20)
+}
+
+func TestStaticPeersBackoff(t *testing.T) {
+	r := newStaticPeersReconnector(zap.L(), nil, nil, time.Minute, time.Minute*3)
+	id := peer.ID("static")
+
+	require.True(t, r.shouldDial(id))
+	require.False(t, r.shouldDial(id))
+	require.Equal(t, time.Minute, r.backoffs[id].delay)
+
+	// backoff doubles, up to max backoff
+	r.backoffs[id].nextDial = time.Now()
+	require.True(t, r.shouldDial(id))
+	require.Equal(t, time.Minute*2, r.backoffs[id].delay)
+	r.backoffs[id].nextDial = time.Now()
+	require.True(t, r.shouldDial(id))
+	require.Equal(t, time.Minute*3, r.backoffs[id].delay)
+
+	r.resetBackoff(id)
+	require.True(t, r.shouldDial(id))
+	require.Equal(t, time.Minute, r.backoffs[id].delay)
+}
@@ -5,10 +5,14 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"sync"
 )
 
+// ErrSubscriptionsLimit is returned when a subscription would exceed the subscriptions limit
+var ErrSubscriptionsLimit = errors.New("reached subscriptions limit")
+
 // SubFilter is a wrapper on top of pubsub.SubscriptionFilter,
 type SubFilter interface {
 	// SubscriptionFilter allows controlling what topics the node will subscribe to
@@ -17,14 +21,24 @@ type SubFilter interface {
 	//Whitelist
 }
 
+// SubscriptionsLimiter enables to register topics while keeping the subscriptions limit
+type SubscriptionsLimiter interface {
+	// TryRegister adds the given topic to the whitelist,
+	// or returns ErrSubscriptionsLimit if it would exceed the subscriptions limit
+	TryRegister(topic string) error
+}
+
 type subFilter struct {
 	logger    *zap.Logger
 	fork      forks.Fork
 	whitelist *dynamicWhitelist
 	subsLimit int
+	// registerLock makes sure that the limit check and registration happen atomically
+	registerLock sync.Mutex
 }
 
 func newSubFilter(logger *zap.Logger, fork forks.Fork, subsLimit int) SubFilter {
+	metricSubscriptionsLimit.Set(float64(subsLimit))
 	return &subFilter{
 		logger:    logger.With(zap.String("who", "subFilter")),
 		fork:      fork,
@@ -55,14 +69,36 @@ func (sf *subFilter) FilterIncomingSubscriptions(pi peer.ID, subs []*ps_pb.RPC_S
 	return res, nil
 }
 
+// TryRegister implements SubscriptionsLimiter
+func (sf *subFilter) TryRegister(topic string) error {
+	sf.registerLock.Lock()
+	defer sf.registerLock.Unlock()
+
+	if !sf.whitelist.Whitelisted(topic) && sf.subsLimit > 0 && sf.whitelist.Size() >= sf.subsLimit {
+		sf.logger.Warn("refused subscription", zap.String("topic", topic), zap.Int("limit", sf.subsLimit))
+		return errors.Wrapf(ErrSubscriptionsLimit, "could not subscribe to topic %s", topic)
+	}
+	sf.whitelist.Register(topic)
+	metricSubscriptions.Set(float64(sf.whitelist.Size()))
+	return nil
+}
+
 // Register adds the given topic to the whitelist
 func (sf *subFilter) Register(topic string) {
+	sf.registerLock.Lock()
+	defer sf.registerLock.Unlock()
+
 	sf.whitelist.Register(topic)
+	metricSubscriptions.Set(float64(sf.whitelist.Size()))
 }
 
 // Deregister removes the given topic from the whitelist
 func (sf *subFilter) Deregister(topic string) {
+	sf.registerLock.Lock()
+	defer sf.registerLock.Unlock()
+
 	sf.whitelist.Deregister(topic)
+	metricSubscriptions.Set(float64(sf.whitelist.Size()))
 }
 
 // Whitelisted implements Whitelist
@@ -107,3 +143,13 @@ func (wl *dynamicWhitelist) Whitelisted(name string) bool {
 	_, ok := wl.whitelist.Load(name)
 	return ok
 }
+
+// Size returns the amount of whitelisted names
+func (wl *dynamicWhitelist) Size() int {
+	size := 0
+	wl.whitelist.Range(func(key, value interface{}) bool {
+		size++
+		return true
+	})
+	return size
+}
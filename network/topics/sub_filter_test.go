@@ -19,3 +19,23 @@ func TestSubFilter(t *testing.T) {
 	require.True(t, sf.CanSubscribe(f.GetTopicFullName("1")))
 	require.False(t, sf.CanSubscribe(f.GetTopicFullName("2")))
 }
+
+func TestSubFilter_SubscriptionsLimit(t *testing.T) {
+	f := forksfactory.NewFork(forksprotocol.GenesisForkVersion)
+	sf := newSubFilter(zap.L(), f, 2)
+	limiter := sf.(SubscriptionsLimiter)
+
+	require.NoError(t, limiter.TryRegister(f.GetTopicFullName("1")))
+	require.NoError(t, limiter.TryRegister(f.GetTopicFullName("2")))
+	// already registered topics are not counted twice
+	require.NoError(t, limiter.TryRegister(f.GetTopicFullName("2")))
+
+	err := limiter.TryRegister(f.GetTopicFullName("3"))
+	require.ErrorIs(t, err, ErrSubscriptionsLimit)
+	require.False(t, sf.CanSubscribe(f.GetTopicFullName("3")))
+
+	// once a topic is removed, there is room for a new one
+	sf.(Whitelist).Deregister(f.GetTopicFullName("1"))
+	require.NoError(t, limiter.TryRegister(f.GetTopicFullName("3")))
+	require.True(t, sf.CanSubscribe(f.GetTopicFullName("3")))
+}
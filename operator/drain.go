@@ -0,0 +1,117 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultDrainUnsubscribeDelay is the time given to running duties to complete before unsubscribing from validator topics
+	defaultDrainUnsubscribeDelay = 2 * 12 * time.Second
+	// defaultDrainUnsubscribeInterval is the time between two unsubscribe stages
+	defaultDrainUnsubscribeInterval = time.Second
+	// defaultDrainUnsubscribeBatch is the amount of validators that are unsubscribed in a single stage
+	defaultDrainUnsubscribeBatch = 16
+)
+
+// drainer unsubscribes from validator topics in stages while the node is draining,
+// and subscribes back once drain mode is disabled
+type drainer struct {
+	delay    time.Duration
+	interval time.Duration
+	batch    int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// SetDrainMode enables or disables drain mode.
+// new duties are skipped right away, while validator topics are unsubscribed gradually once running duties had time to complete.
+// disabling drain mode stops the unsubscribe stages and subscribes back to the validator topics
+func (n *operatorNode) SetDrainMode(enabled bool) {
+	n.dutyCtrl.SetDrainMode(enabled)
+
+	n.drainLock.Lock()
+	defer n.drainLock.Unlock()
+
+	if enabled {
+		if n.drain.cancel != nil {
+			return
+		}
+		ctx, cancel := context.WithCancel(n.context)
+		done := make(chan struct{})
+		n.drain.cancel = cancel
+		n.drain.done = done
+		go func() {
+			defer close(done)
+			n.unsubscribeInStages(ctx)
+		}()
+		return
+	}
+	if n.drain.cancel == nil {
+		return
+	}
+	n.drain.cancel()
+	done := n.drain.done
+	n.drain.cancel = nil
+	n.drain.done = nil
+	go func() {
+		// waiting for the current stage to finish so it won't unsubscribe after we subscribed back
+		<-done
+		n.resubscribe()
+	}()
+}
+
+// unsubscribeInStages waits for running duties to complete and then unsubscribes from validator topics,
+// a batch of validators in every stage. it stops once the given context is done
+func (n *operatorNode) unsubscribeInStages(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(n.drain.delay):
+	}
+	shares, err := n.validatorsCtrl.GetAllValidatorShares()
+	if err != nil {
+		n.logger.Warn("drain: could not get validator shares", zap.Error(err))
+		return
+	}
+	for i := 0; i < len(shares); i += n.drain.batch {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(n.drain.interval):
+			}
+		}
+		end := i + n.drain.batch
+		if end > len(shares) {
+			end = len(shares)
+		}
+		for _, share := range shares[i:end] {
+			if err := n.net.Unsubscribe(share.PublicKey.Serialize()); err != nil {
+				n.logger.Debug("drain: could not unsubscribe validator", zap.Error(err),
+					zap.String("pubKey", share.PublicKey.SerializeToHexStr()))
+			}
+		}
+		n.logger.Debug("drain: unsubscribed validators", zap.Int("unsubscribed", end), zap.Int("total", len(shares)))
+	}
+	n.logger.Info("drain: unsubscribed all validators")
+}
+
+// resubscribe subscribes back to the validator topics
+func (n *operatorNode) resubscribe() {
+	shares, err := n.validatorsCtrl.GetAllValidatorShares()
+	if err != nil {
+		n.logger.Warn("drain: could not get validator shares", zap.Error(err))
+		return
+	}
+	for _, share := range shares {
+		if err := n.net.Subscribe(share.PublicKey.Serialize()); err != nil {
+			n.logger.Debug("drain: could not subscribe validator", zap.Error(err),
+				zap.String("pubKey", share.PublicKey.SerializeToHexStr()))
+		}
+	}
+	n.logger.Info("drain: subscribed back to all validators")
+}
@@ -3,8 +3,10 @@ package duties
 import (
 	"context"
 	"encoding/hex"
+	"sync/atomic"
 	"time"
 
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
@@ -15,6 +17,7 @@ import (
 	"github.com/bloxapp/ssv/operator/validator"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/controller.go -source=./controller.go
@@ -33,6 +36,9 @@ type DutyController interface {
 	Start()
 	// CurrentSlotChan will trigger every slot
 	CurrentSlotChan() <-chan uint64
+	// SetDrainMode enables or disables drain mode,
+	// while draining new duties are skipped and running ones are completed
+	SetDrainMode(enabled bool)
 }
 
 // ControllerOptions holds the needed dependencies
@@ -46,6 +52,11 @@ type ControllerOptions struct {
 	GenesisEpoch        uint64
 	DutyLimit           uint64
 	ForkVersion         forksprotocol.ForkVersion
+	DrainMode           bool
+	// ExporterMode disables duties fetching and proposal preparations, to reduce beacon node load
+	ExporterMode bool
+	// RoleDutyLimits overrides DutyLimit for specific roles, keys are role names (e.g. SYNC_COMMITTEE)
+	RoleDutyLimits map[string]uint64
 }
 
 // dutyController internal implementation of DutyController
@@ -56,9 +67,15 @@ type dutyController struct {
 	// executor enables to work with a custom execution
 	executor            DutyExecutor
 	fetcher             DutyFetcher
+	scheduler           *dutyScheduler
+	preparer            *proposalPreparer
 	validatorController validator.Controller
 	genesisEpoch        uint64
 	dutyLimit           uint64
+	roleDutyLimits      map[spectypes.BeaconRole]uint64
+	// draining is set to 1 when in drain mode
+	draining     int32
+	exporterMode bool
 
 	// chan
 	currentSlotC chan uint64
@@ -69,16 +86,23 @@ var secPerSlot int64 = 12
 // NewDutyController creates a new instance of DutyController
 func NewDutyController(opts *ControllerOptions) DutyController {
 	fetcher := newDutyFetcher(opts.Logger, opts.BeaconClient, opts.ValidatorController, opts.EthNetwork)
+	scheduler := newDutyScheduler(opts.Ctx, opts.EthNetwork.SlotDurationSec(), opts.EthNetwork.GetSlotStartTime)
+	preparer := newProposalPreparer(opts.Logger, opts.BeaconClient, opts.ValidatorController)
 	dc := dutyController{
 		logger:              opts.Logger,
 		ctx:                 opts.Ctx,
 		ethNetwork:          opts.EthNetwork,
 		fetcher:             fetcher,
+		scheduler:           scheduler,
+		preparer:            preparer,
 		validatorController: opts.ValidatorController,
 		genesisEpoch:        opts.GenesisEpoch,
 		dutyLimit:           opts.DutyLimit,
+		roleDutyLimits:      roleDutyLimits(opts.Logger, opts.RoleDutyLimits),
 		executor:            opts.Executor,
+		exporterMode:        opts.ExporterMode,
 	}
+	dc.SetDrainMode(opts.DrainMode)
 	return &dc
 }
 
@@ -87,6 +111,9 @@ func (dc *dutyController) Start() {
 	// warmup
 	indices := dc.validatorController.GetValidatorsIndices()
 	dc.logger.Debug("warming up indices", zap.Int("count", len(indices)))
+	if !dc.exporterMode {
+		go dc.submitProposalPreparationsLoop()
+	}
 
 	genesisTime := time.Unix(int64(dc.ethNetwork.MinGenesisTime()), 0)
 	slotTicker := slots.NewSlotTicker(genesisTime, uint64(dc.ethNetwork.SlotDurationSec().Seconds()))
@@ -100,6 +127,21 @@ func (dc *dutyController) CurrentSlotChan() <-chan uint64 {
 	return dc.currentSlotC
 }
 
+// SetDrainMode enables or disables drain mode
+func (dc *dutyController) SetDrainMode(enabled bool) {
+	var val int32
+	if enabled {
+		val = 1
+	}
+	if prev := atomic.SwapInt32(&dc.draining, val); prev != val {
+		dc.logger.Info("drain mode was updated", zap.Bool("enabled", enabled))
+	}
+}
+
+func (dc *dutyController) isDraining() bool {
+	return atomic.LoadInt32(&dc.draining) == 1
+}
+
 // ExecuteDuty tries to execute the given duty
 func (dc *dutyController) ExecuteDuty(duty *spectypes.Duty) error {
 	if dc.executor != nil {
@@ -112,6 +154,10 @@ func (dc *dutyController) ExecuteDuty(duty *spectypes.Duty) error {
 		return errors.Wrap(err, "failed to deserialize pubkey from duty")
 	}
 	if v, ok := dc.validatorController.GetValidator(pubKey.SerializeToHexStr()); ok {
+		if v.GetShare().Paused {
+			logger.Info("validator is paused, skipping duty")
+			return nil
+		}
 		go func() {
 			// force the validator to be started (subscribed to validator's topic and synced)
 			// TODO: handle error (return error
@@ -136,14 +182,53 @@ func (dc *dutyController) listenToTicker(slots <-chan types.Slot) {
 
 		// execute duties
 		dc.logger.Info("slot ticker", zap.Uint64("slot", uint64(currentSlot)))
+		if dc.exporterMode {
+			continue
+		}
+		if dc.isDraining() {
+			dc.logger.Info("drain mode is enabled, skipping duties of slot", zap.Uint64("slot", uint64(currentSlot)))
+			continue
+		}
 		duties, err := dc.fetcher.GetDuties(uint64(currentSlot))
 		if err != nil {
 			dc.logger.Warn("failed to get duties", zap.Error(err))
 		}
+		duties = coalesceDuties(duties)
 		for i := range duties {
-			go dc.onDuty(&duties[i])
+			dc.dispatchDuty(&duties[i])
+		}
+	}
+}
+
+// coalesceDuties removes duplicated duties of the same validator, slot and role, so a single instance run covers them.
+// duties of different roles (e.g. attester and aggregator of the same slot) are kept, as each role runs its own consensus
+// and produces a different beacon submission. the order of duties is preserved
+func coalesceDuties(duties []spectypes.Duty) []spectypes.Duty {
+	type dutyKey struct {
+		pubKey spec.BLSPubKey
+		slot   spec.Slot
+		role   spectypes.BeaconRole
+	}
+	seen := make(map[dutyKey]bool, len(duties))
+	res := make([]spectypes.Duty, 0, len(duties))
+	for _, duty := range duties {
+		k := dutyKey{pubKey: duty.PubKey, slot: duty.Slot, role: duty.Type}
+		if seen[k] {
+			continue
 		}
+		seen[k] = true
+		res = append(res, duty)
 	}
+	return res
+}
+
+// dispatchDuty schedules the given duty to its intra-slot target time, or handles it immediately w/o a scheduler
+func (dc *dutyController) dispatchDuty(duty *spectypes.Duty) {
+	if dc.scheduler == nil {
+		go dc.onDuty(duty)
+		return
+	}
+	dc.scheduler.schedule(duty, dc.onDuty)
 }
 
 func (dc *dutyController) notifyCurrentSlot(slot types.Slot) {
@@ -155,6 +240,10 @@ func (dc *dutyController) notifyCurrentSlot(slot types.Slot) {
 // onDuty handles next duty
 func (dc *dutyController) onDuty(duty *spectypes.Duty) {
 	logger := dc.loggerWithDutyContext(dc.logger, duty)
+	if dc.isDraining() {
+		logger.Info("drain mode is enabled, skipping duty")
+		return
+	}
 	if dc.shouldExecute(duty) {
 		logger.Debug("duty was sent to execution")
 		if err := dc.ExecuteDuty(duty); err != nil {
@@ -176,8 +265,8 @@ func (dc *dutyController) shouldExecute(duty *spectypes.Duty) bool {
 	}
 
 	currentSlot := uint64(dc.ethNetwork.EstimatedCurrentSlot())
-	// execute task if slot already began and not pass 1 epoch
-	if currentSlot >= uint64(duty.Slot) && currentSlot-uint64(duty.Slot) <= dc.dutyLimit {
+	// execute task if slot already began and not pass the duty limit
+	if currentSlot >= uint64(duty.Slot) && currentSlot-uint64(duty.Slot) <= dc.dutyLimitOf(duty.Type) {
 		return true
 	} else if currentSlot+1 == uint64(duty.Slot) {
 		dc.loggerWithDutyContext(dc.logger, duty).Debug("current slot and duty slot are not aligned, " +
@@ -187,6 +276,28 @@ func (dc *dutyController) shouldExecute(duty *spectypes.Duty) bool {
 	return false
 }
 
+// dutyLimitOf returns the duty limit of the given role, fallbacks to the global limit
+func (dc *dutyController) dutyLimitOf(role spectypes.BeaconRole) uint64 {
+	if limit, ok := dc.roleDutyLimits[role]; ok {
+		return limit
+	}
+	return dc.dutyLimit
+}
+
+// roleDutyLimits maps the configured role names to beacon roles, unknown roles are ignored
+func roleDutyLimits(logger *zap.Logger, limits map[string]uint64) map[spectypes.BeaconRole]uint64 {
+	res := make(map[spectypes.BeaconRole]uint64, len(limits))
+	for name, limit := range limits {
+		role, ok := validator.BeaconRoleByName(name)
+		if !ok {
+			logger.Warn("ignoring duty limit of unknown role", zap.String("role", name))
+			continue
+		}
+		res[role] = limit
+	}
+	return res
+}
+
 // loggerWithDutyContext returns an instance of logger with the given duty's information
 func (dc *dutyController) loggerWithDutyContext(logger *zap.Logger, duty *spectypes.Duty) *zap.Logger {
 	currentSlot := uint64(dc.ethNetwork.EstimatedCurrentSlot())
@@ -196,7 +307,8 @@ func (dc *dutyController) loggerWithDutyContext(logger *zap.Logger, duty *specty
 		With(zap.Uint64("slot", uint64(duty.Slot))).
 		With(zap.Uint64("epoch", uint64(duty.Slot)/32)).
 		With(zap.String("pubKey", hex.EncodeToString(duty.PubKey[:]))).
-		With(zap.Time("start_time", dc.ethNetwork.GetSlotStartTime(uint64(duty.Slot))))
+		With(zap.Time("start_time", dc.ethNetwork.GetSlotStartTime(uint64(duty.Slot)))).
+		With(logfields.TraceID(logfields.DutyTraceID(duty)))
 }
 
 // getEpochFirstSlot returns the beacon node first slot in epoch
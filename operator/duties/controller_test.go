@@ -9,15 +9,38 @@ import (
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/golang/mock/gomock"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/operator/duties/mocks"
+	validatormocks "github.com/bloxapp/ssv/operator/validator/mocks"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/utils/threshold"
 )
 
+// startCountingValidator counts the duties that were started
+type startCountingValidator struct {
+	validator.IValidator
+	share   *beacon.Share
+	started chan *spectypes.Duty
+}
+
+func (v *startCountingValidator) GetShare() *beacon.Share {
+	return v.share
+}
+
+func (v *startCountingValidator) Start() error {
+	return nil
+}
+
+func (v *startCountingValidator) StartDuty(duty *spectypes.Duty) {
+	v.started <- duty
+}
+
 func TestDutyController_ListenToTicker(t *testing.T) {
 	var wg sync.WaitGroup
 
@@ -63,6 +86,197 @@ func TestDutyController_ListenToTicker(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDutyController_DrainMode(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var executed []spec.BLSPubKey
+	mockExecutor := mocks.NewMockDutyExecutor(mockCtrl)
+	// the duty that was skipped while draining is not expected to be executed
+	mockExecutor.EXPECT().ExecuteDuty(gomock.Any()).DoAndReturn(func(duty *spectypes.Duty) error {
+		executed = append(executed, duty.PubKey)
+		started <- struct{}{}
+		<-release
+		return nil
+	}).Times(2)
+
+	dutyCtrl := &dutyController{
+		logger: zap.L(), ctx: context.Background(), ethNetwork: beacon.NewNetwork(core.PraterNetwork),
+		executor:  mockExecutor,
+		dutyLimit: 32,
+	}
+	currentSlot := dutyCtrl.ethNetwork.EstimatedCurrentSlot()
+
+	// start a running instance and enable drain mode while it is running
+	finished := make(chan struct{})
+	go func() {
+		dutyCtrl.onDuty(&spectypes.Duty{Slot: spec.Slot(currentSlot), PubKey: spec.BLSPubKey{}})
+		close(finished)
+	}()
+	<-started
+	dutyCtrl.SetDrainMode(true)
+
+	// new duties are skipped
+	dutyCtrl.onDuty(&spectypes.Duty{Slot: spec.Slot(currentSlot), PubKey: spec.BLSPubKey{1}})
+
+	// the running instance completes
+	close(release)
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("running duty should complete while draining")
+	}
+
+	// new duties are executed once drain mode is disabled
+	dutyCtrl.SetDrainMode(false)
+	dutyCtrl.onDuty(&spectypes.Duty{Slot: spec.Slot(currentSlot), PubKey: spec.BLSPubKey{2}})
+	require.Equal(t, []spec.BLSPubKey{{}, {2}}, executed)
+}
+
+func TestCoalesceDuties(t *testing.T) {
+	pk1, pk2 := spec.BLSPubKey{1}, spec.BLSPubKey{2}
+	duties := []spectypes.Duty{
+		{Type: spectypes.BNRoleAggregator, PubKey: pk1, Slot: 10},
+		{Type: spectypes.BNRoleAttester, PubKey: pk1, Slot: 10},
+		{Type: spectypes.BNRoleAttester, PubKey: pk2, Slot: 10},
+		{Type: spectypes.BNRoleAttester, PubKey: pk2, Slot: 10}, // duplicated
+		{Type: spectypes.BNRoleAggregator, PubKey: pk2, Slot: 10},
+		{Type: spectypes.BNRoleProposer, PubKey: pk2, Slot: 10},
+		{Type: spectypes.BNRoleAttester, PubKey: pk1, Slot: 11},
+	}
+	// overlapping attester and aggregator duties are both kept, only the duplicated duty is removed
+	require.Equal(t, []spectypes.Duty{
+		{Type: spectypes.BNRoleAggregator, PubKey: pk1, Slot: 10},
+		{Type: spectypes.BNRoleAttester, PubKey: pk1, Slot: 10},
+		{Type: spectypes.BNRoleAttester, PubKey: pk2, Slot: 10},
+		{Type: spectypes.BNRoleAggregator, PubKey: pk2, Slot: 10},
+		{Type: spectypes.BNRoleProposer, PubKey: pk2, Slot: 10},
+		{Type: spectypes.BNRoleAttester, PubKey: pk1, Slot: 11},
+	}, coalesceDuties(duties))
+	require.Empty(t, coalesceDuties(nil))
+}
+
+func TestDutyController_OverlappingDuties(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	executed := make(chan *spectypes.Duty, 4)
+	mockExecutor := mocks.NewMockDutyExecutor(mockCtrl)
+	mockExecutor.EXPECT().ExecuteDuty(gomock.Any()).DoAndReturn(func(duty *spectypes.Duty) error {
+		executed <- duty
+		return nil
+	}).AnyTimes()
+
+	dutyCtrl := &dutyController{
+		logger: zap.L(), ctx: context.Background(), ethNetwork: beacon.NewNetwork(core.PraterNetwork),
+		executor:  mockExecutor,
+		dutyLimit: 32,
+	}
+	currentSlot := dutyCtrl.ethNetwork.EstimatedCurrentSlot()
+
+	mockFetcher := mocks.NewMockDutyFetcher(mockCtrl)
+	mockFetcher.EXPECT().GetDuties(gomock.Any()).Return([]spectypes.Duty{
+		{Type: spectypes.BNRoleAttester, PubKey: spec.BLSPubKey{1}, Slot: spec.Slot(currentSlot)},
+		{Type: spectypes.BNRoleAggregator, PubKey: spec.BLSPubKey{1}, Slot: spec.Slot(currentSlot)},
+	}, nil).Times(1)
+	dutyCtrl.fetcher = mockFetcher
+
+	cn := make(chan types.Slot, 1)
+	cn <- currentSlot
+	close(cn)
+	dutyCtrl.listenToTicker(cn)
+
+	// both attester and aggregator duties are executed, each in its own run
+	var roles []spectypes.BeaconRole
+	for i := 0; i < 2; i++ {
+		select {
+		case duty := <-executed:
+			roles = append(roles, duty.Type)
+		case <-time.After(time.Second):
+			t.Fatal("duty should be executed")
+		}
+	}
+	require.ElementsMatch(t, []spectypes.BeaconRole{spectypes.BNRoleAttester, spectypes.BNRoleAggregator}, roles)
+	select {
+	case duty := <-executed:
+		t.Fatalf("unexpected execution of %s duty", duty.Type.String())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestDutyController_ExporterMode(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFetcher := mocks.NewMockDutyFetcher(mockCtrl)
+	// duties are not fetched in exporter mode
+	mockFetcher.EXPECT().GetDuties(gomock.Any()).Times(0)
+
+	dutyCtrl := &dutyController{
+		logger: zap.L(), ctx: context.Background(), ethNetwork: beacon.NewNetwork(core.PraterNetwork),
+		fetcher:      mockFetcher,
+		exporterMode: true,
+	}
+	currentSlot := dutyCtrl.ethNetwork.EstimatedCurrentSlot()
+
+	cn := make(chan types.Slot)
+	done := make(chan struct{})
+	go func() {
+		dutyCtrl.listenToTicker(cn)
+		close(done)
+	}()
+	cn <- currentSlot
+	cn <- currentSlot + 1
+	close(cn)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ticker listener should stop once the slots channel is closed")
+	}
+}
+
+func TestDutyController_PausedValidator(t *testing.T) {
+	threshold.Init()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	pk := sk.GetPublicKey()
+	v := &startCountingValidator{
+		share:   &beacon.Share{PublicKey: pk, Paused: true},
+		started: make(chan *spectypes.Duty, 1),
+	}
+	validatorCtrl := validatormocks.NewMockController(mockCtrl)
+	validatorCtrl.EXPECT().GetValidator(pk.SerializeToHexStr()).Return(v, true).AnyTimes()
+
+	dutyCtrl := &dutyController{
+		logger: zap.L(), ctx: context.Background(), ethNetwork: beacon.NewNetwork(core.PraterNetwork),
+		validatorController: validatorCtrl,
+	}
+	duty := &spectypes.Duty{Slot: spec.Slot(dutyCtrl.ethNetwork.EstimatedCurrentSlot())}
+	copy(duty.PubKey[:], pk.Serialize())
+
+	// duties of a paused validator are skipped
+	require.NoError(t, dutyCtrl.ExecuteDuty(duty))
+	select {
+	case <-v.started:
+		t.Fatal("duty of a paused validator should be skipped")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// once resumed, duties are executed
+	v.share.Paused = false
+	require.NoError(t, dutyCtrl.ExecuteDuty(duty))
+	select {
+	case <-v.started:
+	case <-time.After(time.Second):
+		t.Fatal("duty of a resumed validator should be executed")
+	}
+}
+
 func TestDutyController_ShouldExecute(t *testing.T) {
 	ctrl := dutyController{logger: zap.L(), ethNetwork: beacon.NewNetwork(core.PraterNetwork)}
 	currentSlot := uint64(ctrl.ethNetwork.EstimatedCurrentSlot())
@@ -72,6 +286,27 @@ func TestDutyController_ShouldExecute(t *testing.T) {
 	require.False(t, ctrl.shouldExecute(&spectypes.Duty{Slot: spec.Slot(currentSlot + 1000), PubKey: spec.BLSPubKey{}}))
 }
 
+func TestDutyController_RoleDutyLimits(t *testing.T) {
+	ctrl := dutyController{
+		logger:     zap.L(),
+		ethNetwork: beacon.NewNetwork(core.PraterNetwork),
+		dutyLimit:  32,
+		roleDutyLimits: roleDutyLimits(zap.L(), map[string]uint64{
+			"sync_committee": 256,
+			"unknown":        1,
+		}),
+	}
+	require.Len(t, ctrl.roleDutyLimits, 1)
+	currentSlot := uint64(ctrl.ethNetwork.EstimatedCurrentSlot())
+
+	// role specific limit
+	require.True(t, ctrl.shouldExecute(&spectypes.Duty{Type: spectypes.BNRoleSyncCommittee, Slot: spec.Slot(currentSlot - 100)}))
+	require.False(t, ctrl.shouldExecute(&spectypes.Duty{Type: spectypes.BNRoleSyncCommittee, Slot: spec.Slot(currentSlot - 300)}))
+	// other roles use the default limit
+	require.True(t, ctrl.shouldExecute(&spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: spec.Slot(currentSlot - 32)}))
+	require.False(t, ctrl.shouldExecute(&spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: spec.Slot(currentSlot - 100)}))
+}
+
 func TestDutyController_GetSlotStartTime(t *testing.T) {
 	d := dutyController{logger: zap.L(), ethNetwork: beacon.NewNetwork(core.PraterNetwork)}
 
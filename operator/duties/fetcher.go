@@ -18,6 +18,13 @@ import (
 
 //go:generate mockgen -package=mocks -destination=./mocks/fetcher.go -source=./fetcher.go
 
+const (
+	// defaultFetchAttempts is the max number of attempts to fetch duties from beacon
+	defaultFetchAttempts = 3
+	// defaultFetchBackoff is the initial backoff between attempts, it is doubled on every retry
+	defaultFetchBackoff = 500 * time.Millisecond
+)
+
 // cacheEntry
 type cacheEntry struct {
 	Duties []spectypes.Duty
@@ -50,6 +57,8 @@ func newDutyFetcher(logger *zap.Logger, beaconClient beaconDutiesClient, indices
 		beaconClient:   beaconClient,
 		indicesFetcher: indicesFetcher,
 		cache:          cache.New(time.Minute*12, time.Minute*13),
+		fetchAttempts:  defaultFetchAttempts,
+		fetchBackoff:   defaultFetchBackoff,
 	}
 	return &df
 }
@@ -62,6 +71,9 @@ type dutyFetcher struct {
 	indicesFetcher validatorsIndicesFetcher
 
 	cache *cache.Cache
+
+	fetchAttempts int
+	fetchBackoff  time.Duration
 }
 
 // GetDuties tries to get slot's duties from cache, if not available in cache it fetches them from beacon
@@ -97,7 +109,7 @@ func (df *dutyFetcher) GetDuties(slot uint64) ([]spectypes.Duty, error) {
 
 // updateDutiesFromBeacon will be called once in an epoch to update the cache with all the epoch's slots
 func (df *dutyFetcher) updateDutiesFromBeacon(slot uint64) error {
-	duties, err := df.fetchDuties(slot)
+	duties, err := df.fetchDutiesWithRetry(slot)
 	if err != nil {
 		return errors.Wrap(err, "failed to get duties from beacon")
 	}
@@ -118,6 +130,25 @@ func (df *dutyFetcher) updateDutiesFromBeacon(slot uint64) error {
 	return nil
 }
 
+// fetchDutiesWithRetry calls fetchDuties, transient beacon errors are retried with an exponential backoff
+// until fetchAttempts is reached
+func (df *dutyFetcher) fetchDutiesWithRetry(slot uint64) ([]*spectypes.Duty, error) {
+	backoff := df.fetchBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var duties []*spectypes.Duty
+		duties, err = df.fetchDuties(slot)
+		if err == nil || attempt >= df.fetchAttempts {
+			return duties, err
+		}
+		df.logger.Debug("failed to fetch duties, retrying", zap.Uint64("slot", slot),
+			zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Error(err))
+		metricsDutyFetchRetries.Inc()
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
 // fetchDuties fetches duties for the epoch of the given slot
 func (df *dutyFetcher) fetchDuties(slot uint64) ([]*spectypes.Duty, error) {
 	if indices := df.indicesFetcher.GetValidatorsIndices(); len(indices) > 0 {
@@ -3,6 +3,7 @@ package duties
 import (
 	"errors"
 	"testing"
+	"time"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -24,6 +25,44 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 		mockClient := createBeaconDutiesClient(ctrl, nil, expectedErr)
 		mockFetcher := createIndexFetcher(ctrl, []spec.ValidatorIndex{205238})
 		dm := newDutyFetcher(zap.L(), mockClient, mockFetcher, beacon.NewNetwork(core.PraterNetwork))
+		dm.(*dutyFetcher).fetchAttempts = 1
+		duties, err := dm.GetDuties(893108)
+		require.EqualError(t, err, "failed to get duties from beacon: test duties")
+		require.Len(t, duties, 0)
+	})
+
+	t.Run("retries on beacon errors", func(t *testing.T) {
+		beaconDuties := []*spectypes.Duty{
+			{
+				Slot:   893108,
+				PubKey: spec.BLSPubKey{},
+			},
+		}
+		mockClient := mocks.NewMockbeaconDutiesClient(ctrl)
+		gomock.InOrder(
+			mockClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, errors.New("test duties")).Times(2),
+			mockClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(beaconDuties, nil).Times(1),
+		)
+		mockClient.EXPECT().SubscribeToCommitteeSubnet(gomock.Any()).Return(nil).MaxTimes(1)
+		mockFetcher := mocks.NewMockvalidatorsIndicesFetcher(ctrl)
+		mockFetcher.EXPECT().GetValidatorsIndices().Return([]spec.ValidatorIndex{205238}).Times(3)
+		dm := newDutyFetcher(zap.L(), mockClient, mockFetcher, beacon.NewNetwork(core.PraterNetwork))
+		dm.(*dutyFetcher).fetchBackoff = time.Millisecond * 10
+
+		duties, err := dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 1)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		mockClient := mocks.NewMockbeaconDutiesClient(ctrl)
+		mockClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, errors.New("test duties")).Times(2)
+		mockFetcher := mocks.NewMockvalidatorsIndicesFetcher(ctrl)
+		mockFetcher.EXPECT().GetValidatorsIndices().Return([]spec.ValidatorIndex{205238}).Times(2)
+		dm := newDutyFetcher(zap.L(), mockClient, mockFetcher, beacon.NewNetwork(core.PraterNetwork))
+		dm.(*dutyFetcher).fetchAttempts = 2
+		dm.(*dutyFetcher).fetchBackoff = time.Millisecond * 10
+
 		duties, err := dm.GetDuties(893108)
 		require.EqualError(t, err, "failed to get duties from beacon: test duties")
 		require.Len(t, duties, 0)
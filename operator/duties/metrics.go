@@ -0,0 +1,28 @@
+package duties
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricsDutyFetchRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:duties:fetch_retries",
+		Help: "Count of duties fetch retries after beacon errors",
+	})
+	metricsProposalPreparations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:duties:proposal_preparations",
+		Help: "Count of proposal preparations submissions by status",
+	}, []string{"status"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsDutyFetchRetries); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsProposalPreparations); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
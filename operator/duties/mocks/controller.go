@@ -96,3 +96,15 @@ func (mr *MockDutyControllerMockRecorder) CurrentSlotChan() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentSlotChan", reflect.TypeOf((*MockDutyController)(nil).CurrentSlotChan))
 }
+
+// SetDrainMode mocks base method
+func (m *MockDutyController) SetDrainMode(enabled bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDrainMode", enabled)
+}
+
+// SetDrainMode indicates an expected call of SetDrainMode
+func (mr *MockDutyControllerMockRecorder) SetDrainMode(enabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDrainMode", reflect.TypeOf((*MockDutyController)(nil).SetDrainMode), enabled)
+}
@@ -0,0 +1,93 @@
+package duties
+
+import (
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// proposalPreparationsClient is the beacon client that is needed for submitting proposal preparations
+type proposalPreparationsClient interface {
+	SubmitProposalPreparation(preparations []*eth2apiv1.ProposalPreparation) error
+}
+
+// feeRecipientsFetcher returns the fee recipients of the active validators
+type feeRecipientsFetcher interface {
+	GetFeeRecipients() map[spec.ValidatorIndex]common.Address
+}
+
+// proposalPreparer registers the fee recipients of the active validators in the beacon node,
+// beacon nodes require it ahead of the proposal slots
+type proposalPreparer struct {
+	logger  *zap.Logger
+	client  proposalPreparationsClient
+	fetcher feeRecipientsFetcher
+}
+
+// newProposalPreparer creates a new instance
+func newProposalPreparer(logger *zap.Logger, client proposalPreparationsClient, fetcher feeRecipientsFetcher) *proposalPreparer {
+	return &proposalPreparer{
+		logger:  logger.With(zap.String("component", "operator/proposalPreparer")),
+		client:  client,
+		fetcher: fetcher,
+	}
+}
+
+// submit submits the proposal preparations of all the active validators.
+// validators w/o a fee recipient (zero address) are skipped, as their block rewards would be burned
+func (p *proposalPreparer) submit() error {
+	feeRecipients := p.fetcher.GetFeeRecipients()
+	preparations := make([]*eth2apiv1.ProposalPreparation, 0, len(feeRecipients))
+	for index, feeRecipient := range feeRecipients {
+		if feeRecipient == (common.Address{}) {
+			p.logger.Warn("skipping proposal preparation of validator w/o fee recipient",
+				zap.Uint64("validator_index", uint64(index)))
+			continue
+		}
+		var execAddress bellatrix.ExecutionAddress
+		copy(execAddress[:], feeRecipient.Bytes())
+		preparations = append(preparations, &eth2apiv1.ProposalPreparation{
+			ValidatorIndex: index,
+			FeeRecipient:   execAddress,
+		})
+	}
+	if len(preparations) == 0 {
+		return nil
+	}
+	if err := p.client.SubmitProposalPreparation(preparations); err != nil {
+		metricsProposalPreparations.WithLabelValues("failure").Inc()
+		return errors.Wrap(err, "could not submit proposal preparations")
+	}
+	metricsProposalPreparations.WithLabelValues("success").Inc()
+	p.logger.Debug("submitted proposal preparations", zap.Int("count", len(preparations)))
+	return nil
+}
+
+// submitProposalPreparationsLoop submits proposal preparations once in an epoch
+func (dc *dutyController) submitProposalPreparationsLoop() {
+	if dc.preparer == nil {
+		return
+	}
+	submit := func() {
+		if err := dc.preparer.submit(); err != nil {
+			dc.logger.Warn("failed to submit proposal preparations", zap.Error(err))
+		}
+	}
+	submit()
+	epochDuration := dc.ethNetwork.SlotDurationSec() * time.Duration(dc.ethNetwork.SlotsPerEpoch())
+	ticker := time.NewTicker(epochDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-dc.ctx.Done():
+			return
+		case <-ticker.C:
+			submit()
+		}
+	}
+}
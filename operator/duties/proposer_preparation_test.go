@@ -0,0 +1,80 @@
+package duties
+
+import (
+	"sort"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingPreparationsClient records the submitted proposal preparations
+type recordingPreparationsClient struct {
+	submitted [][]*eth2apiv1.ProposalPreparation
+	err       error
+}
+
+func (c *recordingPreparationsClient) SubmitProposalPreparation(preparations []*eth2apiv1.ProposalPreparation) error {
+	c.submitted = append(c.submitted, preparations)
+	return c.err
+}
+
+type staticFeeRecipients map[spec.ValidatorIndex]common.Address
+
+func (f staticFeeRecipients) GetFeeRecipients() map[spec.ValidatorIndex]common.Address {
+	return f
+}
+
+func TestProposalPreparer_Submit(t *testing.T) {
+	feeRecipients := staticFeeRecipients{
+		1: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+		2: common.HexToAddress("0xcEEfd323DD28a8d9514EDDfeC45a6c81800A7D49"),
+	}
+	client := &recordingPreparationsClient{}
+	preparer := newProposalPreparer(zap.L(), client, feeRecipients)
+
+	successes := testutil.ToFloat64(metricsProposalPreparations.WithLabelValues("success"))
+	require.NoError(t, preparer.submit())
+	require.Len(t, client.submitted, 1)
+	preparations := client.submitted[0]
+	require.Len(t, preparations, 2)
+	sort.Slice(preparations, func(i, j int) bool {
+		return preparations[i].ValidatorIndex < preparations[j].ValidatorIndex
+	})
+	for _, p := range preparations {
+		require.Equal(t, feeRecipients[p.ValidatorIndex].Bytes(), p.FeeRecipient[:])
+	}
+	require.Equal(t, successes+1, testutil.ToFloat64(metricsProposalPreparations.WithLabelValues("success")))
+
+	// failures are reported
+	failures := testutil.ToFloat64(metricsProposalPreparations.WithLabelValues("failure"))
+	client.err = errors.New("test error")
+	require.Error(t, preparer.submit())
+	require.Equal(t, failures+1, testutil.ToFloat64(metricsProposalPreparations.WithLabelValues("failure")))
+
+	// nothing is submitted w/o active validators
+	preparer = newProposalPreparer(zap.L(), client, staticFeeRecipients{})
+	require.NoError(t, preparer.submit())
+	require.Len(t, client.submitted, 2)
+
+	// validators w/o a fee recipient are skipped
+	client.err = nil
+	preparer = newProposalPreparer(zap.L(), client, staticFeeRecipients{
+		1: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+		2: {},
+	})
+	require.NoError(t, preparer.submit())
+	require.Len(t, client.submitted, 3)
+	require.Len(t, client.submitted[2], 1)
+	require.Equal(t, spec.ValidatorIndex(1), client.submitted[2][0].ValidatorIndex)
+
+	// nothing is submitted if none of the validators has a fee recipient
+	preparer = newProposalPreparer(zap.L(), client, staticFeeRecipients{2: {}})
+	require.NoError(t, preparer.submit())
+	require.Len(t, client.submitted, 3)
+}
@@ -0,0 +1,62 @@
+package duties
+
+import (
+	"context"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+)
+
+// dutyScheduler fires duties at their intra-slot target time, instead of at slot start
+type dutyScheduler struct {
+	ctx          context.Context
+	slotDuration time.Duration
+	// slotStartTime returns the start time of the given slot
+	slotStartTime func(slot uint64) time.Time
+}
+
+func newDutyScheduler(ctx context.Context, slotDuration time.Duration, slotStartTime func(slot uint64) time.Time) *dutyScheduler {
+	return &dutyScheduler{
+		ctx:           ctx,
+		slotDuration:  slotDuration,
+		slotStartTime: slotStartTime,
+	}
+}
+
+// dutyOffset returns the offset from slot start at which a duty of the given role should be executed:
+// proposals at slot start, attestations and sync committee messages at 1/3 of the slot,
+// aggregations and sync committee contributions at 2/3 of the slot
+func dutyOffset(role spectypes.BeaconRole, slotDuration time.Duration) time.Duration {
+	switch role {
+	case spectypes.BNRoleAttester, spectypes.BNRoleSyncCommittee:
+		return slotDuration / 3
+	case spectypes.BNRoleAggregator, spectypes.BNRoleSyncCommitteeContribution:
+		return slotDuration * 2 / 3
+	default:
+		return 0
+	}
+}
+
+// targetTime returns the time at which the given duty should be executed
+func (s *dutyScheduler) targetTime(duty *spectypes.Duty) time.Time {
+	return s.slotStartTime(uint64(duty.Slot)).Add(dutyOffset(duty.Type, s.slotDuration))
+}
+
+// schedule calls fire with the given duty once its target time arrives,
+// the duty is fired immediately if the target time has passed
+func (s *dutyScheduler) schedule(duty *spectypes.Duty, fire func(duty *spectypes.Duty)) {
+	delay := time.Until(s.targetTime(duty))
+	if delay <= 0 {
+		go fire(duty)
+		return
+	}
+	go func() {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			fire(duty)
+		case <-s.ctx.Done():
+		}
+	}()
+}
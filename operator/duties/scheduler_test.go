@@ -0,0 +1,56 @@
+package duties
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDutyScheduler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	slotDuration := 600 * time.Millisecond
+	slotStart := time.Now()
+	s := newDutyScheduler(ctx, slotDuration, func(slot uint64) time.Time {
+		return slotStart
+	})
+
+	fired := make(chan time.Time, 1)
+	s.schedule(&spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: 1}, func(duty *spectypes.Duty) {
+		fired <- time.Now()
+	})
+
+	select {
+	case firedAt := <-fired:
+		offset := firedAt.Sub(slotStart)
+		require.GreaterOrEqual(t, offset, slotDuration/3)
+		require.Less(t, offset, slotDuration/3+150*time.Millisecond)
+	case <-time.After(time.Second * 2):
+		t.Fatal("duty was not fired")
+	}
+
+	t.Run("passed target time", func(t *testing.T) {
+		s := newDutyScheduler(ctx, slotDuration, func(slot uint64) time.Time {
+			return time.Now().Add(-slotDuration)
+		})
+		start := time.Now()
+		s.schedule(&spectypes.Duty{Type: spectypes.BNRoleAggregator, Slot: 1}, func(duty *spectypes.Duty) {
+			fired <- time.Now()
+		})
+		firedAt := <-fired
+		require.Less(t, firedAt.Sub(start), 100*time.Millisecond)
+	})
+}
+
+func TestDutyOffset(t *testing.T) {
+	slotDuration := 12 * time.Second
+	require.Equal(t, time.Duration(0), dutyOffset(spectypes.BNRoleProposer, slotDuration))
+	require.Equal(t, 4*time.Second, dutyOffset(spectypes.BNRoleAttester, slotDuration))
+	require.Equal(t, 4*time.Second, dutyOffset(spectypes.BNRoleSyncCommittee, slotDuration))
+	require.Equal(t, 8*time.Second, dutyOffset(spectypes.BNRoleAggregator, slotDuration))
+	require.Equal(t, 8*time.Second, dutyOffset(spectypes.BNRoleSyncCommitteeContribution, slotDuration))
+}
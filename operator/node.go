@@ -3,11 +3,15 @@ package operator
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/ekm"
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/exporter"
 	"github.com/bloxapp/ssv/exporter/api"
@@ -19,14 +23,24 @@ import (
 	"github.com/bloxapp/ssv/operator/validator"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	qbftcontroller "github.com/bloxapp/ssv/protocol/v1/qbft/controller"
 	qbftstorageprotocol "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	ssvstorage "github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/async"
+)
+
+const (
+	defaultOperatorsReportInterval = 10 * time.Minute
+	defaultStorageReportInterval   = 5 * time.Minute
 )
 
 // Node represents the behavior of SSV node
 type Node interface {
 	Start() error
 	StartEth1(syncOffset *eth1.SyncOffset) error
+	// SetDrainMode enables or disables drain mode, used for maintenance
+	SetDrainMode(enabled bool)
 }
 
 // Options contains options to create the node
@@ -44,7 +58,18 @@ type Options struct {
 	GenesisEpoch uint64 `yaml:"GenesisEpoch" env:"GENESIS_EPOCH" env-description:"Genesis Epoch SSV node will start"`
 	// max slots for duty to wait
 	DutyLimit        uint64                      `yaml:"DutyLimit" env:"DUTY_LIMIT" env-default:"32" env-description:"max slots to wait for duty to start"`
+	DutyLimitPerRole map[string]uint64           `yaml:"DutyLimitPerRole" env:"DUTY_LIMIT_PER_ROLE" env-description:"max slots to wait for duty to start per role (e.g. SYNC_COMMITTEE:64), fallbacks to DutyLimit"`
 	ValidatorOptions validator.ControllerOptions `yaml:"ValidatorOptions"`
+	// DrainMode skips new duties while letting running ones to complete
+	DrainMode bool `yaml:"DrainMode" env:"DRAIN_MODE" env-description:"Skip new duties while letting running ones to complete, used for maintenance"`
+	// DrainTimeout is the time the node keeps draining after a shutdown signal, before it exits
+	DrainTimeout time.Duration `yaml:"DrainTimeout" env:"DRAIN_TIMEOUT" env-default:"1m" env-description:"Time to drain before exiting once a shutdown signal is received"`
+	// OperatorsReportInterval is the interval for reporting operators metrics
+	OperatorsReportInterval time.Duration `yaml:"OperatorsReportInterval" env:"OPERATORS_REPORT_INTERVAL" env-default:"10m" env-description:"Interval for reporting operators metrics"`
+	// StorageReportInterval is the interval for reporting storage size metrics
+	StorageReportInterval time.Duration `yaml:"StorageReportInterval" env:"STORAGE_REPORT_INTERVAL" env-default:"5m" env-description:"Interval for reporting storage size metrics"`
+	// ExporterMode disables validator metadata updates and duties fetching, for read-only deployments
+	ExporterMode bool `yaml:"ExporterMode" env:"EXPORTER_MODE" env-description:"Disables validator metadata updates and duties fetching, for read-only (exporter) deployments"`
 
 	ForkVersion forksprotocol.ForkVersion
 
@@ -62,6 +87,7 @@ type operatorNode struct {
 	net            network.P2PNetwork
 	storage        storage.Storage
 	qbftStorage    qbftstorageprotocol.QBFTStore
+	db             basedb.IDb
 	eth1Client     eth1.Client
 	dutyCtrl       duties.DutyController
 	//fork           *forks.Forker
@@ -70,12 +96,27 @@ type operatorNode struct {
 
 	ws        api.WebSocketServer
 	wsAPIPort int
+
+	operatorsReportInterval time.Duration
+	reportingOperators      uint32
+	storageReportInterval   time.Duration
+	exporterMode            bool
+
+	drainMode bool
+	drainLock sync.Mutex
+	drain     drainer
 }
 
 // New is the constructor of operatorNode
 func New(opts Options) Node {
 	qbftStorage := qbftstorage.New(opts.DB, opts.Logger, spectypes.BNRoleAttester.String(), opts.ForkVersion)
 
+	dutyExec := opts.DutyExec
+	if dutyExec == nil && opts.ValidatorOptions.ReadOnly {
+		// validators are not signing in read-only mode, therefore duties are only logged
+		dutyExec = duties.NewReadOnlyExecutor(opts.Logger)
+	}
+
 	node := &operatorNode{
 		context:        opts.Context,
 		logger:         opts.Logger.With(zap.String("component", "operatorNode")),
@@ -86,6 +127,7 @@ func New(opts Options) Node {
 		eth1Client:     opts.Eth1Client,
 		storage:        storage.NewNodeStorage(opts.DB, opts.Logger),
 		qbftStorage:    qbftStorage,
+		db:             opts.DB,
 
 		dutyCtrl: duties.NewDutyController(&duties.ControllerOptions{
 			Logger:              opts.Logger,
@@ -95,14 +137,28 @@ func New(opts Options) Node {
 			ValidatorController: opts.ValidatorController,
 			GenesisEpoch:        opts.GenesisEpoch,
 			DutyLimit:           opts.DutyLimit,
-			Executor:            opts.DutyExec,
+			RoleDutyLimits:      opts.DutyLimitPerRole,
+			Executor:            dutyExec,
 			ForkVersion:         opts.ForkVersion,
+			DrainMode:           opts.DrainMode,
+			ExporterMode:        opts.ExporterMode,
 		}),
 
 		forkVersion: opts.ForkVersion,
 
 		ws:        opts.WS,
 		wsAPIPort: opts.WsAPIPort,
+
+		operatorsReportInterval: opts.OperatorsReportInterval,
+		storageReportInterval:   opts.StorageReportInterval,
+		exporterMode:            opts.ExporterMode,
+
+		drainMode: opts.DrainMode,
+		drain: drainer{
+			delay:    defaultDrainUnsubscribeDelay,
+			interval: defaultDrainUnsubscribeInterval,
+			batch:    defaultDrainUnsubscribeBatch,
+		},
 	}
 
 	if err := node.init(opts); err != nil {
@@ -136,14 +192,27 @@ func (n *operatorNode) Start() error {
 	n.validatorsCtrl.StartNetworkHandlers()
 	n.validatorsCtrl.StartValidators()
 	go n.net.UpdateSubnets()
-	go n.validatorsCtrl.UpdateValidatorMetaDataLoop()
+	n.startMetadataUpdateLoop()
 	go n.listenForCurrentSlot()
-	go n.reportOperators()
+	go n.reportOperatorsLoop()
+	go n.reportStorageLoop()
+	if n.drainMode {
+		n.SetDrainMode(true)
+	}
 	n.dutyCtrl.Start()
 
 	return nil
 }
 
+// startMetadataUpdateLoop starts to update validators metadata, unless running in exporter mode
+func (n *operatorNode) startMetadataUpdateLoop() {
+	if n.exporterMode {
+		n.logger.Info("exporter mode is enabled, validators metadata won't be updated")
+		return
+	}
+	go n.validatorsCtrl.UpdateValidatorMetaDataLoop()
+}
+
 // listenForCurrentSlot listens to current slot and trigger relevant components if needed
 func (n *operatorNode) listenForCurrentSlot() {
 	for slot := range n.dutyCtrl.CurrentSlotChan() {
@@ -175,7 +244,7 @@ func (n *operatorNode) StartEth1(syncOffset *eth1.SyncOffset) error {
 	)
 
 	// setup validator controller to listen to new events
-	go n.validatorsCtrl.ListenToEth1Events(n.eth1Client.EventsFeed())
+	go n.validatorsCtrl.ListenToEth1Events(eth1.FeedSubscriber(n.eth1Client))
 
 	// starts the eth1 events subscription
 	if err := n.eth1Client.Start(); err != nil {
@@ -198,6 +267,7 @@ func (n *operatorNode) healthAgents() []metrics.HealthCheckAgent {
 	if agent, ok := n.beacon.(metrics.HealthCheckAgent); ok {
 		agents = append(agents, agent)
 	}
+	agents = append(agents, &qbftcontroller.SyncHealthCheck{Timeout: qbftcontroller.DefaultSyncStuckTimeout})
 	return agents
 }
 
@@ -232,7 +302,24 @@ func (n *operatorNode) startWSServer() error {
 	return nil
 }
 
+// reportOperatorsLoop reports operators on startup and then periodically
+func (n *operatorNode) reportOperatorsLoop() {
+	n.reportOperators()
+	interval := n.operatorsReportInterval
+	if interval <= 0 {
+		interval = defaultOperatorsReportInterval
+	}
+	async.Interval(n.context, interval, n.reportOperators)
+}
+
+// reportOperators reports the current operators, overlapping runs are skipped
 func (n *operatorNode) reportOperators() {
+	if !atomic.CompareAndSwapUint32(&n.reportingOperators, 0, 1) {
+		n.logger.Debug("operators reporting is already running")
+		return
+	}
+	defer atomic.StoreUint32(&n.reportingOperators, 0)
+
 	operators, err := n.storage.ListOperators(0, 1000) // TODO more than 1000?
 	if err != nil {
 		n.logger.Warn("failed to get all operators for reporting", zap.Error(err))
@@ -243,3 +330,21 @@ func (n *operatorNode) reportOperators() {
 		exporter.ReportOperatorIndex(n.logger, &operators[i])
 	}
 }
+
+func (n *operatorNode) reportStorageLoop() {
+	interval := n.storageReportInterval
+	if interval <= 0 {
+		interval = defaultStorageReportInterval
+	}
+	async.Interval(n.context, interval, n.reportStorage)
+}
+
+// reportStorage reports the keys count and approximate size of the main storage collections
+func (n *operatorNode) reportStorage() {
+	ssvstorage.ReportCollections(n.logger, n.db, []ssvstorage.Collection{
+		{Name: "decided", Prefixes: [][]byte{[]byte(spectypes.BNRoleAttester.String())}},
+		{Name: "shares", Prefixes: [][]byte{validator.SharesPrefix()}},
+		{Name: "operators", Prefixes: [][]byte{n.storage.OperatorsCollectionPrefix()}},
+		{Name: "slashing", Prefixes: ekm.SlashingProtectionPrefixes(n.ethNetwork)},
+	})
+}
@@ -0,0 +1,208 @@
+package operator
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/golang/mock/gomock"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network"
+	dutiesmocks "github.com/bloxapp/ssv/operator/duties/mocks"
+	"github.com/bloxapp/ssv/operator/storage"
+	validatormocks "github.com/bloxapp/ssv/operator/validator/mocks"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestOperatorNode_ReportOperators(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := kv.New(basedb.Options{
+		Type:   "badger-memory",
+		Path:   "",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	nodeStorage := storage.NewNodeStorage(db, zap.L())
+	require.NoError(t, nodeStorage.SaveOperatorData(&registrystorage.OperatorData{
+		PublicKey: "operator-1-pk",
+		Name:      "report-operator-1",
+		Index:     1,
+	}))
+
+	n := &operatorNode{
+		context:                 ctx,
+		logger:                  zap.L(),
+		storage:                 nodeStorage,
+		operatorsReportInterval: 50 * time.Millisecond,
+	}
+	go n.reportOperatorsLoop()
+
+	reported := func(name string) bool {
+		families, err := prometheus.DefaultGatherer.Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() != "ssv:exporter:operator_index" {
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				for _, label := range m.GetLabel() {
+					if label.GetName() == "name" && label.GetValue() == name {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+
+	require.Eventually(t, func() bool {
+		return reported("report-operator-1")
+	}, time.Second, 10*time.Millisecond)
+	require.False(t, reported("report-operator-2"))
+
+	// a new operator is reported on the next interval
+	require.NoError(t, nodeStorage.SaveOperatorData(&registrystorage.OperatorData{
+		PublicKey: "operator-2-pk",
+		Name:      "report-operator-2",
+		Index:     2,
+	}))
+	require.Eventually(t, func() bool {
+		return reported("report-operator-2")
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestOperatorNode_ReportOperatorsOverlap(t *testing.T) {
+	n := &operatorNode{
+		logger:             zap.L(),
+		reportingOperators: 1,
+	}
+	// storage is not accessed while another run is in progress
+	require.NotPanics(t, n.reportOperators)
+}
+
+func TestOperatorNode_MetadataUpdateLoop(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		started := make(chan struct{})
+		validatorsCtrl := validatormocks.NewMockController(mockCtrl)
+		validatorsCtrl.EXPECT().UpdateValidatorMetaDataLoop().Do(func() {
+			close(started)
+		}).Times(1)
+
+		n := &operatorNode{logger: zap.L(), validatorsCtrl: validatorsCtrl}
+		n.startMetadataUpdateLoop()
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("metadata update loop should be started")
+		}
+	})
+
+	t.Run("exporter mode", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		validatorsCtrl := validatormocks.NewMockController(mockCtrl)
+		validatorsCtrl.EXPECT().UpdateValidatorMetaDataLoop().Times(0)
+
+		n := &operatorNode{logger: zap.L(), validatorsCtrl: validatorsCtrl, exporterMode: true}
+		n.startMetadataUpdateLoop()
+		// gives a chance to a wrongly started loop to run before the expectations are verified
+		time.Sleep(50 * time.Millisecond)
+	})
+}
+
+// subscriptionsNetwork records the validator topics the node is subscribed to
+type subscriptionsNetwork struct {
+	network.P2PNetwork
+
+	lock       sync.Mutex
+	subscribed map[string]bool
+}
+
+func (n *subscriptionsNetwork) Subscribe(pk spectypes.ValidatorPK) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.subscribed[hex.EncodeToString(pk)] = true
+	return nil
+}
+
+func (n *subscriptionsNetwork) Unsubscribe(pk spectypes.ValidatorPK) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.subscribed, hex.EncodeToString(pk))
+	return nil
+}
+
+func (n *subscriptionsNetwork) count() int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return len(n.subscribed)
+}
+
+func TestOperatorNode_DrainMode(t *testing.T) {
+	threshold.Init()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	net := &subscriptionsNetwork{subscribed: make(map[string]bool)}
+	var shares []*beaconprotocol.Share
+	for i := 0; i < 5; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		shares = append(shares, &beaconprotocol.Share{PublicKey: sk.GetPublicKey()})
+		require.NoError(t, net.Subscribe(sk.GetPublicKey().Serialize()))
+	}
+	validatorsCtrl := validatormocks.NewMockController(mockCtrl)
+	validatorsCtrl.EXPECT().GetAllValidatorShares().Return(shares, nil).AnyTimes()
+	dutyCtrl := dutiesmocks.NewMockDutyController(mockCtrl)
+	dutyCtrl.EXPECT().SetDrainMode(true).Times(1)
+	dutyCtrl.EXPECT().SetDrainMode(false).Times(1)
+
+	n := &operatorNode{
+		context:        context.Background(),
+		logger:         zap.L(),
+		validatorsCtrl: validatorsCtrl,
+		dutyCtrl:       dutyCtrl,
+		net:            net,
+		drain: drainer{
+			delay:    50 * time.Millisecond,
+			interval: 100 * time.Millisecond,
+			batch:    2,
+		},
+	}
+
+	n.SetDrainMode(true)
+	// running duties have time to complete before validators are unsubscribed
+	require.Equal(t, 5, net.count())
+	// validators are unsubscribed in stages
+	require.Eventually(t, func() bool {
+		return net.count() == 3
+	}, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return net.count() == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// disabling drain mode stops the remaining stages and subscribes back
+	n.SetDrainMode(false)
+	require.Eventually(t, func() bool {
+		return net.count() == 5
+	}, time.Second, 5*time.Millisecond)
+	<-time.After(200 * time.Millisecond)
+	require.Equal(t, 5, net.count())
+}
@@ -27,6 +27,8 @@ type Storage interface {
 
 	GetPrivateKey() (*rsa.PrivateKey, bool, error)
 	SetupPrivateKey(generateIfNone bool, operatorKeyBase64 string) error
+	// OperatorsCollectionPrefix returns the full storage prefix of the operators collection
+	OperatorsCollectionPrefix() []byte
 }
 
 type storage struct {
@@ -61,6 +63,10 @@ func (s *storage) DeleteOperatorData(index uint64) error {
 	return s.operatorStore.DeleteOperatorData(index)
 }
 
+func (s *storage) MarkOperatorRemoved(index uint64) error {
+	return s.operatorStore.MarkOperatorRemoved(index)
+}
+
 func (s *storage) ListOperators(from uint64, to uint64) ([]registrystorage.OperatorData, error) {
 	return s.operatorStore.ListOperators(from, to)
 }
@@ -69,6 +75,12 @@ func (s *storage) GetOperatorsPrefix() []byte {
 	return s.operatorStore.GetOperatorsPrefix()
 }
 
+func (s *storage) OperatorsCollectionPrefix() []byte {
+	prefix := make([]byte, len(storagePrefix))
+	copy(prefix, storagePrefix)
+	return append(prefix, s.GetOperatorsPrefix()...)
+}
+
 func (s *storage) CleanRegistryData() error {
 	err := s.cleanSyncOffset()
 	if err != nil {
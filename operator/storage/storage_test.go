@@ -191,3 +191,27 @@ func TestStorage_SaveAndGetSyncOffset(t *testing.T) {
 	require.NoError(t, err)
 	require.Zero(t, offset.Cmp(o))
 }
+
+func TestSetupGeneratedPrivateKey(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	pk, sk, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+
+	nodeStorage := NewNodeStorage(db, zap.L())
+	require.NoError(t, nodeStorage.SetupPrivateKey(false, base64.StdEncoding.EncodeToString(sk)))
+
+	storedSk, found, err := nodeStorage.GetPrivateKey()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, string(sk), string(rsaencryption.PrivateKeyToByte(storedSk)))
+	operatorPublicKey, err := rsaencryption.ExtractPublicKey(storedSk)
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(pk), operatorPublicKey)
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"crypto/rsa"
 	"encoding/hex"
+	"math/rand"
 	"sync"
 	"time"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/eth1"
@@ -35,7 +36,8 @@ import (
 //go:generate mockgen -package=mocks -destination=./mocks/controller.go -source=./controller.go
 
 const (
-	metadataBatchSize = 25
+	metadataBatchSize       = 25
+	eth1ResubscribeInterval = time.Second
 )
 
 // ShareEncryptionKeyProvider is a function that returns the operator private key
@@ -51,6 +53,8 @@ type ControllerOptions struct {
 	Logger                     *zap.Logger
 	SignatureCollectionTimeout time.Duration `yaml:"SignatureCollectionTimeout" env:"SIGNATURE_COLLECTION_TIMEOUT" env-default:"5s" env-description:"Timeout for signature collection after consensus"`
 	MetadataUpdateInterval     time.Duration `yaml:"MetadataUpdateInterval" env:"METADATA_UPDATE_INTERVAL" env-default:"12m" env-description:"Interval for updating metadata"`
+	MetadataUpdateJitter       time.Duration `yaml:"MetadataUpdateJitter" env:"METADATA_UPDATE_JITTER" env-default:"1m" env-description:"Maximum random delay that is added to the metadata update interval"`
+	MetadataUpdateBatchSize    int           `yaml:"MetadataUpdateBatchSize" env:"METADATA_UPDATE_BATCH_SIZE" env-default:"25" env-description:"Amount of validators to fetch metadata for in a single beacon request"`
 	HistorySyncRateLimit       time.Duration `yaml:"HistorySyncRateLimit" env:"HISTORY_SYNC_BACKOFF" env-default:"200ms" env-description:"Interval for updating metadata"`
 	MinPeers                   int           `yaml:"MinimumPeers" env:"MINIMUM_PEERS" env-default:"2" env-description:"The required minimum peers for sync"`
 	ETHNetwork                 beaconprotocol.Network
@@ -66,6 +70,23 @@ type ControllerOptions struct {
 	ForkVersion                forksprotocol.ForkVersion
 	NewDecidedHandler          qbftcontroller.NewDecidedHandler
 	DutyRoles                  []spectypes.BeaconRole
+	DefaultFeeRecipient        string `yaml:"DefaultFeeRecipient" env:"DEFAULT_FEE_RECIPIENT" env-description:"Fee recipient address of block proposals, used for validators w/o a fee recipient override"`
+	AsyncStatePersistence      bool   `yaml:"AsyncStatePersistence" env:"ASYNC_STATE_PERSISTENCE" env-default:"false" env-description:"Flag that indicates whether the state of running instances is saved in the background"`
+	// DisableHighestRoundCatchup disables the fast catchup of the highest round upon round change
+	DisableHighestRoundCatchup bool `yaml:"DisableHighestRoundCatchup" env:"DISABLE_HIGHEST_ROUND_CATCHUP" env-default:"false" env-description:"Flag that indicates whether to disable fetching the highest round change from peers upon round change"`
+	// LateMessagesWindow is the time to wait for late messages (e.g. late commits) once an instance is done
+	LateMessagesWindow time.Duration `yaml:"LateMessagesWindow" env:"LATE_MESSAGES_WINDOW" env-default:"1m" env-description:"Time to wait for late messages once an instance is done, afterwards the retained messages are purged"`
+	// DutyTimeout is the deadline of a duty's consensus, once passed the instance is stopped and the duty is abandoned
+	DutyTimeout time.Duration `yaml:"DutyTimeout" env:"DUTY_TIMEOUT" env-default:"2m" env-description:"Deadline for reaching consensus on a duty, afterwards the instance is stopped so other duties of the role can proceed (0 disables)"`
+	// MaxValueSize is the max size (bytes) of a proposed value, proposals with larger values are rejected
+	MaxValueSize int `yaml:"MaxValueSize" env:"MAX_VALUE_SIZE" env-default:"1048576" env-description:"Maximum size in bytes of a proposed value, larger proposals are rejected"`
+	// ReadOnly runs all validators in read mode, i.e. decided messages are tracked w/o signing or broadcasting.
+	// the key manager is not used in this mode and can be nil
+	ReadOnly bool `yaml:"ReadOnly" env:"READ_ONLY" env-default:"false" env-description:"Flag that indicates whether validators only track decided messages, w/o signing or broadcasting"`
+	// RoleSignatureCollectionTimeouts overrides SignatureCollectionTimeout for specific roles, keyed by role name (e.g. SYNC_COMMITTEE)
+	RoleSignatureCollectionTimeouts map[string]time.Duration `yaml:"RoleSignatureCollectionTimeouts" env:"ROLE_SIGNATURE_COLLECTION_TIMEOUTS" env-description:"Per role timeout for signature collection after consensus, e.g. SYNC_COMMITTEE:12s"`
+	// RoleMinPeers overrides MinPeers for specific roles, keyed by role name (e.g. SYNC_COMMITTEE)
+	RoleMinPeers map[string]int `yaml:"RoleMinimumPeers" env:"ROLE_MINIMUM_PEERS" env-description:"Per role required minimum peers for sync, e.g. SYNC_COMMITTEE:1"`
 
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"4096" env-description:"Number of goroutines to use for message workers"`
@@ -75,9 +96,11 @@ type ControllerOptions struct {
 // Controller represent the validators controller,
 // it takes care of bootstrapping, updating and managing existing validators and their shares
 type Controller interface {
-	ListenToEth1Events(feed *event.Feed)
+	ListenToEth1Events(subscribe eth1.EventsSubscriber)
 	StartValidators()
 	GetValidatorsIndices() []spec.ValidatorIndex
+	// GetFeeRecipients returns the fee recipients of the active validators by their indices
+	GetFeeRecipients() map[spec.ValidatorIndex]common.Address
 	GetValidator(pubKey string) (validator.IValidator, bool)
 	UpdateValidatorMetaDataLoop()
 	StartNetworkHandlers()
@@ -86,7 +109,7 @@ type Controller interface {
 	OnFork(forkVersion forksprotocol.ForkVersion) error
 	// GetValidatorStats returns stats of validators, including the following:
 	//  - the amount of validators in the network
-	//  - the amount of active validators (i.e. not slashed or existed)
+	//  - the amount of active validators, i.e. eligible for duties (not slashed, exited or liquidated)
 	//  - the amount of validators assigned to this operator
 	GetValidatorStats() (uint64, uint64, uint64, error)
 }
@@ -100,6 +123,7 @@ type controller struct {
 	logger      *zap.Logger
 	beacon      beaconprotocol.Beacon
 	keyManager  spectypes.KeyManager
+	readOnly    bool
 
 	shareEncryptionKeyProvider ShareEncryptionKeyProvider
 	operatorPubKey             string
@@ -107,49 +131,55 @@ type controller struct {
 	validatorsMap    *validatorsMap
 	validatorOptions *validator.Options // TODO(nkryuchkov): check if it's needed
 
-	metadataUpdateQueue    utilsprotocol.Queue
-	metadataUpdateInterval time.Duration
-
-	operatorsIDs  *sync.Map
-	network       network.P2PNetwork
-	forkVersion   forksprotocol.ForkVersion
-	messageRouter *messageRouter
-	messageWorker *worker.Worker
+	metadataUpdateQueue     utilsprotocol.Queue
+	metadataUpdateInterval  time.Duration
+	metadataUpdateJitter    time.Duration
+	metadataUpdateBatchSize int
+
+	operatorsIDs    *sync.Map
+	network         network.P2PNetwork
+	forkVersion     forksprotocol.ForkVersion
+	forkCoordinator *forkCoordinator
+	messageRouter   *messageRouter
+	messageWorker   *worker.Worker
 }
 
 // OnFork called upon a fork, it will propagate the fork event to all internal components.
+// all validators are forked together by the fork coordinator, which pauses message processing during the transition.
 // triggering validators fork with goroutines as validator.OnFork might block due to
 // decided message processing in the qbft controllers
 func (c *controller) OnFork(forkVersion forksprotocol.ForkVersion) error {
-	c.forkVersion = forkVersion
-	c.validatorOptions.ForkVersion = forkVersion
+	return c.forkCoordinator.transition(forkVersion, func() error {
+		c.forkVersion = forkVersion
+		c.validatorOptions.ForkVersion = forkVersion
 
-	storageHandler, ok := c.validatorOptions.IbftStorage.(forksprotocol.ForkHandler)
-	if !ok {
-		return errors.New("ibft storage is not a fork handler")
-	}
-	err := storageHandler.OnFork(forkVersion)
-	if err != nil {
-		return err
-	}
+		storageHandler, ok := c.validatorOptions.IbftStorage.(forksprotocol.ForkHandler)
+		if !ok {
+			return errors.New("ibft storage is not a fork handler")
+		}
+		err := storageHandler.OnFork(forkVersion)
+		if err != nil {
+			return err
+		}
 
-	var wg sync.WaitGroup
-	var errLock sync.Mutex
-	_ = c.validatorsMap.ForEach(func(iValidator validator.IValidator) error {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			if localErr := iValidator.OnFork(forkVersion); localErr != nil {
-				errLock.Lock()
-				err = localErr
-				errLock.Unlock()
-			}
-		}()
-		return nil
-	})
-	wg.Wait()
+		var wg sync.WaitGroup
+		var errLock sync.Mutex
+		_ = c.validatorsMap.ForEach(func(iValidator validator.IValidator) error {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if localErr := iValidator.OnFork(forkVersion); localErr != nil {
+					errLock.Lock()
+					err = localErr
+					errLock.Unlock()
+				}
+			}()
+			return nil
+		})
+		wg.Wait()
 
-	return err
+		return err
+	})
 }
 
 // NewController creates a new validator controller instance
@@ -186,9 +216,18 @@ func NewController(options ControllerOptions) Controller {
 		SignatureCollectionTimeout: options.SignatureCollectionTimeout,
 		MinPeers:                   options.MinPeers,
 		IbftStorage:                qbftStorage,
-		ReadMode:                   false, // set to false for committee validators. if non committee, we set validator with true value
+		ReadMode:                   options.ReadOnly, // committee validators are in read mode only in read-only mode. non committee validators are always in read mode
 		FullNode:                   options.FullNode,
 		NewDecidedHandler:          options.NewDecidedHandler,
+		AsyncStatePersistence:      options.AsyncStatePersistence,
+		DisableHighestRoundCatchup: options.DisableHighestRoundCatchup,
+		LateMessagesWindow:         options.LateMessagesWindow,
+		DutyTimeout:                options.DutyTimeout,
+		MaxValueSize:               options.MaxValueSize,
+		DefaultFeeRecipient:        defaultFeeRecipient(options.Logger, options.DefaultFeeRecipient),
+
+		RoleSignatureCollectionTimeouts: roleSigTimeouts(options.Logger, options.RoleSignatureCollectionTimeouts),
+		RoleMinPeers:                    roleMinPeers(options.Logger, options.RoleMinPeers),
 	}
 	ctrl := controller{
 		collection:                 collection,
@@ -200,14 +239,18 @@ func NewController(options ControllerOptions) Controller {
 		shareEncryptionKeyProvider: options.ShareEncryptionKeyProvider,
 		operatorPubKey:             options.OperatorPubKey,
 		keyManager:                 options.KeyManager,
+		readOnly:                   options.ReadOnly,
 		network:                    options.Network,
 		forkVersion:                options.ForkVersion,
+		forkCoordinator:            newForkCoordinator(options.Logger, options.ForkVersion),
 
 		validatorsMap:    newValidatorsMap(options.Context, options.Logger, options.DB, validatorOptions),
 		validatorOptions: validatorOptions,
 
-		metadataUpdateQueue:    tasks.NewExecutionQueue(10 * time.Millisecond),
-		metadataUpdateInterval: options.MetadataUpdateInterval,
+		metadataUpdateQueue:     tasks.NewExecutionQueue(10 * time.Millisecond),
+		metadataUpdateInterval:  options.MetadataUpdateInterval,
+		metadataUpdateJitter:    options.MetadataUpdateJitter,
+		metadataUpdateBatchSize: options.MetadataUpdateBatchSize,
 
 		operatorsIDs: operatorsIDs,
 
@@ -242,6 +285,8 @@ func (c *controller) GetAllValidatorShares() ([]*beaconprotocol.Share, error) {
 	return c.collection.GetAllValidatorShares()
 }
 
+// GetValidatorStats returns the total, active and operator validators counts, see Controller.
+// liquidated validators are counted in the total and operator counts, but they are not active as they don't perform duties
 func (c *controller) GetValidatorStats() (uint64, uint64, uint64, error) {
 	allShares, err := c.collection.GetAllValidatorShares()
 	if err != nil {
@@ -253,7 +298,7 @@ func (c *controller) GetValidatorStats() (uint64, uint64, uint64, error) {
 		if ok := s.IsOperatorShare(c.operatorPubKey); ok {
 			operatorShares++
 		}
-		if s.HasMetadata() && s.Metadata.IsActive() {
+		if !s.Liquidated && s.HasMetadata() && s.Metadata.IsActive() {
 			active++
 		}
 	}
@@ -275,7 +320,12 @@ func (c *controller) handleRouterMessages() {
 			hexPK := hex.EncodeToString(pk)
 
 			if v, ok := c.validatorsMap.GetValidator(hexPK); ok {
-				if err := v.ProcessMsg(&msg); err != nil {
+				if err := c.forkCoordinator.process(func() error {
+					return v.ProcessMsg(&msg)
+				}); err != nil {
+					if errors.Is(err, validator.ErrNoControllerForRole) {
+						reportRouterDrop(dropReasonNoQueueForRole)
+					}
 					c.logger.Warn("failed to process message", zap.Error(err))
 				}
 			} else {
@@ -283,6 +333,7 @@ func (c *controller) handleRouterMessages() {
 					continue // not supporting other types
 				}
 				if !c.messageWorker.TryEnqueue(&msg) { // start to save non committee decided messages only post fork
+					reportRouterDrop(dropReasonFullQueue)
 					c.logger.Warn("Failed to enqueue post consensus message: buffer is full")
 				}
 			}
@@ -312,28 +363,51 @@ func (c *controller) handleWorkerMessages(msg *spectypes.SSVMessage) error {
 		return errors.Errorf("could not find validator [%s]", hex.EncodeToString(msg.GetID().GetPubKey()))
 	}
 
-	opts := *c.validatorOptions
-	opts.Share = share
-	opts.ReadMode = true
+	return c.forkCoordinator.process(func() error {
+		opts := *c.validatorOptions
+		opts.Share = share
+		opts.ReadMode = true
 
-	return validator.NewValidator(&opts).ProcessMsg(msg)
+		return validator.NewValidator(&opts).ProcessMsg(msg)
+	})
 }
 
-// ListenToEth1Events is listening to events coming from eth1 client
-func (c *controller) ListenToEth1Events(feed *event.Feed) {
+// ListenToEth1Events is listening to events coming from eth1 client,
+// once the subscription is closed it re-subscribes until the controller context is done
+func (c *controller) ListenToEth1Events(subscribe eth1.EventsSubscriber) {
+	handler := c.Eth1EventHandler(true)
+
+	for {
+		c.listenToEth1Subscription(subscribe, handler)
+		select {
+		case <-c.context.Done():
+			return
+		case <-time.After(eth1ResubscribeInterval):
+			c.logger.Debug("re-subscribing to eth1 events")
+		}
+	}
+}
+
+// listenToEth1Subscription handles events until the subscription is closed or the controller context is done
+func (c *controller) listenToEth1Subscription(subscribe eth1.EventsSubscriber, handler eth1.SyncEventHandler) {
 	cn := make(chan *eth1.Event)
-	sub := feed.Subscribe(cn)
+	sub := subscribe(cn)
 	defer sub.Unsubscribe()
 
-	handler := c.Eth1EventHandler(true)
-
 	for {
 		select {
 		case e := <-cn:
 			logFields, err := handler(*e)
 			_ = eth1.HandleEventResult(c.logger, *e, logFields, err, true)
-		case err := <-sub.Err():
-			c.logger.Warn("event feed subscription error", zap.Error(err))
+		case err, ok := <-sub.Err():
+			if ok && err != nil {
+				c.logger.Warn("event feed subscription error", zap.Error(err))
+			} else {
+				c.logger.Warn("event feed subscription was closed")
+			}
+			return
+		case <-c.context.Done():
+			return
 		}
 	}
 }
@@ -400,12 +474,36 @@ func (c *controller) StartNetworkHandlers() {
 func (c *controller) updateValidatorsMetadata(pubKeys [][]byte) {
 	if len(pubKeys) > 0 {
 		c.logger.Debug("updating validators", zap.Int("count", len(pubKeys)))
-		if err := beaconprotocol.UpdateValidatorsMetadata(pubKeys, c, c.beacon, c.onMetadataUpdated); err != nil {
-			c.logger.Warn("could not update all validators", zap.Error(err))
+		batchSize := c.metadataBatchSize()
+		for start := 0; start < len(pubKeys); start += batchSize {
+			end := start + batchSize
+			if end > len(pubKeys) {
+				end = len(pubKeys)
+			}
+			if err := beaconprotocol.UpdateValidatorsMetadata(pubKeys[start:end], c, c.beacon, c.onMetadataUpdated); err != nil {
+				c.logger.Warn("could not update all validators", zap.Error(err))
+			}
 		}
 	}
 }
 
+// metadataBatchSize returns the configured batch size of metadata updates, fallbacks to the default
+func (c *controller) metadataBatchSize() int {
+	if c.metadataUpdateBatchSize > 0 {
+		return c.metadataUpdateBatchSize
+	}
+	return metadataBatchSize
+}
+
+// metadataUpdateDelay returns the delay until the next metadata update,
+// a random jitter is added to the interval so nodes won't burst the beacon node at the same time
+func (c *controller) metadataUpdateDelay() time.Duration {
+	if c.metadataUpdateJitter <= 0 {
+		return c.metadataUpdateInterval
+	}
+	return c.metadataUpdateInterval + time.Duration(rand.Int63n(int64(c.metadataUpdateJitter)))
+}
+
 // UpdateValidatorMetadata updates a given validator with metadata (implements ValidatorMetadataStorage)
 func (c *controller) UpdateValidatorMetadata(pk string, metadata *beaconprotocol.ValidatorMetadata) error {
 	if metadata == nil {
@@ -452,6 +550,21 @@ func (c *controller) GetValidatorsIndices() []spec.ValidatorIndex {
 	return indices
 }
 
+// GetFeeRecipients returns the fee recipients of the active validators by their indices
+func (c *controller) GetFeeRecipients() map[spec.ValidatorIndex]common.Address {
+	feeRecipients := make(map[spec.ValidatorIndex]common.Address)
+	err := c.validatorsMap.ForEach(func(v validator.IValidator) error {
+		if share := v.GetShare(); share.HasMetadata() && share.Metadata.IsActive() {
+			feeRecipients[share.Metadata.Index] = v.FeeRecipient()
+		}
+		return nil
+	})
+	if err != nil {
+		c.logger.Warn("failed to get fee recipients", zap.Error(err))
+	}
+	return feeRecipients
+}
+
 // onMetadataUpdated is called when validator's metadata was updated
 func (c *controller) onMetadataUpdated(pk string, meta *beaconprotocol.ValidatorMetadata) {
 	if meta == nil {
@@ -492,7 +605,7 @@ func (c *controller) onShareCreate(validatorEvent abiparser.ValidatorRegistratio
 	isOperatorShare := share.IsOperatorShare(c.operatorPubKey)
 
 	if isOperatorShare {
-		if shareSecret == nil {
+		if shareSecret == nil && !c.readOnly {
 			return nil, isOperatorShare, errors.New("could not decode shareSecret")
 		}
 
@@ -505,9 +618,11 @@ func (c *controller) onShareCreate(validatorEvent abiparser.ValidatorRegistratio
 			logger.Warn("could not find validator metadata")
 		}
 
-		// save secret key
-		if err := c.keyManager.AddShare(shareSecret); err != nil {
-			return nil, isOperatorShare, errors.Wrap(err, "could not add share secret to key manager")
+		// save secret key, shares are not signing in read-only mode
+		if !c.readOnly {
+			if err := c.keyManager.AddShare(shareSecret); err != nil {
+				return nil, isOperatorShare, errors.Wrap(err, "could not add share secret to key manager")
+			}
 		}
 	}
 
@@ -531,8 +646,8 @@ func (c *controller) onShareRemove(pk string, removeSecret bool) error {
 			return errors.Wrap(err, "could not close validator")
 		}
 	}
-	// remove the share secret from key-manager
-	if removeSecret {
+	// remove the share secret from key-manager, shares are not added to key-manager in read-only mode
+	if removeSecret && !c.readOnly {
 		if err := c.keyManager.RemoveShare(pk); err != nil {
 			return errors.Wrap(err, "could not remove share secret from key manager")
 		}
@@ -570,19 +685,20 @@ func (c *controller) UpdateValidatorMetaDataLoop() {
 	go c.metadataUpdateQueue.Start()
 
 	for {
-		time.Sleep(c.metadataUpdateInterval)
+		time.Sleep(c.metadataUpdateDelay())
 
 		shares, err := c.collection.GetOperatorValidatorShares(c.operatorPubKey, true)
 		if err != nil {
 			c.logger.Warn("could not get validators shares for metadata update", zap.Error(err))
 			continue
 		}
+		reportValidatorsCount(shares)
 		var pks [][]byte
 		for _, share := range shares {
 			pks = append(pks, share.PublicKey.Serialize())
 		}
 		c.logger.Debug("updating metadata in loop", zap.Int("shares count", len(shares)))
 		beaconprotocol.UpdateValidatorsMetadataBatch(pks, c.metadataUpdateQueue, c,
-			c.beacon, c.onMetadataUpdated, metadataBatchSize)
+			c.beacon, c.onMetadataUpdated, c.metadataBatchSize())
 	}
 }
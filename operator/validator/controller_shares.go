@@ -55,8 +55,10 @@ func (c *controller) loadShare(options ShareOptions) (string, error) {
 		} else if !updated {
 			return "", errors.New("could not find validator metadata")
 		}
-		if err := c.keyManager.AddShare(shareKey); err != nil {
-			return "", errors.Wrap(err, "could not save share key from share options")
+		if !c.readOnly {
+			if err := c.keyManager.AddShare(shareKey); err != nil {
+				return "", errors.Wrap(err, "could not save share key from share options")
+			}
 		}
 		if err := c.collection.SaveValidatorShare(share); err != nil {
 			return "", errors.Wrap(err, "could not save share from share options")
@@ -3,20 +3,36 @@ package validator
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/network"
 	"github.com/bloxapp/ssv/network/forks/genesis"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/queue/worker"
+	testingprotocol "github.com/bloxapp/ssv/protocol/v1/testing"
 	"github.com/bloxapp/ssv/protocol/v1/validator"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
 )
 
 func init() {
@@ -68,6 +84,209 @@ func TestHandleNonCommitteeMessages(t *testing.T) {
 
 }
 
+// broadcastCountingNetwork counts the messages that were broadcasted
+type broadcastCountingNetwork struct {
+	network.P2PNetwork
+	broadcasted int32
+}
+
+func (n *broadcastCountingNetwork) Broadcast(msg spectypes.SSVMessage) error {
+	atomic.AddInt32(&n.broadcasted, 1)
+	return nil
+}
+
+func (n *broadcastCountingNetwork) Subscribe(vpk spectypes.ValidatorPK) error {
+	return nil
+}
+
+func (n *broadcastCountingNetwork) Peers(vpk spectypes.ValidatorPK) ([]peer.ID, error) {
+	return nil, nil
+}
+
+// usageCountingKeyManager counts the usages of the key manager,
+// signing methods that are not overridden will panic
+type usageCountingKeyManager struct {
+	spectypes.KeyManager
+	used int32
+}
+
+func (km *usageCountingKeyManager) AddShare(shareKey *bls.SecretKey) error {
+	atomic.AddInt32(&km.used, 1)
+	return nil
+}
+
+func (km *usageCountingKeyManager) RemoveShare(pubKey string) error {
+	atomic.AddInt32(&km.used, 1)
+	return nil
+}
+
+func (km *usageCountingKeyManager) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
+	atomic.AddInt32(&km.used, 1)
+	return nil, nil
+}
+
+func TestReadOnlyController(t *testing.T) {
+	sks, nodes := testingprotocol.GenerateBLSKeys(1, 2, 3, 4)
+	net := &broadcastCountingNetwork{}
+	km := &usageCountingKeyManager{}
+	// the beacon mock has no expectations, any request for duty data fails the test
+	beaconMock := beacon.NewMockBeacon(gomock.NewController(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctr := NewController(ControllerOptions{
+		Context:         ctx,
+		DB:              testingprotocol.NewInMemDb(),
+		Logger:          logex.GetLogger(),
+		ETHNetwork:      beacon.NewNetwork(core.PraterNetwork),
+		Network:         net,
+		Beacon:          beaconMock,
+		KeyManager:      km,
+		ForkVersion:     forksprotocol.GenesisForkVersion,
+		DutyRoles:       []spectypes.BeaconRole{spectypes.BNRoleAttester},
+		ReadOnly:        true,
+		WorkersCount:    1,
+		QueueBufferSize: 10,
+	}).(*controller)
+
+	validatorSk := &bls.SecretKey{}
+	validatorSk.SetByCSPRNG()
+	share := &beacon.Share{
+		NodeID:    1,
+		PublicKey: validatorSk.GetPublicKey(),
+		Committee: nodes,
+		Metadata:  &beacon.ValidatorMetadata{Index: 1},
+	}
+	v := ctr.validatorsMap.GetOrCreateValidator(share)
+
+	identifier := spectypes.NewMsgID(share.PublicKey.Serialize(), spectypes.BNRoleAttester)
+	decided := testingprotocol.AggregateSign(t, sks, []spectypes.OperatorID{1, 2, 3}, &specqbft.Message{
+		MsgType:    specqbft.CommitMsgType,
+		Height:     specqbft.Height(1),
+		Round:      specqbft.Round(1),
+		Identifier: identifier[:],
+		Data:       testingprotocol.CommitDataToBytes(t, &specqbft.CommitData{Data: []byte("value")}),
+	})
+	encoded, err := decided.Encode()
+	require.NoError(t, err)
+	require.NoError(t, v.ProcessMsg(&spectypes.SSVMessage{
+		MsgType: spectypes.SSVDecidedMsgType,
+		MsgID:   identifier,
+		Data:    encoded,
+	}))
+
+	// decided messages are still recorded
+	stored, err := ctr.ibftStorage.GetLastDecided(identifier[:])
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Equal(t, specqbft.Height(1), stored.Message.Height)
+
+	// duties are not executed, therefore nothing is signed
+	require.NoError(t, v.Start())
+	pk := phase0.BLSPubKey{}
+	copy(pk[:], share.PublicKey.Serialize())
+	v.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 12})
+
+	// the share secret is not removed as it was never added
+	require.NoError(t, ctr.onShareRemove(share.PublicKey.SerializeToHexStr(), true))
+
+	require.Zero(t, atomic.LoadInt32(&net.broadcasted), "no messages should be broadcasted")
+	require.Zero(t, atomic.LoadInt32(&km.used), "key manager should not be used")
+}
+
+func TestGetValidatorStats(t *testing.T) {
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	splitKeys, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+
+	const operatorPubKey = "operator-pk"
+	newShare := func(operatorShare bool, status eth2apiv1.ValidatorState, liquidated bool) *beacon.Share {
+		share, _ := generateRandomValidatorShare(splitKeys)
+		if operatorShare {
+			share.Operators = [][]byte{[]byte(operatorPubKey)}
+		}
+		if status != eth2apiv1.ValidatorStateUnknown {
+			share.Metadata = &beacon.ValidatorMetadata{Index: 1, Status: status}
+		}
+		share.Liquidated = liquidated
+		return share
+	}
+	shares := []*beacon.Share{
+		newShare(true, eth2apiv1.ValidatorStateActiveOngoing, false),
+		newShare(true, eth2apiv1.ValidatorStateActiveOngoing, true), // liquidated
+		newShare(true, eth2apiv1.ValidatorStatePendingQueued, false),
+		newShare(false, eth2apiv1.ValidatorStateActiveOngoing, false),
+		newShare(false, eth2apiv1.ValidatorStateActiveOngoing, true), // liquidated
+		newShare(false, eth2apiv1.ValidatorStateExitedUnslashed, false),
+		newShare(false, eth2apiv1.ValidatorStateUnknown, false), // w/o metadata
+	}
+
+	collection := NewCollection(CollectionOptions{DB: testingprotocol.NewInMemDb(), Logger: zap.L()})
+	for _, share := range shares {
+		require.NoError(t, collection.SaveValidatorShare(share))
+	}
+	ctr := &controller{collection: collection, operatorPubKey: operatorPubKey}
+
+	total, active, operatorValidators, err := ctr.GetValidatorStats()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), total)
+	require.Equal(t, uint64(2), active)
+	require.Equal(t, uint64(3), operatorValidators)
+}
+
+func TestHandleOperatorRemovalEvent(t *testing.T) {
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	splitKeys, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+
+	const operatorPubKey = "operator-pk"
+	db := testingprotocol.NewInMemDb()
+	operators := registrystorage.NewOperatorsStorage(db, zap.L(), []byte("test"))
+	require.NoError(t, operators.SaveOperatorData(&registrystorage.OperatorData{PublicKey: operatorPubKey, Index: 1}))
+	require.NoError(t, operators.SaveOperatorData(&registrystorage.OperatorData{PublicKey: "other-pk", Index: 2}))
+	collection := NewCollection(CollectionOptions{DB: db, Logger: zap.L()})
+	share, _ := generateRandomValidatorShare(splitKeys)
+	share.OperatorIds = []uint64{1, 2, 3, 4}
+	require.NoError(t, collection.SaveValidatorShare(share))
+
+	ctr := &controller{
+		logger:           zap.L(),
+		collection:       collection,
+		storage:          operators,
+		operatorPubKey:   operatorPubKey,
+		validatorOptions: &validator.Options{},
+	}
+
+	// own operator is removed along with its shares
+	_, err = ctr.handleOperatorRemovalEvent(abiparser.OperatorRemovalEvent{OperatorId: 1}, false)
+	require.NoError(t, err)
+	_, found, err := collection.GetValidatorShare(share.PublicKey.Serialize())
+	require.NoError(t, err)
+	require.False(t, found)
+	od, found, err := operators.GetOperatorData(1)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, od.Removed)
+
+	// a replayed removal event of an operator that was already removed is skipped
+	require.NoError(t, collection.SaveValidatorShare(share))
+	logFields, err := ctr.handleOperatorRemovalEvent(abiparser.OperatorRemovalEvent{OperatorId: 1}, false)
+	require.NoError(t, err)
+	require.Nil(t, logFields)
+	_, found, err = collection.GetValidatorShare(share.PublicKey.Serialize())
+	require.NoError(t, err)
+	require.True(t, found)
+
+	// removed operators are not listed
+	listed, err := operators.ListOperators(0, 0)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, uint64(2), listed[0].Index)
+}
+
 func TestGetIndices(t *testing.T) {
 	validators := map[string]validator.IValidator{
 		"0": newValidator(&beacon.ValidatorMetadata{
@@ -131,6 +350,57 @@ func TestGetIndices(t *testing.T) {
 	require.Equal(t, 1, len(indices)) // should return only active indices
 }
 
+func TestListenToEth1Events_Resubscribe(t *testing.T) {
+	logger := logex.GetLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctr := setupController(logger, map[string]validator.IValidator{})
+	ctr.context = ctx
+
+	feed := new(event.Feed)
+	scopes := make(chan *event.SubscriptionScope, 2)
+	go ctr.ListenToEth1Events(func(ch chan<- *eth1.Event) event.Subscription {
+		scope := new(event.SubscriptionScope)
+		sub := scope.Track(feed.Subscribe(ch))
+		scopes <- scope
+		return sub
+	})
+
+	first := <-scopes
+	require.Equal(t, 1, feed.Send(&eth1.Event{Name: "unknown"}))
+	// closing the subscription should trigger a new one
+	first.Close()
+
+	select {
+	case <-scopes:
+	case <-time.After(eth1ResubscribeInterval * 3):
+		require.Fail(t, "expected to re-subscribe to eth1 events")
+	}
+	require.Equal(t, 1, feed.Send(&eth1.Event{Name: "unknown"}))
+}
+
+func TestMetadataUpdateDelay(t *testing.T) {
+	c := &controller{
+		metadataUpdateInterval: 12 * time.Minute,
+		metadataUpdateJitter:   time.Minute,
+	}
+	for i := 0; i < 100; i++ {
+		delay := c.metadataUpdateDelay()
+		require.GreaterOrEqual(t, delay, 12*time.Minute)
+		require.Less(t, delay, 13*time.Minute)
+	}
+
+	c.metadataUpdateJitter = 0
+	require.Equal(t, 12*time.Minute, c.metadataUpdateDelay())
+}
+
+func TestMetadataBatchSize(t *testing.T) {
+	c := &controller{}
+	require.Equal(t, metadataBatchSize, c.metadataBatchSize())
+	c.metadataUpdateBatchSize = 10
+	require.Equal(t, 10, c.metadataBatchSize())
+}
+
 func setupController(logger *zap.Logger, validators map[string]validator.IValidator) controller {
 	return controller{
 		context:                    context.Background(),
@@ -148,6 +418,7 @@ func setupController(logger *zap.Logger, validators map[string]validator.IValida
 		metadataUpdateQueue:    nil,
 		metadataUpdateInterval: 0,
 		messageRouter:          newMessageRouter(logger, genesis.New().MsgID()),
+		forkCoordinator:        newForkCoordinator(logger, forksprotocol.GenesisForkVersion),
 		messageWorker: worker.NewWorker(&worker.Config{
 			Ctx:          context.Background(),
 			Logger:       logger,
@@ -189,3 +460,29 @@ func generateChangeRoundMsg(t *testing.T, identifier spectypes.MessageID) []byte
 	require.NoError(t, err)
 	return res
 }
+
+func TestRoleSigTimeouts(t *testing.T) {
+	timeouts := roleSigTimeouts(logex.GetLogger(), map[string]time.Duration{
+		"SYNC_COMMITTEE": 12 * time.Second,
+		"proposer":       3 * time.Second,
+		"UNKNOWN":        time.Second,
+	})
+	require.Len(t, timeouts, 2)
+	require.Equal(t, 12*time.Second, timeouts[spectypes.BNRoleSyncCommittee])
+	require.Equal(t, 3*time.Second, timeouts[spectypes.BNRoleProposer])
+}
+
+func TestDefaultFeeRecipient(t *testing.T) {
+	require.Equal(t, common.Address{}, defaultFeeRecipient(logex.GetLogger(), ""))
+	require.Equal(t, common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+		defaultFeeRecipient(logex.GetLogger(), "0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"))
+}
+
+func TestRoleMinPeers(t *testing.T) {
+	minPeers := roleMinPeers(logex.GetLogger(), map[string]int{
+		"SYNC_COMMITTEE": 1,
+		"UNKNOWN":        3,
+	})
+	require.Len(t, minPeers, 1)
+	require.Equal(t, 1, minPeers[spectypes.BNRoleSyncCommittee])
+}
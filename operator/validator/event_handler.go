@@ -10,6 +10,7 @@ import (
 
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/abiparser"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 
 	"github.com/pkg/errors"
@@ -85,6 +86,11 @@ func (c *controller) handleOperatorRemovalEvent(
 			Err: errors.New("could not find operator data"),
 		}
 	}
+	// the operator is resolved by index, including removed operators, so a replayed removal event is skipped
+	if od.Removed {
+		c.logger.Debug("operator was already removed", zap.Uint64("operatorId", od.Index))
+		return nil, nil
+	}
 
 	// this check is deprecated, since the validation is happening on the contract side
 	//if od.OwnerAddress != event.OwnerAddress {
@@ -106,7 +112,16 @@ func (c *controller) handleOperatorRemovalEvent(
 	}
 
 	if !isOperatorEvent {
-		// TODO: remove this check when we will support operator removal for non-operator (mark as inactive)
+		affected, err := c.reevaluateCommittees(event.OperatorId)
+		if err != nil {
+			return nil, err
+		}
+		if len(affected) > 0 {
+			logFields = append(logFields, zap.Strings("affectedValidators", affected))
+		}
+		if err := c.storage.MarkOperatorRemoved(uint64(event.OperatorId)); err != nil {
+			return nil, errors.Wrap(err, "could not mark operator as removed")
+		}
 		return logFields, nil
 	}
 
@@ -126,14 +141,46 @@ func (c *controller) handleOperatorRemovalEvent(
 		}
 	}
 
-	err = c.storage.DeleteOperatorData(uint64(event.OperatorId))
-	if err != nil {
-		return nil, errors.Wrap(err, "could not delete operator data")
+	// operator data is kept so historical decided data could still be resolved
+	if err := c.storage.MarkOperatorRemoved(uint64(event.OperatorId)); err != nil {
+		return nil, errors.Wrap(err, "could not mark operator as removed")
 	}
 
 	return logFields, nil
 }
 
+// reevaluateCommittees checks the committees of the validators that include the removed operator,
+// it returns the public keys of the affected validators and warns on committees w/o a quorum of active operators
+func (c *controller) reevaluateCommittees(removedOperatorID uint32) ([]string, error) {
+	shares, err := c.collection.GetOperatorIDValidatorShares(removedOperatorID, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator shares of removed operator")
+	}
+	var affected []string
+	for _, share := range shares {
+		pk := share.PublicKey.SerializeToHexStr()
+		affected = append(affected, pk)
+		active := 0
+		for _, id := range share.OperatorIds {
+			if id == uint64(removedOperatorID) {
+				continue
+			}
+			od, found, err := c.storage.GetOperatorData(id)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get operator data")
+			}
+			if found && !od.Removed {
+				active++
+			}
+		}
+		if !share.HasQuorum(active) {
+			c.logger.Warn("validator committee lost the quorum of active operators",
+				zap.String("pubKey", pk), zap.Int("activeOperators", active))
+		}
+	}
+	return affected, nil
+}
+
 // handleValidatorRegistrationEvent handles registry contract event for validator added
 func (c *controller) handleValidatorRegistrationEvent(
 	validatorRegistrationEvent abiparser.ValidatorRegistrationEvent,
@@ -203,14 +250,8 @@ func (c *controller) handleValidatorRemovalEvent(
 	//	}
 	//}
 
-	// remove decided messages
-	messageID := spectypes.NewMsgID(validatorShare.PublicKey.Serialize(), spectypes.BNRoleAttester)
-	if err := c.ibftStorage.CleanAllDecided(messageID[:]); err != nil { // TODO need to delete for multi duty as well
-		return nil, errors.Wrap(err, "could not clean all decided messages")
-	}
-	// remove change round messages
-	if err := c.ibftStorage.CleanLastChangeRound(messageID[:]); err != nil { // TODO need to delete for multi duty as well
-		return nil, errors.Wrap(err, "could not clean last change round")
+	if err := cleanValidatorQBFTData(c.ibftStorage, validatorShare.PublicKey.Serialize()); err != nil {
+		return nil, err
 	}
 	// remove from storage
 	if err := c.collection.DeleteValidatorShare(validatorShare.PublicKey.Serialize()); err != nil {
@@ -325,3 +366,17 @@ func (c *controller) handleAccountEnableEvent(
 
 	return logFields, nil
 }
+
+// cleanValidatorQBFTData removes the decided messages and the last change round of the given validator
+func cleanValidatorQBFTData(ibftStorage qbftstorage.QBFTStore, pubKey []byte) error {
+	// remove decided messages
+	messageID := spectypes.NewMsgID(pubKey, spectypes.BNRoleAttester)
+	if err := ibftStorage.CleanAllDecided(messageID[:]); err != nil { // TODO need to delete for multi duty as well
+		return errors.Wrap(err, "could not clean all decided messages")
+	}
+	// remove change round messages
+	if err := ibftStorage.CleanLastChangeRound(messageID[:]); err != nil { // TODO need to delete for multi duty as well
+		return errors.Wrap(err, "could not clean last change round")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+)
+
+// forkCoordinator makes sure all the validators of the node transition to a new fork together.
+// message processing is paused during a transition, so messages are never processed while
+// only some of the validators were forked
+type forkCoordinator struct {
+	logger *zap.Logger
+	// lock is held for reading while processing messages, and for writing during a fork transition
+	lock    sync.RWMutex
+	version forksprotocol.ForkVersion
+}
+
+// newForkCoordinator creates a new fork coordinator, starting with the given version
+func newForkCoordinator(logger *zap.Logger, version forksprotocol.ForkVersion) *forkCoordinator {
+	return &forkCoordinator{
+		logger:  logger.With(zap.String("who", "forkCoordinator")),
+		version: version,
+	}
+}
+
+// process runs the given message handler, it blocks while a fork transition is in progress
+func (fc *forkCoordinator) process(handler func() error) error {
+	fc.lock.RLock()
+	defer fc.lock.RUnlock()
+
+	return handler()
+}
+
+// transition pauses message processing and runs the given fork function,
+// once it returns w/o an error the coordinator moves to the new version and message processing is resumed
+func (fc *forkCoordinator) transition(forkVersion forksprotocol.ForkVersion, fork func() error) error {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	if forkVersion == fc.version {
+		return nil
+	}
+	logger := fc.logger.With(zap.String("previousFork", string(fc.version)),
+		zap.String("currentFork", string(forkVersion)))
+	logger.Debug("starting fork transition, message processing is paused")
+
+	start := time.Now()
+	if err := fork(); err != nil {
+		reportForkTransition(forkVersion, false)
+		logger.Warn("could not complete fork transition", zap.Error(err))
+		return err
+	}
+	fc.version = forkVersion
+	reportForkTransition(forkVersion, true)
+	logger.Debug("fork transition completed", zap.Duration("took", time.Since(start)))
+	return nil
+}
@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+type forkingStorage struct {
+	qbftstorage.QBFTStore
+}
+
+func (s *forkingStorage) OnFork(forkVersion forksprotocol.ForkVersion) error {
+	return nil
+}
+
+// forkingValidator counts forks and records the amount of forked validators upon processing messages
+type forkingValidator struct {
+	validator.IValidator
+	forked    *int32
+	release   chan struct{}
+	err       error
+	processed chan int32
+}
+
+func (v *forkingValidator) OnFork(forkVersion forksprotocol.ForkVersion) error {
+	if v.err != nil {
+		return v.err
+	}
+	atomic.AddInt32(v.forked, 1)
+	<-v.release
+	return nil
+}
+
+func (v *forkingValidator) ProcessMsg(msg *spectypes.SSVMessage) error {
+	v.processed <- atomic.LoadInt32(v.forked)
+	return nil
+}
+
+func TestController_OnForkTransition(t *testing.T) {
+	const forkVersion = forksprotocol.ForkVersion("next")
+	const validatorsCount = 4
+	logger := logex.GetLogger()
+
+	var forked int32
+	release := make(chan struct{})
+	processed := make(chan int32, validatorsCount)
+	validators := make(map[string]validator.IValidator)
+	var pks [][]byte
+	for i := 0; i < validatorsCount; i++ {
+		// the message id holds a fixed size public key
+		pk := spectypes.NewMsgID([]byte(fmt.Sprintf("pk-%d", i)), spectypes.BNRoleAttester).GetPubKey()
+		pks = append(pks, pk)
+		validators[hex.EncodeToString(pk)] = &forkingValidator{
+			forked:    &forked,
+			release:   release,
+			processed: processed,
+		}
+	}
+	ctr := setupController(logger, validators)
+	ctr.validatorOptions = &validator.Options{IbftStorage: &forkingStorage{}}
+	go ctr.handleRouterMessages()
+
+	transitions := func(status string) float64 {
+		return testutil.ToFloat64(metricsForkTransitions.WithLabelValues(string(forkVersion), status))
+	}
+	completedBefore := transitions("completed")
+
+	forkErr := make(chan error, 1)
+	go func() {
+		forkErr <- ctr.OnFork(forkVersion)
+	}()
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&forked) == validatorsCount
+	}, time.Second, 5*time.Millisecond)
+
+	// messages are not processed during the transition
+	for _, pk := range pks {
+		ctr.messageRouter.Route(spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(pk, spectypes.BNRoleAttester),
+			Data:    []byte("data"),
+		})
+	}
+	select {
+	case <-processed:
+		require.Fail(t, "message was processed during fork transition")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-forkErr)
+	for i := 0; i < validatorsCount; i++ {
+		select {
+		case forkedOnProcess := <-processed:
+			require.Equal(t, int32(validatorsCount), forkedOnProcess)
+		case <-time.After(time.Second):
+			require.Fail(t, "message was not processed after fork transition")
+		}
+	}
+	require.Equal(t, forkVersion, ctr.forkVersion)
+	require.Equal(t, forkVersion, ctr.forkCoordinator.version)
+	require.Equal(t, completedBefore+1, transitions("completed"))
+
+	// forking again to the same version is a no-op
+	require.NoError(t, ctr.OnFork(forkVersion))
+	require.Equal(t, int32(validatorsCount), atomic.LoadInt32(&forked))
+	require.Equal(t, completedBefore+1, transitions("completed"))
+}
+
+func TestController_OnForkTransitionFailure(t *testing.T) {
+	const forkVersion = forksprotocol.ForkVersion("next-failure")
+	logger := logex.GetLogger()
+
+	var forked int32
+	release := make(chan struct{})
+	close(release)
+	ctr := setupController(logger, map[string]validator.IValidator{
+		"01": &forkingValidator{forked: &forked, release: release},
+		"02": &forkingValidator{forked: &forked, release: release, err: errors.New("test error")},
+	})
+	ctr.validatorOptions = &validator.Options{IbftStorage: &forkingStorage{}}
+	failedBefore := testutil.ToFloat64(metricsForkTransitions.WithLabelValues(string(forkVersion), "failed"))
+
+	require.EqualError(t, ctr.OnFork(forkVersion), "test error")
+	require.Equal(t, forksprotocol.GenesisForkVersion, ctr.forkCoordinator.version)
+	require.Equal(t, failedBefore+1, testutil.ToFloat64(metricsForkTransitions.WithLabelValues(string(forkVersion), "failed")))
+}
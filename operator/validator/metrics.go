@@ -1,6 +1,8 @@
 package validator
 
 import (
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -17,6 +19,25 @@ var (
 		Name: "ssv:validator:status",
 		Help: "Validator status",
 	}, []string{"pubKey"})
+	metricsValidatorsCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:validator:count",
+		Help: "Count of validators by status",
+	}, []string{"status"})
+	metricsForkTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:validator:fork_transitions",
+		Help: "Count fork transitions of all validators by version and status (completed / failed)",
+	}, []string{"version", "status"})
+	metricsRouterDrops = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:validator:router_drops",
+		Help: "Count messages that were dropped by the message router, by reason",
+	}, []string{"reason"})
+)
+
+// reasons of messages that are dropped by the router
+const (
+	dropReasonUnknownType    = "unknown_type"
+	dropReasonNoQueueForRole = "no_queue_for_role"
+	dropReasonFullQueue      = "full_queue"
 )
 
 func init() {
@@ -26,6 +47,15 @@ func init() {
 	if err := prometheus.Register(metricsValidatorStatus); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsValidatorsCount); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsForkTransitions); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsRouterDrops); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 // ReportValidatorStatus reports the current status of validator
@@ -59,6 +89,52 @@ func ReportValidatorStatus(pk string, meta *beacon.ValidatorMetadata, logger *za
 	}
 }
 
+// validatorsCountStatuses are the statuses that are reported by reportValidatorsCount
+var validatorsCountStatuses = []string{"active", "pending", "exiting", "slashed", "not_found", "unknown"}
+
+// reportValidatorsCount reports the amount of validators of each status, according to the given shares metadata
+func reportValidatorsCount(shares []*beacon.Share) {
+	counts := make(map[string]int, len(validatorsCountStatuses))
+	for _, share := range shares {
+		counts[validatorCountStatus(share.Metadata)]++
+	}
+	for _, status := range validatorsCountStatuses {
+		metricsValidatorsCount.WithLabelValues(status).Set(float64(counts[status]))
+	}
+}
+
+// validatorCountStatus returns the status label of the given metadata, only active validators are duty-eligible
+func validatorCountStatus(meta *beacon.ValidatorMetadata) string {
+	switch {
+	case meta == nil:
+		return "not_found"
+	case meta.IsActive():
+		return "active"
+	case meta.Slashed():
+		return "slashed"
+	case meta.Exiting(), meta.Status == v1.ValidatorStateActiveExiting:
+		return "exiting"
+	case meta.Pending():
+		return "pending"
+	default:
+		return "unknown"
+	}
+}
+
+// reportForkTransition reports a fork transition of all validators to the given version
+func reportForkTransition(forkVersion forksprotocol.ForkVersion, completed bool) {
+	status := "completed"
+	if !completed {
+		status = "failed"
+	}
+	metricsForkTransitions.WithLabelValues(string(forkVersion), status).Inc()
+}
+
+// reportRouterDrop reports a message that was dropped by the router
+func reportRouterDrop(reason string) {
+	metricsRouterDrops.WithLabelValues(reason).Inc()
+}
+
 type validatorStatus int32
 
 var (
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+)
+
+func TestReportValidatorsCount(t *testing.T) {
+	withStatus := func(status v1.ValidatorState) *beacon.Share {
+		return &beacon.Share{Metadata: &beacon.ValidatorMetadata{Status: status, Index: 1}}
+	}
+	reportValidatorsCount([]*beacon.Share{
+		withStatus(v1.ValidatorStateActiveOngoing),
+		withStatus(v1.ValidatorStateActiveOngoing),
+		withStatus(v1.ValidatorStatePendingQueued),
+		withStatus(v1.ValidatorStateActiveExiting),
+		withStatus(v1.ValidatorStateExitedUnslashed),
+		withStatus(v1.ValidatorStateExitedSlashed),
+		withStatus(v1.ValidatorStateUnknown),
+		{},
+	})
+
+	expected := map[string]float64{
+		"active":    2,
+		"pending":   1,
+		"exiting":   2,
+		"slashed":   1,
+		"not_found": 1,
+		"unknown":   1,
+	}
+	for status, count := range expected {
+		require.Equal(t, count, testutil.ToFloat64(metricsValidatorsCount.WithLabelValues(status)), status)
+	}
+
+	// statuses w/o validators are reset
+	reportValidatorsCount([]*beacon.Share{withStatus(v1.ValidatorStateActiveOngoing)})
+	require.Equal(t, float64(1), testutil.ToFloat64(metricsValidatorsCount.WithLabelValues("active")))
+	require.Equal(t, float64(0), testutil.ToFloat64(metricsValidatorsCount.WithLabelValues("pending")))
+}
@@ -10,8 +10,8 @@ import (
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
 	beacon "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	validator "github.com/bloxapp/ssv/protocol/v1/validator"
+	common "github.com/ethereum/go-ethereum/common"
 	gomock "github.com/golang/mock/gomock"
-	event "github.com/prysmaticlabs/prysm/async/event"
 	reflect "reflect"
 )
 
@@ -39,15 +39,15 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 }
 
 // ListenToEth1Events mocks base method
-func (m *MockController) ListenToEth1Events(feed *event.Feed) {
+func (m *MockController) ListenToEth1Events(subscribe eth1.EventsSubscriber) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "ListenToEth1Events", feed)
+	m.ctrl.Call(m, "ListenToEth1Events", subscribe)
 }
 
 // ListenToEth1Events indicates an expected call of ListenToEth1Events
-func (mr *MockControllerMockRecorder) ListenToEth1Events(feed interface{}) *gomock.Call {
+func (mr *MockControllerMockRecorder) ListenToEth1Events(subscribe interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenToEth1Events", reflect.TypeOf((*MockController)(nil).ListenToEth1Events), feed)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenToEth1Events", reflect.TypeOf((*MockController)(nil).ListenToEth1Events), subscribe)
 }
 
 // StartValidators mocks base method
@@ -76,6 +76,20 @@ func (mr *MockControllerMockRecorder) GetValidatorsIndices() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsIndices", reflect.TypeOf((*MockController)(nil).GetValidatorsIndices))
 }
 
+// GetFeeRecipients mocks base method
+func (m *MockController) GetFeeRecipients() map[phase0.ValidatorIndex]common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeRecipients")
+	ret0, _ := ret[0].(map[phase0.ValidatorIndex]common.Address)
+	return ret0
+}
+
+// GetFeeRecipients indicates an expected call of GetFeeRecipients
+func (mr *MockControllerMockRecorder) GetFeeRecipients() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRecipients", reflect.TypeOf((*MockController)(nil).GetFeeRecipients))
+}
+
 // GetValidator mocks base method
 func (m *MockController) GetValidator(pubKey string) (validator.IValidator, bool) {
 	m.ctrl.T.Helper()
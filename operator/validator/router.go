@@ -3,6 +3,7 @@ package validator
 import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/network/forks"
+	"github.com/bloxapp/ssv/protocol/v1/message"
 	"go.uber.org/zap"
 )
 
@@ -22,10 +23,16 @@ type messageRouter struct {
 	msgID  forks.MsgIDFunc
 }
 
-func (r *messageRouter) Route(message spectypes.SSVMessage) {
+func (r *messageRouter) Route(msg spectypes.SSVMessage) {
+	if len(message.MsgTypeToString(msg.MsgType)) == 0 {
+		reportRouterDrop(dropReasonUnknownType)
+		r.logger.Debug("unknown message type. dropping message", zap.Int("msgType", int(msg.MsgType)))
+		return
+	}
 	select {
-	case r.ch <- message:
+	case r.ch <- msg:
 	default:
+		reportRouterDrop(dropReasonFullQueue)
 		r.logger.Warn("message router buffer is full. dropping message")
 	}
 }
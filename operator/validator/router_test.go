@@ -5,6 +5,7 @@ import (
 	"fmt"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/network/forks/genesis"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"sync"
@@ -50,3 +51,24 @@ func TestRouter(t *testing.T) {
 
 	require.Equal(t, count, expectedCount)
 }
+
+func TestRouterDrops(t *testing.T) {
+	router := newMessageRouter(zap.L(), genesis.New().MsgID())
+	msgID := spectypes.NewMsgID([]byte{1, 1, 1, 1, 1}, spectypes.BNRoleAttester)
+
+	t.Run("unknown type", func(t *testing.T) {
+		drops := testutil.ToFloat64(metricsRouterDrops.WithLabelValues(dropReasonUnknownType))
+		router.Route(spectypes.SSVMessage{MsgType: spectypes.MsgType(100), MsgID: msgID, Data: []byte("data")})
+		require.Equal(t, drops+1, testutil.ToFloat64(metricsRouterDrops.WithLabelValues(dropReasonUnknownType)))
+		require.Len(t, router.GetMessageChan(), 0)
+	})
+
+	t.Run("full queue", func(t *testing.T) {
+		drops := testutil.ToFloat64(metricsRouterDrops.WithLabelValues(dropReasonFullQueue))
+		for i := 0; i < bufSize+1; i++ {
+			router.Route(spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, MsgID: msgID, Data: []byte(fmt.Sprintf("data-%d", i))})
+		}
+		require.Equal(t, drops+1, testutil.ToFloat64(metricsRouterDrops.WithLabelValues(dropReasonFullQueue)))
+		require.Len(t, router.GetMessageChan(), bufSize)
+	})
+}
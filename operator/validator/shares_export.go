@@ -0,0 +1,197 @@
+package validator
+
+import (
+	"crypto/rsa"
+	"strings"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/ekm"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+// SharesExport is a portable representation of the shares of an operator
+type SharesExport struct {
+	OperatorPubKey string           `json:"operatorPubKey"`
+	Shares         []*ExportedShare `json:"shares"`
+}
+
+// ExportedShare holds the serialized share (public metadata), the share key,
+// encrypted with the operator public key, and the slashing protection records of the share
+type ExportedShare struct {
+	PublicKey          string                  `json:"publicKey"`
+	Data               []byte                  `json:"data"`
+	EncryptedKey       string                  `json:"encryptedKey"`
+	SlashingProtection *ekm.SlashingProtection `json:"slashingProtection"`
+}
+
+// ExportShares exports all the shares of the operator from the given collection, share keys and slashing protection
+// records are taken from the key manager storage (db).
+// share keys are encrypted with the operator public key, in the same way they are emitted by the contract
+func ExportShares(collection validator.ICollection, db basedb.IDb, network beaconprotocol.Network, operatorPrivateKey *rsa.PrivateKey) (*SharesExport, error) {
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not extract operator public key")
+	}
+	shareKeys, err := ekm.ListShareKeys(db, network)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list share keys")
+	}
+	keys := make(map[string]*bls.SecretKey, len(shareKeys))
+	for _, sk := range shareKeys {
+		keys[sk.GetPublicKey().SerializeToHexStr()] = sk
+	}
+
+	shares, err := collection.GetOperatorValidatorShares(operatorPubKey, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get operator shares")
+	}
+	res := &SharesExport{
+		OperatorPubKey: operatorPubKey,
+		Shares:         make([]*ExportedShare, 0, len(shares)),
+	}
+	for _, share := range shares {
+		pubKey := share.PublicKey.SerializeToHexStr()
+		sharePubKey, err := share.OperatorSharePubKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get share public key of validator %s", pubKey)
+		}
+		sk, ok := keys[sharePubKey.SerializeToHexStr()]
+		if !ok {
+			return nil, errors.Errorf("could not find share key of validator %s", pubKey)
+		}
+		encryptedKey, err := rsaencryption.EncodeKey(&operatorPrivateKey.PublicKey, sk.SerializeToHexStr())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encrypt share key of validator %s", pubKey)
+		}
+		slashingProtection, err := ekm.GetSlashingProtection(db, network, sharePubKey.Serialize())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get slashing protection of validator %s", pubKey)
+		}
+		data, err := share.Serialize()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not serialize share of validator %s", pubKey)
+		}
+		res.Shares = append(res.Shares, &ExportedShare{
+			PublicKey:          pubKey,
+			Data:               data,
+			EncryptedKey:       encryptedKey,
+			SlashingProtection: slashingProtection,
+		})
+	}
+	return res, nil
+}
+
+// ImportShares saves the exported shares into the given collection, adds the share keys to the key manager
+// and restores their slashing protection records into the key manager storage (db).
+// the export must have been created with the same operator key, otherwise the share keys can't be decrypted.
+// all shares are validated before saving anything, if saving fails the shares that were added by the import are removed
+func ImportShares(
+	collection validator.ICollection,
+	keyManager spectypes.KeyManager,
+	db basedb.IDb,
+	network beaconprotocol.Network,
+	operatorPrivateKey *rsa.PrivateKey,
+	export *SharesExport,
+) ([]*beaconprotocol.Share, error) {
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not extract operator public key")
+	}
+	if export.OperatorPubKey != operatorPubKey {
+		return nil, errors.New("shares were exported with a different operator key")
+	}
+
+	// validating all shares before saving anything
+	shares := make([]*beaconprotocol.Share, 0, len(export.Shares))
+	keys := make([]*bls.SecretKey, 0, len(export.Shares))
+	for _, exported := range export.Shares {
+		share, sk, err := importedShare(exported, operatorPrivateKey, operatorPubKey)
+		if err != nil {
+			return nil, err
+		}
+		if exported.SlashingProtection == nil || len(exported.SlashingProtection.HighestAttestation) == 0 {
+			return nil, errors.Errorf("missing slashing protection of validator %s", exported.PublicKey)
+		}
+		shares = append(shares, share)
+		keys = append(keys, sk)
+	}
+
+	var added []int
+	rollback := func() {
+		for _, i := range added {
+			_ = keyManager.RemoveShare(keys[i].GetPublicKey().SerializeToHexStr())
+			_ = collection.DeleteValidatorShare(shares[i].PublicKey.Serialize())
+		}
+	}
+	for i, share := range shares {
+		pubKey := share.PublicKey.SerializeToHexStr()
+		_, exists, err := collection.GetValidatorShare(share.PublicKey.Serialize())
+		if err != nil {
+			rollback()
+			return nil, errors.Wrapf(err, "could not check share of validator %s", pubKey)
+		}
+		if !exists {
+			added = append(added, i)
+		}
+		if err := keyManager.AddShare(keys[i]); err != nil {
+			rollback()
+			return nil, errors.Wrapf(err, "could not add share key of validator %s", pubKey)
+		}
+		if err := ekm.RestoreSlashingProtection(db, network, keys[i].GetPublicKey().Serialize(), export.Shares[i].SlashingProtection); err != nil {
+			rollback()
+			return nil, errors.Wrapf(err, "could not restore slashing protection of validator %s", pubKey)
+		}
+		if err := collection.SaveValidatorShare(share); err != nil {
+			rollback()
+			return nil, errors.Wrapf(err, "could not save share of validator %s", pubKey)
+		}
+	}
+	return shares, nil
+}
+
+// importedShare rebuilds the exported share and validates it the same way shares are validated
+// when created from contract events: the operator must be part of the committee and the decrypted key
+// must match the committee share public key
+func importedShare(exported *ExportedShare, operatorPrivateKey *rsa.PrivateKey, operatorPubKey string) (*beaconprotocol.Share, *bls.SecretKey, error) {
+	pk := &bls.PublicKey{}
+	if err := pk.DeserializeHexStr(exported.PublicKey); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to deserialize share public key")
+	}
+	share, err := (&beaconprotocol.Share{}).Deserialize(pk.Serialize(), exported.Data)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not deserialize share of validator %s", exported.PublicKey)
+	}
+	if len(share.Operators) != len(share.OperatorIds) || len(share.Committee) != len(share.OperatorIds) {
+		return nil, nil, errors.Errorf("inconsistent committee of validator %s", exported.PublicKey)
+	}
+	found := false
+	for i, id := range share.OperatorIds {
+		if _, ok := share.Committee[spectypes.OperatorID(id)]; !ok {
+			return nil, nil, errors.Errorf("inconsistent committee of validator %s", exported.PublicKey)
+		}
+		if spectypes.OperatorID(id) == share.NodeID && strings.EqualFold(string(share.Operators[i]), operatorPubKey) {
+			found = true
+		}
+	}
+	if !found {
+		return nil, nil, errors.Errorf("validator %s is not managed by the operator", exported.PublicKey)
+	}
+	sk, err := decryptShareKey(operatorPrivateKey, exported.EncryptedKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not decrypt share key of validator %s", exported.PublicKey)
+	}
+	sharePubKey, err := share.OperatorSharePubKey()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not get share public key of validator %s", exported.PublicKey)
+	}
+	if !sharePubKey.IsEqual(sk.GetPublicKey()) {
+		return nil, nil, errors.Errorf("share key of validator %s doesn't match the committee", exported.PublicKey)
+	}
+	return share, sk, nil
+}
@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/types"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestSharesExportImport(t *testing.T) {
+	threshold.Init()
+	network := beacon.NewNetwork(core.PraterNetwork)
+
+	_, skPem, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	operatorKey, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+	require.NoError(t, err)
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorKey)
+	require.NoError(t, err)
+
+	srcDB, srcCollection := newTestCollection(t)
+	defer srcDB.Close()
+	srcKeyManager, err := ekm.NewETHKeyManagerSigner(srcDB, nil, network, types.GetDefaultDomain())
+	require.NoError(t, err)
+
+	var shares []*beacon.Share
+	for i := 0; i < 3; i++ {
+		share, shareKey := generateOperatorShare(operatorPubKey)
+		require.NoError(t, srcKeyManager.AddShare(shareKey))
+		require.NoError(t, srcCollection.SaveValidatorShare(share))
+		shares = append(shares, share)
+	}
+
+	shareKeys, err := ekm.ListShareKeys(srcDB, network)
+	require.NoError(t, err)
+	require.Len(t, shareKeys, 3)
+
+	// the first share already attested, its slashing protection must survive the migration
+	firstSharePubKey, err := shares[0].OperatorSharePubKey()
+	require.NoError(t, err)
+	highestAtt := &eth.AttestationData{
+		Slot:            320,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &eth.Checkpoint{Epoch: 9, Root: make([]byte, 32)},
+		Target:          &eth.Checkpoint{Epoch: 10, Root: make([]byte, 32)},
+	}
+	rawAtt, err := highestAtt.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, ekm.RestoreSlashingProtection(srcDB, network, firstSharePubKey.Serialize(), &ekm.SlashingProtection{HighestAttestation: rawAtt}))
+
+	export, err := ExportShares(srcCollection, srcDB, network, operatorKey)
+	require.NoError(t, err)
+	require.Len(t, export.Shares, 3)
+
+	// make sure the export survives the file format
+	raw, err := json.Marshal(export)
+	require.NoError(t, err)
+	var decoded SharesExport
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	t.Run("import with operator key", func(t *testing.T) {
+		dstDB, dstCollection := newTestCollection(t)
+		defer dstDB.Close()
+		dstKeyManager, err := ekm.NewETHKeyManagerSigner(dstDB, nil, network, types.GetDefaultDomain())
+		require.NoError(t, err)
+
+		imported, err := ImportShares(dstCollection, dstKeyManager, dstDB, network, operatorKey, &decoded)
+		require.NoError(t, err)
+		require.Len(t, imported, 3)
+
+		sp, err := ekm.GetSlashingProtection(dstDB, network, firstSharePubKey.Serialize())
+		require.NoError(t, err)
+		require.Equal(t, rawAtt, sp.HighestAttestation)
+
+		for _, share := range shares {
+			stored, found, err := dstCollection.GetValidatorShare(share.PublicKey.Serialize())
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, share.NodeID, stored.NodeID)
+			require.Equal(t, share.OwnerAddress, stored.OwnerAddress)
+			require.Equal(t, share.Committee[share.NodeID].Pk, stored.Committee[share.NodeID].Pk)
+		}
+		dstKeys, err := ekm.ListShareKeys(dstDB, network)
+		require.NoError(t, err)
+		require.Len(t, dstKeys, 3)
+	})
+
+	t.Run("import with different operator key", func(t *testing.T) {
+		dstDB, dstCollection := newTestCollection(t)
+		defer dstDB.Close()
+		dstKeyManager, err := ekm.NewETHKeyManagerSigner(dstDB, nil, network, types.GetDefaultDomain())
+		require.NoError(t, err)
+
+		_, otherSkPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		otherKey, err := rsaencryption.ConvertPemToPrivateKey(string(otherSkPem))
+		require.NoError(t, err)
+
+		_, err = ImportShares(dstCollection, dstKeyManager, dstDB, network, otherKey, &decoded)
+		require.EqualError(t, err, "shares were exported with a different operator key")
+		all, err := dstCollection.GetAllValidatorShares()
+		require.NoError(t, err)
+		require.Len(t, all, 0)
+	})
+
+	t.Run("import without slashing protection", func(t *testing.T) {
+		dstDB, dstCollection := newTestCollection(t)
+		defer dstDB.Close()
+		dstKeyManager, err := ekm.NewETHKeyManagerSigner(dstDB, nil, network, types.GetDefaultDomain())
+		require.NoError(t, err)
+
+		var noProtection SharesExport
+		require.NoError(t, json.Unmarshal(raw, &noProtection))
+		noProtection.Shares[1].SlashingProtection = nil
+
+		_, err = ImportShares(dstCollection, dstKeyManager, dstDB, network, operatorKey, &noProtection)
+		require.EqualError(t, err, "missing slashing protection of validator "+noProtection.Shares[1].PublicKey)
+		all, err := dstCollection.GetAllValidatorShares()
+		require.NoError(t, err)
+		require.Len(t, all, 0)
+	})
+}
+
+func newTestCollection(t *testing.T) (basedb.IDb, *Collection) {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	return db, NewCollection(CollectionOptions{DB: db, Logger: zap.L()}).(*Collection)
+}
+
+func generateOperatorShare(operatorPubKey string) (*beacon.Share, *bls.SecretKey) {
+	validatorKey := &bls.SecretKey{}
+	validatorKey.SetByCSPRNG()
+	shareKey := &bls.SecretKey{}
+	shareKey.SetByCSPRNG()
+
+	committee := map[spectypes.OperatorID]*beacon.Node{
+		1: {IbftID: 1, Pk: shareKey.GetPublicKey().Serialize()},
+	}
+	operators := [][]byte{[]byte(operatorPubKey)}
+	for i := uint64(2); i <= 4; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		committee[spectypes.OperatorID(i)] = &beacon.Node{IbftID: i, Pk: sk.GetPublicKey().Serialize()}
+		operators = append(operators, []byte("operator"))
+	}
+
+	return &beacon.Share{
+		NodeID:       1,
+		PublicKey:    validatorKey.GetPublicKey(),
+		Committee:    committee,
+		OwnerAddress: "0xFeedB14D8b2C76FdF808C29818b06b830E8C2c0e",
+		Operators:    operators,
+		OperatorIds:  []uint64{1, 2, 3, 4},
+	}, shareKey
+}
@@ -0,0 +1,248 @@
+package validator
+
+import (
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+// SharesDiff is the difference between the stored shares and the shares according to the contract
+type SharesDiff struct {
+	// Missing are shares that exist in the contract but not in storage
+	Missing []string
+	// Extra are shares that exist in storage but not in the contract
+	Extra []string
+	// LiquidatedMismatch are shares with a different liquidation status in storage and in the contract
+	LiquidatedMismatch []string
+}
+
+// Empty returns true if there is no difference
+func (d *SharesDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.LiquidatedMismatch) == 0
+}
+
+type reconciledShare struct {
+	event      abiparser.ValidatorRegistrationEvent
+	liquidated bool
+}
+
+// SharesReconciler builds a temporary view of the operator shares out of contract events,
+// w/o mutating the node storage. the view is then compared with the stored shares
+type SharesReconciler struct {
+	logger         *zap.Logger
+	operatorPubKey string
+	// operators is a temporary collection, used only to resolve operator public keys
+	operators  registrystorage.OperatorsCollection
+	shares     map[string]*reconciledShare
+	syncOffset *eth1.SyncOffset
+}
+
+// NewSharesReconciler creates a new reconciler, the given operators collection must be a temporary one
+func NewSharesReconciler(logger *zap.Logger, operatorPubKey string, operators registrystorage.OperatorsCollection) *SharesReconciler {
+	return &SharesReconciler{
+		logger:         logger.With(zap.String("who", "sharesReconciler")),
+		operatorPubKey: operatorPubKey,
+		operators:      operators,
+		shares:         make(map[string]*reconciledShare),
+	}
+}
+
+// SaveSyncOffset implements eth1.SyncOffsetStorage, the offset is kept in memory only
+func (r *SharesReconciler) SaveSyncOffset(offset *eth1.SyncOffset) error {
+	r.syncOffset = offset
+	return nil
+}
+
+// GetSyncOffset implements eth1.SyncOffsetStorage
+func (r *SharesReconciler) GetSyncOffset() (*eth1.SyncOffset, bool, error) {
+	return r.syncOffset, r.syncOffset != nil, nil
+}
+
+// Sync re-syncs the contract events into the temporary view
+func (r *SharesReconciler) Sync(client eth1.Client, syncOffset *eth1.SyncOffset) error {
+	return eth1.SyncEth1Events(r.logger, client, r, syncOffset, r.EventHandler())
+}
+
+// EventHandler returns a dry-run handler that applies events on the temporary view
+func (r *SharesReconciler) EventHandler() eth1.SyncEventHandler {
+	return func(e eth1.Event) ([]zap.Field, error) {
+		switch e.Name {
+		case abiparser.OperatorRegistration:
+			ev := e.Data.(abiparser.OperatorRegistrationEvent)
+			return nil, r.operators.SaveOperatorData(&registrystorage.OperatorData{
+				PublicKey:    string(ev.PublicKey),
+				Name:         ev.Name,
+				OwnerAddress: ev.OwnerAddress,
+				Index:        uint64(ev.Id),
+			})
+		case abiparser.OperatorRemoval:
+			ev := e.Data.(abiparser.OperatorRemovalEvent)
+			return nil, r.onOperatorRemoval(ev)
+		case abiparser.ValidatorRegistration:
+			ev := e.Data.(abiparser.ValidatorRegistrationEvent)
+			if err := SetOperatorPublicKeys(r.operators, &ev); err != nil {
+				return nil, errors.Wrap(err, "could not set operator public keys")
+			}
+			for _, pk := range ev.OperatorPublicKeys {
+				if strings.EqualFold(string(pk), r.operatorPubKey) {
+					r.shares[hex.EncodeToString(ev.PublicKey)] = &reconciledShare{event: ev}
+					break
+				}
+			}
+		case abiparser.ValidatorRemoval:
+			ev := e.Data.(abiparser.ValidatorRemovalEvent)
+			delete(r.shares, hex.EncodeToString(ev.PublicKey))
+		case abiparser.AccountLiquidation:
+			ev := e.Data.(abiparser.AccountLiquidationEvent)
+			r.setLiquidated(ev.OwnerAddress.String(), true)
+		case abiparser.AccountEnable:
+			ev := e.Data.(abiparser.AccountEnableEvent)
+			r.setLiquidated(ev.OwnerAddress.String(), false)
+		}
+		return nil, nil
+	}
+}
+
+func (r *SharesReconciler) onOperatorRemoval(ev abiparser.OperatorRemovalEvent) error {
+	od, found, err := r.operators.GetOperatorData(uint64(ev.OperatorId))
+	if err != nil {
+		return errors.Wrap(err, "could not get operator data")
+	}
+	if !found {
+		return &abiparser.MalformedEventError{
+			Err: errors.New("could not find operator data"),
+		}
+	}
+	// same as the node, operator removal is applied only for the current operator
+	if !strings.EqualFold(od.PublicKey, r.operatorPubKey) {
+		return nil
+	}
+	for pk, s := range r.shares {
+		for _, id := range s.event.OperatorIds {
+			if id == ev.OperatorId {
+				delete(r.shares, pk)
+				break
+			}
+		}
+	}
+	return r.operators.DeleteOperatorData(uint64(ev.OperatorId))
+}
+
+func (r *SharesReconciler) setLiquidated(ownerAddress string, liquidated bool) {
+	for _, s := range r.shares {
+		if strings.EqualFold(s.event.OwnerAddress.String(), ownerAddress) {
+			s.liquidated = liquidated
+		}
+	}
+}
+
+// Diff compares the temporary view with the shares in the given collection
+func (r *SharesReconciler) Diff(collection validator.ICollection) (*SharesDiff, error) {
+	stored, err := collection.GetOperatorValidatorShares(r.operatorPubKey, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get operator shares")
+	}
+	diff := &SharesDiff{}
+	storedKeys := make(map[string]bool, len(stored))
+	for _, share := range stored {
+		pk := share.PublicKey.SerializeToHexStr()
+		storedKeys[pk] = true
+		s, ok := r.shares[pk]
+		if !ok {
+			diff.Extra = append(diff.Extra, pk)
+			continue
+		}
+		if s.liquidated != share.Liquidated {
+			diff.LiquidatedMismatch = append(diff.LiquidatedMismatch, pk)
+		}
+	}
+	for pk := range r.shares {
+		if !storedKeys[pk] {
+			diff.Missing = append(diff.Missing, pk)
+		}
+	}
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Extra)
+	sort.Strings(diff.LiquidatedMismatch)
+	return diff, nil
+}
+
+// Apply fixes the stored shares according to the given diff, extra shares are removed
+// in the same way as on validator removal event
+func (r *SharesReconciler) Apply(
+	diff *SharesDiff,
+	collection validator.ICollection,
+	ibftStorage qbftstorage.QBFTStore,
+	keyManager spectypes.KeyManager,
+	shareEncryptionKeyProvider ShareEncryptionKeyProvider,
+) error {
+	for _, pk := range diff.Missing {
+		s := r.shares[pk]
+		share, shareSecret, err := ShareFromValidatorEvent(s.event, r.operators, shareEncryptionKeyProvider, r.operatorPubKey)
+		if err != nil {
+			return errors.Wrapf(err, "could not create share of validator %s", pk)
+		}
+		if shareSecret == nil {
+			return errors.Errorf("could not decode share secret of validator %s", pk)
+		}
+		if err := keyManager.AddShare(shareSecret); err != nil {
+			return errors.Wrapf(err, "could not add share secret of validator %s", pk)
+		}
+		share.Liquidated = s.liquidated
+		if err := collection.SaveValidatorShare(share); err != nil {
+			return errors.Wrapf(err, "could not save share of validator %s", pk)
+		}
+	}
+	for _, pk := range diff.Extra {
+		key, err := hex.DecodeString(pk)
+		if err != nil {
+			return errors.Wrap(err, "could not decode validator public key")
+		}
+		share, found, err := collection.GetValidatorShare(key)
+		if err != nil {
+			return errors.Wrapf(err, "could not get share of validator %s", pk)
+		}
+		if !found {
+			return errors.Errorf("could not find share of validator %s", pk)
+		}
+		if sharePubKey, err := share.OperatorSharePubKey(); err == nil {
+			if err := keyManager.RemoveShare(sharePubKey.SerializeToHexStr()); err != nil {
+				return errors.Wrapf(err, "could not remove share secret of validator %s", pk)
+			}
+		}
+		if err := cleanValidatorQBFTData(ibftStorage, key); err != nil {
+			return errors.Wrapf(err, "could not clean qbft data of validator %s", pk)
+		}
+		if err := collection.DeleteValidatorShare(key); err != nil {
+			return errors.Wrapf(err, "could not remove share of validator %s", pk)
+		}
+	}
+	for _, pk := range diff.LiquidatedMismatch {
+		key, err := hex.DecodeString(pk)
+		if err != nil {
+			return errors.Wrap(err, "could not decode validator public key")
+		}
+		share, found, err := collection.GetValidatorShare(key)
+		if err != nil {
+			return errors.Wrapf(err, "could not get share of validator %s", pk)
+		}
+		if !found {
+			return errors.Errorf("could not find share of validator %s", pk)
+		}
+		share.Liquidated = r.shares[pk].liquidated
+		if err := collection.SaveValidatorShare(share); err != nil {
+			return errors.Wrapf(err, "could not save share of validator %s", pk)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package validator
+
+import (
+	"crypto/rsa"
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestSharesReconciler(t *testing.T) {
+	threshold.Init()
+	network := beacon.NewNetwork(core.PraterNetwork)
+
+	_, skPem, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	operatorKey, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+	require.NoError(t, err)
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorKey)
+	require.NoError(t, err)
+
+	db, collection := newTestCollection(t)
+	defer db.Close()
+	keyManager, err := ekm.NewETHKeyManagerSigner(db, nil, network, types.GetDefaultDomain())
+	require.NoError(t, err)
+
+	// consistent share, but liquidated only in the contract
+	liquidated, liquidatedKey := generateOperatorShare(operatorPubKey)
+	require.NoError(t, keyManager.AddShare(liquidatedKey))
+	require.NoError(t, collection.SaveValidatorShare(liquidated))
+	// share that exists only in the contract
+	missing, missingKey := generateOperatorShare(operatorPubKey)
+	missing.OwnerAddress = "0x2B2C8dD5d1fE6cC3Bc1d9b3F0aC2C8d6B3b0E0aa"
+	// share that exists only in storage
+	extra, extraKey := generateOperatorShare(operatorPubKey)
+	require.NoError(t, keyManager.AddShare(extraKey))
+	require.NoError(t, collection.SaveValidatorShare(extra))
+
+	tmpDB, _ := newTestCollection(t)
+	defer tmpDB.Close()
+	reconciler := NewSharesReconciler(zap.L(), operatorPubKey, registrystorage.NewOperatorsStorage(tmpDB, zap.L(), []byte("operator")))
+	handler := reconciler.EventHandler()
+
+	events := []eth1.Event{
+		{Name: abiparser.OperatorRegistration, Data: abiparser.OperatorRegistrationEvent{Id: 1, Name: "ours", PublicKey: []byte(operatorPubKey)}},
+	}
+	for i := uint32(2); i <= 4; i++ {
+		events = append(events, eth1.Event{Name: abiparser.OperatorRegistration, Data: abiparser.OperatorRegistrationEvent{Id: i, PublicKey: []byte("operator")}})
+	}
+	events = append(events,
+		eth1.Event{Name: abiparser.ValidatorRegistration, Data: validatorRegistrationEvent(t, &operatorKey.PublicKey, liquidated, liquidatedKey)},
+		eth1.Event{Name: abiparser.ValidatorRegistration, Data: validatorRegistrationEvent(t, &operatorKey.PublicKey, missing, missingKey)},
+		eth1.Event{Name: abiparser.ValidatorRegistration, Data: validatorRegistrationEvent(t, &operatorKey.PublicKey, extra, extraKey)},
+		eth1.Event{Name: abiparser.ValidatorRemoval, Data: abiparser.ValidatorRemovalEvent{PublicKey: extra.PublicKey.Serialize()}},
+		eth1.Event{Name: abiparser.AccountLiquidation, Data: abiparser.AccountLiquidationEvent{OwnerAddress: common.HexToAddress(liquidated.OwnerAddress)}},
+	)
+	for _, e := range events {
+		_, err := handler(e)
+		require.NoError(t, err)
+	}
+
+	diff, err := reconciler.Diff(collection)
+	require.NoError(t, err)
+	require.Equal(t, []string{missing.PublicKey.SerializeToHexStr()}, diff.Missing)
+	require.Equal(t, []string{extra.PublicKey.SerializeToHexStr()}, diff.Extra)
+	require.Equal(t, []string{liquidated.PublicKey.SerializeToHexStr()}, diff.LiquidatedMismatch)
+
+	// diff is a dry run
+	all, err := collection.GetAllValidatorShares()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	// the extra share has qbft data that should be cleaned
+	ibftStorage := ibftstorage.New(db, zap.L(), spectypes.BNRoleAttester.String(), forksprotocol.GenesisForkVersion)
+	extraMsgID := spectypes.NewMsgID(extra.PublicKey.Serialize(), spectypes.BNRoleAttester)
+	require.NoError(t, ibftStorage.SaveLastDecided(&specqbft.SignedMessage{
+		Signature: []byte("sig"),
+		Signers:   []spectypes.OperatorID{1},
+		Message: &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     1,
+			Round:      1,
+			Identifier: extraMsgID[:],
+		},
+	}))
+
+	operatorKeyProvider := func() (*rsa.PrivateKey, bool, error) {
+		return operatorKey, true, nil
+	}
+	require.NoError(t, reconciler.Apply(diff, collection, ibftStorage, keyManager, operatorKeyProvider))
+
+	diff, err = reconciler.Diff(collection)
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+
+	stored, found, err := collection.GetValidatorShare(liquidated.PublicKey.Serialize())
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, stored.Liquidated)
+	_, found, err = collection.GetValidatorShare(extra.PublicKey.Serialize())
+	require.NoError(t, err)
+	require.False(t, found)
+	decided, err := ibftStorage.GetLastDecided(extraMsgID[:])
+	require.NoError(t, err)
+	require.Nil(t, decided)
+
+	shareKeys, err := ekm.ListShareKeys(db, network)
+	require.NoError(t, err)
+	require.Len(t, shareKeys, 2)
+}
+
+func validatorRegistrationEvent(t *testing.T, operatorPubKey *rsa.PublicKey, share *beacon.Share, shareKey *bls.SecretKey) abiparser.ValidatorRegistrationEvent {
+	encryptedKey, err := rsaencryption.EncodeKey(operatorPubKey, shareKey.SerializeToHexStr())
+	require.NoError(t, err)
+
+	ev := abiparser.ValidatorRegistrationEvent{
+		PublicKey:    share.PublicKey.Serialize(),
+		OwnerAddress: common.HexToAddress(share.OwnerAddress),
+	}
+	for _, id := range share.OperatorIds {
+		ev.OperatorIds = append(ev.OperatorIds, uint32(id))
+		ev.SharesPublicKeys = append(ev.SharesPublicKeys, share.Committee[spectypes.OperatorID(id)].Pk)
+		ev.EncryptedKeys = append(ev.EncryptedKeys, []byte(encryptedKey))
+	}
+	return ev
+}
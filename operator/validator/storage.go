@@ -10,6 +10,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/validator"
 	"github.com/bloxapp/ssv/storage/basedb"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -31,6 +32,11 @@ func collectionPrefix() []byte {
 	return []byte("share-")
 }
 
+// SharesPrefix returns the storage prefix of the shares collection
+func SharesPrefix() []byte {
+	return collectionPrefix()
+}
+
 // CollectionOptions struct
 type CollectionOptions struct {
 	DB     basedb.IDb
@@ -220,3 +226,35 @@ func (s *Collection) UpdateValidatorMetadata(pk string, metadata *beaconprotocol
 	share.Metadata = metadata
 	return s.saveUnsafe(share)
 }
+
+// SetValidatorPaused updates the paused flag of the given validator
+func (s *Collection) SetValidatorPaused(key []byte, paused bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	share, found, err := s.getUnsafe(key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("could not find validator share %s", hex.EncodeToString(key))
+	}
+	share.Paused = paused
+	return s.saveUnsafe(share)
+}
+
+// SetValidatorFeeRecipient updates the fee recipient of the given validator, zero address removes the override
+func (s *Collection) SetValidatorFeeRecipient(key []byte, feeRecipient common.Address) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	share, found, err := s.getUnsafe(key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("could not find validator share %s", hex.EncodeToString(key))
+	}
+	share.FeeRecipient = feeRecipient
+	return s.saveUnsafe(share)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -87,6 +88,68 @@ func TestSaveAndGetValidatorStorage(t *testing.T) {
 	require.False(t, found)
 }
 
+func TestSetValidatorPaused(t *testing.T) {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	collection := NewCollection(CollectionOptions{DB: db, Logger: zap.L()})
+
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	splitKeys, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+
+	validatorShare, _ := generateRandomValidatorShare(splitKeys)
+	require.NoError(t, collection.SaveValidatorShare(validatorShare))
+	key := validatorShare.PublicKey.Serialize()
+
+	require.NoError(t, collection.SetValidatorPaused(key, true))
+	share, found, err := collection.GetValidatorShare(key)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, share.Paused)
+
+	require.NoError(t, collection.SetValidatorPaused(key, false))
+	share, _, err = collection.GetValidatorShare(key)
+	require.NoError(t, err)
+	require.False(t, share.Paused)
+
+	require.Error(t, collection.SetValidatorPaused([]byte("unknown"), true))
+}
+
+func TestSetValidatorFeeRecipient(t *testing.T) {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	collection := NewCollection(CollectionOptions{DB: db, Logger: zap.L()})
+
+	threshold.Init()
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	splitKeys, err := threshold.Create(sk.Serialize(), 3, 4)
+	require.NoError(t, err)
+
+	validatorShare, _ := generateRandomValidatorShare(splitKeys)
+	require.NoError(t, collection.SaveValidatorShare(validatorShare))
+	key := validatorShare.PublicKey.Serialize()
+
+	feeRecipient := common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	require.NoError(t, collection.SetValidatorFeeRecipient(key, feeRecipient))
+	share, found, err := collection.GetValidatorShare(key)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, feeRecipient, share.FeeRecipient)
+
+	require.Error(t, collection.SetValidatorFeeRecipient([]byte("unknown"), feeRecipient))
+}
+
 func generateRandomValidatorShare(splitKeys map[uint64]*bls.SecretKey) (*beacon.Share, *bls.SecretKey) {
 	threshold.Init()
 	sk := bls.SecretKey{}
@@ -1,11 +1,15 @@
 package validator
 
 import (
+	"crypto/rsa"
 	"strings"
+	"time"
 
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/eth1/abiparser"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
@@ -72,18 +76,9 @@ func ShareFromValidatorEvent(
 				return nil, nil, errors.New("could not find operator private key")
 			}
 
-			shareSecret = &bls.SecretKey{}
-			decryptedSharePrivateKey, err := rsaencryption.DecodeKey(operatorPrivateKey, string(validatorRegistrationEvent.EncryptedKeys[i]))
+			shareSecret, err = decryptShareKey(operatorPrivateKey, string(validatorRegistrationEvent.EncryptedKeys[i]))
 			if err != nil {
-				return nil, nil, &abiparser.MalformedEventError{
-					Err: errors.Wrap(err, "failed to decrypt share private key"),
-				}
-			}
-			decryptedSharePrivateKey = strings.Replace(decryptedSharePrivateKey, "0x", "", 1)
-			if err := shareSecret.SetHexString(decryptedSharePrivateKey); err != nil {
-				return nil, nil, &abiparser.MalformedEventError{
-					Err: errors.Wrap(err, "failed to set decrypted share private key"),
-				}
+				return nil, nil, &abiparser.MalformedEventError{Err: err}
 			}
 		}
 	}
@@ -114,3 +109,74 @@ func SetOperatorPublicKeys(
 	}
 	return nil
 }
+
+// decryptShareKey decrypts the given (base64) encrypted share key with the operator private key
+func decryptShareKey(operatorPrivateKey *rsa.PrivateKey, encryptedKey string) (*bls.SecretKey, error) {
+	decryptedSharePrivateKey, err := rsaencryption.DecodeKey(operatorPrivateKey, encryptedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt share private key")
+	}
+	decryptedSharePrivateKey = strings.Replace(decryptedSharePrivateKey, "0x", "", 1)
+	shareSecret := &bls.SecretKey{}
+	if err := shareSecret.SetHexString(decryptedSharePrivateKey); err != nil {
+		return nil, errors.Wrap(err, "failed to set decrypted share private key")
+	}
+	return shareSecret, nil
+}
+
+// BeaconRoleByName returns the beacon role of the given (case insensitive) name
+func BeaconRoleByName(name string) (spectypes.BeaconRole, bool) {
+	roles := []spectypes.BeaconRole{
+		spectypes.BNRoleAttester,
+		spectypes.BNRoleAggregator,
+		spectypes.BNRoleProposer,
+		spectypes.BNRoleSyncCommittee,
+		spectypes.BNRoleSyncCommitteeContribution,
+	}
+	for _, role := range roles {
+		if strings.EqualFold(role.String(), name) {
+			return role, true
+		}
+	}
+	return 0, false
+}
+
+// roleSigTimeouts maps the configured role names to beacon roles, unknown roles are ignored
+func roleSigTimeouts(logger *zap.Logger, timeouts map[string]time.Duration) map[spectypes.BeaconRole]time.Duration {
+	res := make(map[spectypes.BeaconRole]time.Duration, len(timeouts))
+	for name, timeout := range timeouts {
+		role, ok := BeaconRoleByName(name)
+		if !ok {
+			logger.Warn("ignoring signature collection timeout of unknown role", zap.String("role", name))
+			continue
+		}
+		res[role] = timeout
+	}
+	return res
+}
+
+// roleMinPeers maps the configured role names to beacon roles, unknown roles are ignored
+func roleMinPeers(logger *zap.Logger, minPeers map[string]int) map[spectypes.BeaconRole]int {
+	res := make(map[spectypes.BeaconRole]int, len(minPeers))
+	for name, min := range minPeers {
+		role, ok := BeaconRoleByName(name)
+		if !ok {
+			logger.Warn("ignoring minimum peers of unknown role", zap.String("role", name))
+			continue
+		}
+		res[role] = min
+	}
+	return res
+}
+
+// defaultFeeRecipient parses the configured default fee recipient, an empty value results in the zero address.
+// an invalid address is fatal, otherwise block proposals would silently pay a wrong address
+func defaultFeeRecipient(logger *zap.Logger, feeRecipient string) common.Address {
+	if len(feeRecipient) == 0 {
+		return common.Address{}
+	}
+	if !common.IsHexAddress(feeRecipient) {
+		logger.Fatal("invalid default fee recipient", zap.String("feeRecipient", feeRecipient))
+	}
+	return common.HexToAddress(feeRecipient)
+}
@@ -1,6 +1,7 @@
 package forksprotocol
 
 import (
+	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 )
 
@@ -18,6 +19,19 @@ const (
 	GenesisForkVersion ForkVersion = "genesis"
 )
 
+// ErrUnknownForkVersion is returned for fork versions that are not supported
+var ErrUnknownForkVersion = errors.New("unknown fork version")
+
+// ValidateForkVersion checks that the given fork version is supported
+func ValidateForkVersion(forkVersion ForkVersion) error {
+	switch forkVersion {
+	case GenesisForkVersion:
+		return nil
+	default:
+		return errors.Wrapf(ErrUnknownForkVersion, "fork version %q", forkVersion)
+	}
+}
+
 // ForkHandler handles a fork event
 type ForkHandler interface {
 	// OnFork is called upon a ForkVersion change
@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"context"
+	"time"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -31,6 +32,9 @@ type Beacon interface {
 
 	// SubscribeToCommitteeSubnet subscribe committee to subnet (p2p topic)
 	SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error
+
+	// SubmitProposalPreparation registers the fee recipients of the given validators ahead of proposals
+	SubmitProposalPreparation(preparations []*api.ProposalPreparation) error
 }
 
 // SigningUtil is an interface for beacon node signing specific methods
@@ -44,7 +48,15 @@ type Options struct {
 	Context        context.Context
 	Logger         *zap.Logger
 	Network        string `yaml:"Network" env:"NETWORK" env-default:"prater"`
-	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
+	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"Comma-separated beacon node addresses, the first one is the primary and the rest are used for failover"`
 	Graffiti       []byte
 	DB             basedb.IDb
+	// RequestTimeout is the timeout of a single request to the beacon node
+	RequestTimeout time.Duration `yaml:"RequestTimeout" env:"BEACON_REQUEST_TIMEOUT" env-default:"5s"`
+	// RequestRetries is the amount of retries of a failed request to the beacon node
+	RequestRetries int `yaml:"RequestRetries" env:"BEACON_REQUEST_RETRIES" env-default:"2"`
+	// GraffitiWithVersion appends the node version to the graffiti
+	GraffitiWithVersion bool `yaml:"GraffitiWithVersion" env:"GRAFFITI_WITH_VERSION" env-description:"Whether to append the node version to the graffiti"`
+	// EpochsPerSyncCommitteePeriod overrides the sync committee period of custom networks
+	EpochsPerSyncCommitteePeriod uint64 `yaml:"EpochsPerSyncCommitteePeriod" env:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD" env-default:"256"`
 }
@@ -199,6 +199,20 @@ func (mr *MockBeaconMockRecorder) SubscribeToCommitteeSubnet(subscription interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToCommitteeSubnet", reflect.TypeOf((*MockBeacon)(nil).SubscribeToCommitteeSubnet), subscription)
 }
 
+// SubmitProposalPreparation mocks base method
+func (m *MockBeacon) SubmitProposalPreparation(preparations []*v1.ProposalPreparation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitProposalPreparation", preparations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitProposalPreparation indicates an expected call of SubmitProposalPreparation
+func (mr *MockBeaconMockRecorder) SubmitProposalPreparation(preparations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitProposalPreparation", reflect.TypeOf((*MockBeacon)(nil).SubmitProposalPreparation), preparations)
+}
+
 // MockKeyManager is a mock of KeyManager interface
 type MockKeyManager struct {
 	ctrl     *gomock.Controller
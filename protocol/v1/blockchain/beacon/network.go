@@ -5,14 +5,19 @@ import (
 	"time"
 )
 
+// DefaultEpochsPerSyncCommitteePeriod is the mainnet value of EPOCHS_PER_SYNC_COMMITTEE_PERIOD
+const DefaultEpochsPerSyncCommitteePeriod = 256
+
 // Network is a beacon chain network.
 type Network struct {
 	core.Network
+	// EpochsPerSyncCommitteePeriod is the number of epochs in a sync committee period
+	EpochsPerSyncCommitteePeriod uint64
 }
 
 // NewNetwork creates a new beacon chain network.
 func NewNetwork(net core.Network) Network {
-	return Network{net}
+	return Network{net, DefaultEpochsPerSyncCommitteePeriod}
 }
 
 // GetSlotStartTime returns the start time for the given slot
@@ -21,3 +26,13 @@ func (n *Network) GetSlotStartTime(slot uint64) time.Time {
 	start := time.Unix(int64(n.MinGenesisTime()+timeSinceGenesisStart), 0)
 	return start
 }
+
+// SyncCommitteeUntilEpoch returns the first epoch of the sync committee period that follows the given epoch,
+// which is used as the UntilEpoch of sync committee subscriptions
+func (n *Network) SyncCommitteeUntilEpoch(epoch uint64) uint64 {
+	period := n.EpochsPerSyncCommitteePeriod
+	if period == 0 {
+		period = DefaultEpochsPerSyncCommitteePeriod
+	}
+	return (epoch/period + 1) * period
+}
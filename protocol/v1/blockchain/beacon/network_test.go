@@ -0,0 +1,27 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork_SyncCommitteeUntilEpoch(t *testing.T) {
+	t.Run("default period", func(t *testing.T) {
+		n := NewNetwork(core.PraterNetwork)
+		require.Equal(t, uint64(DefaultEpochsPerSyncCommitteePeriod), n.EpochsPerSyncCommitteePeriod)
+		require.Equal(t, uint64(256), n.SyncCommitteeUntilEpoch(0))
+		require.Equal(t, uint64(512), n.SyncCommitteeUntilEpoch(256))
+		require.Equal(t, uint64(512), n.SyncCommitteeUntilEpoch(511))
+	})
+
+	t.Run("custom period", func(t *testing.T) {
+		n := NewNetwork(core.PraterNetwork)
+		n.EpochsPerSyncCommitteePeriod = 8
+		require.Equal(t, uint64(8), n.SyncCommitteeUntilEpoch(0))
+		require.Equal(t, uint64(8), n.SyncCommitteeUntilEpoch(7))
+		require.Equal(t, uint64(16), n.SyncCommitteeUntilEpoch(8))
+		require.Equal(t, uint64(104), n.SyncCommitteeUntilEpoch(100))
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 
@@ -43,6 +44,10 @@ type Share struct {
 	Operators    [][]byte
 	OperatorIds  []uint64
 	Liquidated   bool
+	// Paused is set locally by the operator to skip the duties of the validator, unlike Liquidated it is not on-chain
+	Paused bool
+	// FeeRecipient overrides the default fee recipient of block proposals, zero address means no override
+	FeeRecipient common.Address
 }
 
 //  serializedShare struct
@@ -55,6 +60,8 @@ type serializedShare struct {
 	Operators    [][]byte
 	OperatorIds  []uint64
 	Liquidated   bool
+	Paused       bool
+	FeeRecipient common.Address
 }
 
 // IsOperatorShare checks whether the share belongs to operator
@@ -176,6 +183,8 @@ func (s *Share) Serialize() ([]byte, error) {
 		Operators:    s.Operators,
 		OperatorIds:  s.OperatorIds,
 		Liquidated:   s.Liquidated,
+		Paused:       s.Paused,
+		FeeRecipient: s.FeeRecipient,
 	}
 	// copy committee by value
 	for k, n := range s.Committee {
@@ -219,6 +228,8 @@ func (s *Share) Deserialize(key []byte, val []byte) (*Share, error) {
 		Operators:    value.Operators,
 		OperatorIds:  value.OperatorIds,
 		Liquidated:   value.Liquidated,
+		Paused:       value.Paused,
+		FeeRecipient: value.FeeRecipient,
 	}, nil
 }
 
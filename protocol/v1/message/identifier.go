@@ -0,0 +1,68 @@
+package message
+
+import (
+	"fmt"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+)
+
+// IdentifierSize is the size of a message identifier, made of the validator public key and the role
+const IdentifierSize = len(spectypes.MessageID{})
+
+// identifierPubKeySize is the size of the validator public key in the identifier
+const identifierPubKeySize = 48
+
+// InvalidIdentifierError is returned for identifiers that are not well-formed
+type InvalidIdentifierError struct {
+	Identifier []byte
+	Reason     string
+}
+
+func (e *InvalidIdentifierError) Error() string {
+	return fmt.Sprintf("invalid identifier %x: %s", e.Identifier, e.Reason)
+}
+
+// ValidateIdentifier checks that the given identifier is well-formed, i.e. it has the correct length and a known role
+func ValidateIdentifier(identifier []byte) error {
+	if len(identifier) != IdentifierSize {
+		return &InvalidIdentifierError{
+			Identifier: identifier,
+			Reason:     fmt.Sprintf("expected %d bytes, got %d", IdentifierSize, len(identifier)),
+		}
+	}
+	if role := ToMessageID(identifier).GetRoleType(); !isKnownRole(role) {
+		return &InvalidIdentifierError{
+			Identifier: identifier,
+			Reason:     fmt.Sprintf("unknown role %d", role),
+		}
+	}
+	return nil
+}
+
+// RoleOf returns the role of the given identifier
+func RoleOf(identifier []byte) (spectypes.BeaconRole, error) {
+	if err := ValidateIdentifier(identifier); err != nil {
+		return 0, err
+	}
+	return ToMessageID(identifier).GetRoleType(), nil
+}
+
+// PubKeyOf returns the validator public key of the given identifier
+func PubKeyOf(identifier []byte) (spectypes.ValidatorPK, error) {
+	if err := ValidateIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	pk := make([]byte, identifierPubKeySize)
+	copy(pk, ToMessageID(identifier).GetPubKey())
+	return pk, nil
+}
+
+func isKnownRole(role spectypes.BeaconRole) bool {
+	switch role {
+	case spectypes.BNRoleAttester, spectypes.BNRoleAggregator, spectypes.BNRoleProposer,
+		spectypes.BNRoleSyncCommittee, spectypes.BNRoleSyncCommitteeContribution:
+		return true
+	default:
+		return false
+	}
+}
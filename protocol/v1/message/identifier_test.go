@@ -0,0 +1,51 @@
+package message
+
+import (
+	"encoding/binary"
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIdentifier(t *testing.T) {
+	pk := make([]byte, 48)
+	pk[0] = 1
+
+	t.Run("valid", func(t *testing.T) {
+		id := spectypes.NewMsgID(pk, spectypes.BNRoleSyncCommittee)
+		require.NoError(t, ValidateIdentifier(id[:]))
+
+		role, err := RoleOf(id[:])
+		require.NoError(t, err)
+		require.Equal(t, spectypes.BNRoleSyncCommittee, role)
+
+		pubKey, err := PubKeyOf(id[:])
+		require.NoError(t, err)
+		require.Equal(t, spectypes.ValidatorPK(pk), pubKey)
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		id := spectypes.NewMsgID(pk, spectypes.BNRoleAttester)
+		for _, malformed := range [][]byte{nil, []byte("pk"), id[:IdentifierSize-1], append(id[:], 0)} {
+			err := ValidateIdentifier(malformed)
+			require.Error(t, err)
+			var idErr *InvalidIdentifierError
+			require.ErrorAs(t, err, &idErr)
+			require.Equal(t, malformed, idErr.Identifier)
+		}
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		id := spectypes.NewMsgID(pk, spectypes.BNRoleAttester)
+		binary.LittleEndian.PutUint32(id[48:], 100)
+		err := ValidateIdentifier(id[:])
+		var idErr *InvalidIdentifierError
+		require.ErrorAs(t, err, &idErr)
+
+		_, err = RoleOf(id[:])
+		require.ErrorAs(t, err, &idErr)
+		_, err = PubKeyOf(id[:])
+		require.ErrorAs(t, err, &idErr)
+	})
+}
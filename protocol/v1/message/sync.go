@@ -82,6 +82,9 @@ type SyncMessage struct {
 	Data []*specqbft.SignedMessage
 	// Status is the status code of the operation
 	Status StatusCode
+	// LowestAvailable is a hint for the lowest height that the responding node has,
+	// it is set when the requested range is not available (e.g. light nodes that don't keep history)
+	LowestAvailable *specqbft.Height `json:",omitempty"`
 }
 
 // Encode encodes the message
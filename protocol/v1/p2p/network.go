@@ -88,6 +88,9 @@ type Syncer interface {
 	// GetHistory sync the given range from a set of peers that supports history for the given identifier
 	// it accepts a list of targets for the request
 	GetHistory(mid spectypes.MessageID, from, to specqbft.Height, targets ...string) ([]SyncResult, specqbft.Height, error)
+	// SyncDecidedByRange fetches the decided messages of the given (inclusive) range from a set of peers that supports history,
+	// the messages are passed to the message router
+	SyncDecidedByRange(mid spectypes.MessageID, from, to specqbft.Height) error
 	// LastChangeRound fetches last change round message from a random set of peers
 	LastChangeRound(mid spectypes.MessageID, height specqbft.Height) ([]SyncResult, error)
 }
@@ -89,6 +89,8 @@ func NewMockNetwork(logger *zap.Logger, self peer.ID, inBufSize int) MockNetwork
 		logger:                 logger,
 		self:                   self,
 		topics:                 make(map[string][]peer.ID),
+		subscribed:             make(map[string]bool),
+		handlers:               make(map[string]RequestHandler),
 		peers:                  make(map[peer.ID]MockNetwork),
 		inBufSize:              inBufSize,
 		inPubsub:               make(chan MockMessageEvent, inBufSize),
@@ -247,17 +249,20 @@ func (m *mockNetwork) RegisterHandlers(handlers ...*SyncHandler) {
 	}
 }
 
-func (m *mockNetwork) registerHandler(protocol SyncProtocol, handlers ...RequestHandler) {
-	var pid string
+func mockProtocolID(protocol SyncProtocol) string {
 	switch protocol {
 	case LastDecidedProtocol:
-		pid = "/decided/last/0.0.1"
+		return "/decided/last/0.0.1"
 	case LastChangeRoundProtocol:
-		pid = "/changeround/last/0.0.1"
+		return "/changeround/last/0.0.1"
 	case DecidedHistoryProtocol:
-		pid = "/decided/history/0.0.1"
+		return "/decided/history/0.0.1"
 	}
+	return ""
+}
 
+func (m *mockNetwork) registerHandler(protocol SyncProtocol, handlers ...RequestHandler) {
+	pid := mockProtocolID(protocol)
 	requestHandlers := CombineRequestHandlers(handlers...)
 
 	m.handlersLock.Lock()
@@ -309,6 +314,11 @@ func (m *mockNetwork) GetHistory(mid spectypes.MessageID, from, to specqbft.Heig
 	return m.PollGetHistoryMessages(), to, nil
 }
 
+func (m *mockNetwork) SyncDecidedByRange(mid spectypes.MessageID, from, to specqbft.Height) error {
+	// TODO: route the messages of the range
+	return nil
+}
+
 func (m *mockNetwork) LastChangeRound(mid spectypes.MessageID, height specqbft.Height) ([]SyncResult, error) {
 	//m.lock.Lock()
 	//defer m.lock.Unlock()
@@ -40,6 +40,12 @@ type IController interface {
 	// OnFork called when fork occur.
 	OnFork(forkVersion forksprotocol.ForkVersion) error
 
+	// GetDecidedValue returns the value that was decided at the given height, if it exists in storage
+	GetDecidedValue(height specqbft.Height) ([]byte, bool, error)
+
+	// Close waits for pending writes, should be called once the controller is no longer used
+	Close() error
+
 	// GetCurrentInstance returns current instance if exist. if not, returns nil TODO for mapping, need to remove once duty runner implemented
 	GetCurrentInstance() instance.Instancer
 }
@@ -23,10 +23,13 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
 	forksfactory "github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks/factory"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy/factory"
+	protocolsync "github.com/bloxapp/ssv/protocol/v1/sync"
+	"github.com/bloxapp/ssv/utils/logex"
 )
 
 // ErrAlreadyRunning is used to express that some process is already running, e.g. sync
@@ -51,10 +54,50 @@ type Options struct {
 	KeyManager        spectypes.KeyManager
 	SyncRateLimit     time.Duration
 	SigTimeout        time.Duration
+	RoleSigTimeouts   map[spectypes.BeaconRole]time.Duration
 	MinPeers          int
+	RoleMinPeers      map[spectypes.BeaconRole]int
 	ReadMode          bool
 	FullNode          bool
 	NewDecidedHandler NewDecidedHandler
+	// AsyncStatePersistence saves the running instance state in the background,
+	// decided messages are always saved synchronously
+	AsyncStatePersistence bool
+	// DisableHighestRoundCatchup disables the fast catchup of the highest round upon round change,
+	// round change messages are still broadcasted
+	DisableHighestRoundCatchup bool
+	// LateMessagesWindow is the time to wait for late messages once an instance is done,
+	// the retained messages of the instance height are purged afterwards. 0 falls back to the default window
+	LateMessagesWindow time.Duration
+}
+
+// defaultLateMessagesWindow is the default time to wait for late messages once an instance is done
+const defaultLateMessagesWindow = time.Minute
+
+// lateMessagesWindow returns the configured late messages window, or the default one if not configured
+func (opts Options) lateMessagesWindow() time.Duration {
+	if opts.LateMessagesWindow > 0 {
+		return opts.LateMessagesWindow
+	}
+	return defaultLateMessagesWindow
+}
+
+// sigTimeout returns the signature collection timeout of the configured role,
+// falls back to the global SigTimeout if no role specific timeout was set
+func (opts Options) sigTimeout() time.Duration {
+	if timeout, ok := opts.RoleSigTimeouts[opts.Role]; ok && timeout > 0 {
+		return timeout
+	}
+	return opts.SigTimeout
+}
+
+// minPeers returns the required minimum peers of the configured role,
+// falls back to the global MinPeers if no role specific minimum was set
+func (opts Options) minPeers() int {
+	if min, ok := opts.RoleMinPeers[opts.Role]; ok && min > 0 {
+		return min
+	}
+	return opts.MinPeers
 }
 
 // set of states for the controller
@@ -103,15 +146,22 @@ type Controller struct {
 	SignatureState SignatureState
 
 	// config
-	SyncRateLimit time.Duration
-	MinPeers      int
+	SyncRateLimit         time.Duration
+	MinPeers              int
+	asyncStatePersistence bool
+	// disableHighestRoundCatchup prevents fetching the last round change from peers upon round change
+	disableHighestRoundCatchup bool
+	// lateMessagesWindow is the time to wait for late messages once an instance is done
+	lateMessagesWindow time.Duration
 
 	// state
-	State  uint32
-	height atomic.Value // specqbft.Height
+	State   uint32
+	height  atomic.Value // specqbft.Height
+	syncing uint32       // 1 while a decided sync is running
 
 	// flags
 	ReadMode bool
+	// fullNode is taken from the node config at startup and can't be changed at runtime
 	fullNode bool
 
 	Q msgqueue.MsgQueue
@@ -121,11 +171,25 @@ type Controller struct {
 	newDecidedHandler NewDecidedHandler
 
 	highestRoundCtxCancel context.CancelFunc
+	// traceID is the trace id of the duty of the running instance
+	traceID string
+	// changeRoundCatchups holds the instances with a running fast change round catchup
+	changeRoundCatchups sync.Map
+
+	// msgLogger is a sampled logger for the high-frequency logs of incoming messages
+	msgLogger *zap.Logger
+
+	// pendingState is the latest instance state that is waiting to be saved in the background
+	pendingState     *qbft.State
+	persistingState  bool
+	persistStateLock sync.Mutex
+	// persistStateWG is used to wait for background state writes, see flushInstanceState
+	persistStateWG sync.WaitGroup
 }
 
 // New is the constructor of Controller
 func New(opts Options) IController {
-	logger := opts.Logger.With(zap.String("role", opts.Role.String()), zap.Bool("read mode", opts.ReadMode))
+	logger := opts.Logger.With(logfields.Role(opts.Role), zap.Bool("read mode", opts.ReadMode))
 	fork := forksfactory.NewFork(opts.Version)
 
 	ctrl := &Controller{
@@ -133,6 +197,7 @@ func New(opts Options) IController {
 		InstanceStorage:        opts.Storage,
 		ChangeRoundStorage:     opts.Storage,
 		Logger:                 logger,
+		msgLogger:              logex.Sampled(logger),
 		Network:                opts.Network,
 		InstanceConfig:         opts.InstanceConfig,
 		ValidatorShare:         opts.ValidatorShare,
@@ -140,11 +205,15 @@ func New(opts Options) IController {
 		Fork:                   fork,
 		Beacon:                 opts.Beacon,
 		KeyManager:             opts.KeyManager,
-		SignatureState:         SignatureState{SignatureCollectionTimeout: opts.SigTimeout},
+		SignatureState:         SignatureState{SignatureCollectionTimeout: opts.sigTimeout()},
 		HigherReceivedMessages: make(map[spectypes.OperatorID]specqbft.Height, len(opts.ValidatorShare.Committee)),
 
-		SyncRateLimit: opts.SyncRateLimit,
-		MinPeers:      opts.MinPeers,
+		SyncRateLimit:         opts.SyncRateLimit,
+		MinPeers:              opts.minPeers(),
+		asyncStatePersistence: opts.AsyncStatePersistence,
+
+		disableHighestRoundCatchup: opts.DisableHighestRoundCatchup,
+		lateMessagesWindow:         opts.lateMessagesWindow(),
 
 		ReadMode: opts.ReadMode,
 		fullNode: opts.FullNode,
@@ -157,7 +226,7 @@ func New(opts Options) IController {
 
 	if !opts.ReadMode {
 		q, err := msgqueue.New(
-			logger.With(zap.String("who", "msg_q")),
+			logex.Sampled(logger.With(zap.String("who", "msg_q"))),
 			msgqueue.WithIndexers( /*msgqueue.DefaultMsgIndexer(), */ msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer(), msgqueue.SignedPostConsensusMsgIndexer()),
 		)
 		if err != nil {
@@ -169,12 +238,18 @@ func New(opts Options) IController {
 
 	ctrl.DecidedFactory = factory.NewDecidedFactory(logger, ctrl.GetNodeMode(), opts.Storage, opts.Network)
 	ctrl.DecidedStrategy = ctrl.DecidedFactory.GetStrategy()
+	ctrl.onDecidedStrategyChange()
 
 	// set flags
 	ctrl.State = NotStarted
 	return ctrl
 }
 
+// IsReady returns true once the controller was initialized, i.e. it found enough peers and synced decided history
+func (c *Controller) IsReady() bool {
+	return atomic.LoadUint32(&c.State) == Ready
+}
+
 // Init sets all major processes of iBFT while blocking until completed.
 // if init fails to sync
 func (c *Controller) Init() error {
@@ -216,12 +291,8 @@ func (c *Controller) Init() error {
 		if err != nil {
 			c.Logger.Error("failed to get last known", zap.Error(err))
 		}
-		if err := c.syncDecided(knownMsg, nil); err != nil {
-			if err == ErrAlreadyRunning {
-				// don't fail if init is already running
-				c.Logger.Debug("iBFT init is already running (syncing history)")
-				return nil
-			}
+		// ErrAlreadyRunning is ignored as the running sync of this identifier will bring the node up to date
+		if err := c.syncHistory(knownMsg); err != nil && err != ErrAlreadyRunning {
 			c.Logger.Warn("iBFT implementation init failed to sync history", zap.Error(err))
 			ReportIBFTStatus(c.ValidatorShare.PublicKey.SerializeToHexStr(), false, true)
 			atomic.StoreUint32(&c.State, SyncedChangeRound) // rollback state in order to find peers & try syncing again
@@ -231,7 +302,7 @@ func (c *Controller) Init() error {
 		atomic.StoreUint32(&c.State, Ready)
 
 		ReportIBFTStatus(c.ValidatorShare.PublicKey.SerializeToHexStr(), true, false)
-		c.Logger.Info("iBFT implementation init finished", zap.Int64("height", int64(c.GetHeight())))
+		c.Logger.Info("iBFT implementation init finished", logfields.Height(c.GetHeight()))
 	}
 
 	return nil
@@ -269,7 +340,11 @@ func (c *Controller) StartInstance(opts instance.ControllerStartInstanceOptions,
 		c.Logger.Warn("could not clean change round", zap.Error(err))
 	}
 
+	instanceOpts.RecoveredState = c.savedInstanceState(opts.Height)
+
+	c.traceID = opts.TraceID
 	res, err = c.startInstanceWithOptions(instanceOpts, opts.Value, getInstance)
+	c.traceID = ""
 	defer func() {
 		done()
 		// report error status if the instance returned error
@@ -282,6 +357,33 @@ func (c *Controller) StartInstance(opts instance.ControllerStartInstanceOptions,
 	return res, err
 }
 
+// savedInstanceState returns the persisted state of a prepared instance at the given height, if exists
+func (c *Controller) savedInstanceState(height specqbft.Height) *qbft.State {
+	if c.InstanceStorage == nil {
+		return nil
+	}
+	// making sure that the latest state was written before reading it
+	c.flushInstanceState()
+	state, found, err := c.InstanceStorage.GetCurrentInstance(c.Identifier)
+	if err != nil {
+		c.Logger.Warn("could not get saved instance state", zap.Error(err))
+		return nil
+	}
+	if !found || state == nil || state.GetHeight() != height || len(state.GetPreparedValue()) == 0 {
+		return nil
+	}
+	c.Logger.Info("found saved state of prepared instance", logfields.Height(height),
+		zap.Uint64("preparedRound", uint64(state.GetPreparedRound())))
+	return state
+}
+
+// Close waits for pending background writes of instance state,
+// should be called once the controller is stopped and before the storage is closed
+func (c *Controller) Close() error {
+	c.flushInstanceState()
+	return nil
+}
+
 // GetCurrentInstance returns current instance if exist. if not, returns nil
 func (c *Controller) GetCurrentInstance() instance.Instancer {
 	c.CurrentInstanceLock.RLock()
@@ -299,9 +401,13 @@ func (c *Controller) SetCurrentInstance(instance instance.Instancer) {
 }
 
 // OnFork called upon fork, it will make sure all decided messages were processed
-// before clearing the entire msg queue.
-// it also recreates the fork instance and decided strategy with the new fork version
+// before clearing the entire msg queue, decided messages that failed to be processed are re-queued post fork.
+// it also recreates the fork instance and decided strategy with the new fork version.
+// unknown fork versions are rejected before any of the above, leaving the controller as is
 func (c *Controller) OnFork(forkVersion forksprotocol.ForkVersion) error {
+	if err := forksprotocol.ValidateForkVersion(forkVersion); err != nil {
+		return errors.Wrap(err, "could not fork qbft controller")
+	}
 	atomic.StoreUint32(&c.State, Forking)
 	defer atomic.StoreUint32(&c.State, Ready)
 
@@ -309,29 +415,66 @@ func (c *Controller) OnFork(forkVersion forksprotocol.ForkVersion) error {
 		i.Stop()
 		c.SetCurrentInstance(nil)
 	}
-	c.processAllDecided(c.MessageHandler)
+	failed := c.processAllDecided(c.MessageHandler)
 	cleared := c.Q.Clean(msgqueue.AllIndicesCleaner)
-	c.Logger.Debug("FORKING qbft controller", zap.Int64("clearedMessages", cleared))
+	c.Logger.Debug("FORKING qbft controller", zap.Int64("clearedMessages", cleared),
+		zap.Int("failedDecidedMessages", len(failed)))
+	reportQueueCleaned(queueCleanReasonFork, cleared)
+	c.reportQueueLen()
 
 	// get new QBFT controller fork and update decidedStrategy
 	c.ForkLock.Lock()
 	defer c.ForkLock.Unlock()
 	c.Fork = forksfactory.NewFork(forkVersion)
 	c.DecidedStrategy = c.DecidedFactory.GetStrategy()
+	c.onDecidedStrategyChange()
+
+	// re-queue decided messages that failed to be processed, so they will be processed post fork
+	for _, msg := range failed {
+		c.Q.Add(msg)
+	}
 	return nil
 }
 
+// onDecidedStrategyChange logs and reports the active decided strategy
+func (c *Controller) onDecidedStrategyChange() {
+	mode := c.DecidedFactory.Mode()
+	c.Logger.Info("decided strategy was selected", zap.String("strategy", mode.String()))
+	reportDecidedStrategy(message.ToMessageID(c.Identifier), mode)
+}
+
 func (c *Controller) syncDecided(from, to *specqbft.SignedMessage) error {
+	// only one sync is allowed per identifier (i.e. controller)
+	if !atomic.CompareAndSwapUint32(&c.syncing, 0, 1) {
+		c.Logger.Debug("decided sync is already running")
+		return ErrAlreadyRunning
+	}
+	defer atomic.StoreUint32(&c.syncing, 0)
+
 	c.ForkLock.Lock()
 	decidedStrategy := c.DecidedStrategy
 	c.ForkLock.Unlock()
-	msgs, err := decidedStrategy.Sync(c.Ctx, c.Identifier, from, to)
+	done := runningSyncs.start(c.Identifier)
+	defer done()
+	parent := c.Ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx := protocolsync.WithProgress(parent, c.onSyncProgress)
+	msgs, err := decidedStrategy.Sync(ctx, c.Identifier, from, to)
 	if err != nil {
 		return err
 	}
 	return c.handleSyncMessages(msgs)
 }
 
+// onSyncProgress reports the progress of a running decided sync
+func (c *Controller) onSyncProgress(height specqbft.Height, fetched int) {
+	c.Logger.Debug("decided sync progress", logfields.Height(height), zap.Int("fetched", fetched))
+	runningSyncs.touch(string(c.Identifier))
+	reportSyncProgress(message.ToMessageID(c.Identifier), height)
+}
+
 func (c *Controller) handleSyncMessages(msgs []*specqbft.SignedMessage) error {
 	c.Logger.Debug(fmt.Sprintf("recivied %d msgs from sync", len(msgs)))
 	for _, syncMsg := range msgs {
@@ -341,6 +484,10 @@ func (c *Controller) handleSyncMessages(msgs []*specqbft.SignedMessage) error {
 			c.Logger.Warn("invalid sync msg", zap.Error(err))
 			continue
 		}
+		if err := message.ValidateIdentifier(syncMsg.Message.Identifier); err != nil {
+			c.Logger.Warn("invalid sync msg", zap.Error(err))
+			continue
+		}
 		encoded, err := syncMsg.Encode() // TODo move to better place
 		if err != nil {
 			c.Logger.Warn("failed to encode sync msg", zap.Error(err))
@@ -369,6 +516,14 @@ func (c *Controller) GetIdentifier() []byte {
 
 // ProcessMsg takes an incoming message, and adds it to the message queue or handle it on read mode
 func (c *Controller) ProcessMsg(msg *spectypes.SSVMessage) error {
+	if err := message.ValidateIdentifier(msg.MsgID[:]); err != nil {
+		return err
+	}
+	// rejecting misrouted messages of other validators or roles before they are queued
+	expected := message.ToMessageID(c.Identifier)
+	if err := signedmsg.CheckIdentifier(msg.MsgID[:], expected[:]); err != nil {
+		return err
+	}
 	if c.ReadMode {
 		return c.MessageHandler(msg)
 	}
@@ -377,18 +532,32 @@ func (c *Controller) ProcessMsg(msg *spectypes.SSVMessage) error {
 	if cInstance != nil {
 		currentState := cInstance.GetState()
 		if currentState != nil {
-			fields = append(fields, zap.String("instance stage", qbft.RoundStateName[currentState.Stage.Load()]), zap.Uint32("instance height", uint32(currentState.GetHeight())), zap.Uint32("instance round", uint32(currentState.GetRound())))
+			fields = append(fields, zap.String("instance stage", qbft.RoundStateName[currentState.Stage.Load()]), logfields.Height(currentState.GetHeight()), logfields.Round(currentState.GetRound()))
 		}
 	}
 	fields = append(fields,
-		zap.Int("queue_len", c.Q.Len()),
+		zap.Int("queue_len", c.Q.Size()),
 		zap.String("msgType", message.MsgTypeToString(msg.MsgType)),
 	)
-	c.Logger.Debug("got message, add to queue", fields...)
+	c.getMsgLogger().Debug("got message, add to queue", fields...)
 	c.Q.Add(msg)
+	c.reportQueueLen()
 	return nil
 }
 
+// reportQueueLen reports the current length of the message queue
+func (c *Controller) reportQueueLen() {
+	reportQueueLen(message.ToMessageID(c.Identifier), c.Q.Size())
+}
+
+// getMsgLogger returns the sampled logger of incoming messages, fallbacks to the controller logger
+func (c *Controller) getMsgLogger() *zap.Logger {
+	if c.msgLogger != nil {
+		return c.msgLogger
+	}
+	return c.Logger
+}
+
 // MessageHandler process message from queue,
 func (c *Controller) MessageHandler(msg *spectypes.SSVMessage) error {
 	switch msg.GetType() {
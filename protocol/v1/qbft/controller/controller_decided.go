@@ -46,6 +46,26 @@ func (c *Controller) uponDecided(logger *zap.Logger, msg *specqbft.SignedMessage
 	return false, nil // TODO need to return "decided" false in that case?
 }
 
+// GetDecidedValue returns the value that was decided at the given height.
+// in light node mode, only the value of the last decided height is available
+func (c *Controller) GetDecidedValue(height specqbft.Height) ([]byte, bool, error) {
+	msgs, err := c.DecidedStrategy.GetDecided(c.GetIdentifier(), height, height)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not get decided")
+	}
+	for _, msg := range msgs {
+		if msg == nil || msg.Message == nil || msg.Message.Height != height {
+			continue
+		}
+		commitData, err := msg.Message.GetCommitData()
+		if err != nil {
+			return nil, false, errors.Wrap(err, "could not get commit data")
+		}
+		return commitData.Data, true, nil
+	}
+	return nil, false, nil
+}
+
 // onNewDecidedMessage handles a new decided message, will be called at max twice in an epoch for a single validator.
 // in read mode, we don't broadcast the message in the network
 func (c *Controller) onNewDecidedMessage(msg *specqbft.SignedMessage) error {
@@ -96,68 +116,6 @@ func (c *Controller) validateDecided(msg *specqbft.SignedMessage) error {
 		signedmsg.AuthorizeMsg(c.ValidatorShare)).Run(msg)
 }
 
-// processDecidedMessage is responsible for processing an incoming decided message.
-// we will process decided messages according to the following rules:
-// 1. invalid > exit
-// 2. old decided > exit
-// 3. new message > force decide or stop instance and sync
-// 4. last decided, try to update signers
-// TODO need to be removed
-/* func (c *Controller) processDecidedMessage(msg *specqbft.SignedMessage) error {
-	if err := c.ValidateDecidedMsg(msg); err != nil {
-		c.Logger.Error("received invalid decided message", zap.Error(err), zap.Any("signer ids", msg.Signers))
-		return nil
-	}
-	logger := c.Logger.With(zap.String("who", "processDecided"),
-		zap.Uint64("height", uint64(msg.Message.Height)),
-		zap.Any("signer ids", msg.Signers))
-	logger.Debug("received valid decided msg")
-
-	localMsg, err := c.highestKnownDecided()
-	if err != nil {
-		logger.Warn("could not read local decided message", zap.Error(err))
-		return err
-	}
-	// old message
-	if localMsg != nil && localMsg.Message.Height > msg.Message.Height {
-		logger.Debug("known decided msg")
-		return nil
-	}
-	// new message, force decide or stop instance and sync
-	if localMsg == nil || msg.Message.Height > localMsg.Message.Height {
-		if c.forceDecided(msg) {
-			logger.Debug("current instance decided")
-			return nil
-		}
-		updated, err := c.DecidedStrategy.UpdateDecided(msg)
-		if err != nil {
-			return err
-		}
-		if updated != nil {
-			qbft.ReportDecided(hex.EncodeToString(message.ToMessageID(msg.Message.Identifier).GetPubKey()), updated)
-			if c.newDecidedHandler != nil {
-				go c.newDecidedHandler(msg)
-			}
-		}
-		if currentInstance := c.GetCurrentInstance(); currentInstance != nil {
-			logger.Debug("stopping current instance")
-			currentInstance.Stop()
-		}
-		return c.syncDecided(localMsg, msg)
-	}
-	// last decided, try to update it (merge new signers)
-	if updated, err := c.DecidedStrategy.UpdateDecided(msg); err != nil {
-		logger.Warn("could not update decided")
-	} else if updated != nil {
-		qbft.ReportDecided(hex.EncodeToString(message.ToMessageID(msg.Message.Identifier).GetPubKey()), updated)
-		if c.newDecidedHandler != nil {
-			go c.newDecidedHandler(msg)
-		}
-	}
-	return err
-	return nil
-}*/
-
 // highestKnownDecided returns the highest known decided instance
 func (c *Controller) highestKnownDecided() (*specqbft.SignedMessage, error) {
 	highestKnown, err := c.DecidedStrategy.GetLastDecided(c.GetIdentifier())
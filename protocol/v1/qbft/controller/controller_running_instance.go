@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/leader/roundrobin"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 	"github.com/bloxapp/ssv/protocol/v1/sync/changeround"
 )
@@ -62,24 +64,13 @@ instanceLoop:
 		}
 		if exit {
 			// exited with no error means instance decided
-			// fetch decided msg and return
-			retMsg, e := c.DecidedStrategy.GetDecided(c.Identifier, instanceOpts.Height, instanceOpts.Height)
-			if e != nil {
-				err = e
-				c.Logger.Error("failed to get decided when instance exist", zap.Error(e))
-				break instanceLoop
-			}
-			if len(retMsg) == 0 {
-				err = errors.Errorf("could not fetch decided msg with height %d after instance finished", instanceOpts.Height)
-				break instanceLoop
-			}
-			retRes = &instance.Result{
-				Decided: true,
-				Msg:     retMsg[0],
-			}
+			retRes, err = c.decidedResult(newInstance, instanceOpts.Height)
 			break instanceLoop
 		}
 	}
+	// the instance might have stopped w/o a stage change, making sure highest round catchup is not leaked
+	c.cancelHighestRound()
+
 	var seq specqbft.Height
 	if c.GetCurrentInstance() != nil {
 		// saves seq as instance will be cleared
@@ -94,6 +85,29 @@ instanceLoop:
 	return retRes, err
 }
 
+// decidedResult returns the result of a decided instance, built from the aggregated commit that is held by the instance.
+// falls back to read the decided message from storage if the instance doesn't hold it
+func (c *Controller) decidedResult(inst instance.Instancer, height specqbft.Height) (*instance.Result, error) {
+	if agg, err := inst.CommittedAggregatedMsg(); err == nil && agg != nil && agg.Message != nil && agg.Message.Height == height {
+		return &instance.Result{
+			Decided: true,
+			Msg:     agg,
+		}, nil
+	}
+	retMsg, err := c.DecidedStrategy.GetDecided(c.Identifier, height, height)
+	if err != nil {
+		c.Logger.Error("failed to get decided when instance exist", zap.Error(err))
+		return nil, err
+	}
+	if len(retMsg) == 0 {
+		return nil, errors.Errorf("could not fetch decided msg with height %d after instance finished", height)
+	}
+	return &instance.Result{
+		Decided: true,
+		Msg:     retMsg[0],
+	}, nil
+}
+
 // afterInstance is triggered after the instance was finished
 func (c *Controller) afterInstance(height specqbft.Height, res *instance.Result, err error) {
 	// if instance was decided -> wait for late commit messages
@@ -106,12 +120,13 @@ func (c *Controller) afterInstance(height specqbft.Height, res *instance.Result,
 			}
 			height = res.Msg.Message.Height
 		}
+		c.purgeLateMessages(height)
 		return
 	}
 	// didn't decided -> purge messages with smaller height
 	//c.q.Purge(msgqueue.DefaultMsgIndex(message.SSVConsensusMsgType, c.Identifier)) // TODO: that's the right indexer? might need be height and all messages
 	idn := hex.EncodeToString(c.Identifier)
-	c.Q.Clean(func(k msgqueue.Index) bool {
+	cleaned := c.Q.Clean(func(k msgqueue.Index) bool {
 		if k.ID == idn && k.H <= height {
 			if k.Mt == spectypes.SSVPartialSignatureMsgType && k.H == height { // need post consensus msgs
 				return false
@@ -123,24 +138,56 @@ func (c *Controller) afterInstance(height specqbft.Height, res *instance.Result,
 		}
 		return false
 	})
+	reportQueueCleaned(queueCleanReasonAfterInstance, cleaned)
+	c.reportQueueLen()
+	c.purgeLateMessages(height)
+}
+
+// purgeLateMessages purges the consensus and decided messages of the given height (and lower) once the late messages window is over,
+// so messages that were retained for late processing (e.g. late commits) won't stay in the queue forever.
+// the decided index (which has no height) is kept as it holds future decided messages as well.
+// note that post consensus messages are purged once the signatures collection is done or timed out
+func (c *Controller) purgeLateMessages(height specqbft.Height) {
+	if c.lateMessagesWindow <= 0 {
+		return
+	}
+	idn := hex.EncodeToString(c.Identifier)
+	time.AfterFunc(c.lateMessagesWindow, func() {
+		if c.Ctx != nil && c.Ctx.Err() != nil {
+			return
+		}
+		cleaned := c.Q.Clean(func(k msgqueue.Index) bool {
+			if k.ID != idn || k.H < 0 || k.H > height {
+				return false
+			}
+			return k.Mt == spectypes.SSVConsensusMsgType || k.Mt == spectypes.SSVDecidedMsgType
+		})
+		reportQueueCleaned(queueCleanReasonLateMessages, cleaned)
+		c.reportQueueLen()
+		if cleaned > 0 {
+			c.Logger.Debug("purged late messages", logfields.Height(height), zap.Int64("cleaned", cleaned))
+		}
+	})
 }
 
 // instanceStageChange processes a stage change for the current instance, returns true if requires stopping the instance after stage process.
 func (c *Controller) instanceStageChange(stage qbft.RoundState) (bool, error) {
 	logger := c.Logger.With()
+	if c.traceID != "" {
+		logger = logger.With(logfields.TraceID(c.traceID))
+	}
 	if ci := c.GetCurrentInstance(); ci != nil {
 		if s := ci.GetState(); s != nil {
 			logger = logger.With(zap.Uint64("instanceHeight", uint64(s.GetHeight())))
 		}
 	}
-	if c.highestRoundCtxCancel != nil {
-		c.highestRoundCtxCancel()
-		c.highestRoundCtxCancel = nil
-	}
+	// highest round catchup of the previous stage is cancelled upon any stage change,
+	// including decided / stopped as there is no stage change afterwards
+	c.cancelHighestRound()
 	logger.Debug("instance stage has been changed!", zap.String("stage", qbft.RoundStateName[int32(stage)]))
 	switch stage {
 	case qbft.RoundStatePrepare:
-		if err := c.InstanceStorage.SaveCurrentInstance(c.GetIdentifier(), c.GetCurrentInstance().GetState()); err != nil {
+		if err := c.persistInstanceState(c.GetCurrentInstance().GetState()); err != nil {
 			return true, errors.Wrap(err, "could not save prepare msg to storage")
 		}
 	case qbft.RoundStateDecided:
@@ -149,14 +196,19 @@ func (c *Controller) instanceStageChange(stage qbft.RoundState) (bool, error) {
 			if err != nil {
 				return errors.Wrap(err, "could not get aggregated commit msg and save to storage")
 			}
+			if err := validateDecidedValue(c.GetCurrentInstance().GetState(), agg); err != nil {
+				return errors.Wrap(err, "invalid decided value")
+			}
+			start := time.Now()
 			updated, err := c.DecidedStrategy.UpdateDecided(agg)
+			reportDecidedWrite(message.ToMessageID(c.Identifier).GetRoleType(), time.Since(start))
 			if err != nil {
 				return errors.Wrap(err, "could not save highest decided message to storage")
 			}
 			logger.Info("decided current instance",
-				zap.String("identifier", message.ToMessageID(agg.Message.Identifier).String()),
+				logfields.Identifier(agg.Message.Identifier),
 				zap.Any("signers", agg.GetSigners()),
-				zap.Uint64("height", uint64(agg.Message.Height)),
+				logfields.Height(agg.Message.Height),
 				zap.Any("updated", updated))
 			if updated != nil {
 				if err = c.onNewDecidedMessage(updated); err != nil {
@@ -181,6 +233,9 @@ func (c *Controller) instanceStageChange(stage qbft.RoundState) (bool, error) {
 		if err := currentInstance.BroadcastChangeRound(); err != nil {
 			c.Logger.Warn("could not broadcast round change message", zap.Error(err))
 		}
+		if c.disableHighestRoundCatchup {
+			break
+		}
 		highestRoundTimeout := currentInstance.HighestRoundTimeoutSeconds()
 		if highestRoundTimeout > 0 {
 			ctx, cancel := context.WithCancel(c.Ctx)
@@ -194,9 +249,18 @@ func (c *Controller) instanceStageChange(stage qbft.RoundState) (bool, error) {
 	return false, nil
 }
 
+// cancelHighestRound stops the highest round catchup, if running
+func (c *Controller) cancelHighestRound() {
+	if c.highestRoundCtxCancel != nil {
+		c.highestRoundCtxCancel()
+		c.highestRoundCtxCancel = nil
+	}
+}
+
 func (c *Controller) highestRound(ctx context.Context, highestRoundTimeout time.Duration) {
 	c.Logger.Debug("starting highest round")
 	ticker := time.NewTicker(highestRoundTimeout)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
@@ -212,8 +276,15 @@ func (c *Controller) highestRound(ctx context.Context, highestRoundTimeout time.
 }
 
 // fastChangeRoundCatchup fetches the latest change round (if one exists) from every peer to try and fast sync forward.
-// This is an active msg fetching instead of waiting for an incoming msg to be received which can take a while
+// This is an active msg fetching instead of waiting for an incoming msg to be received which can take a while.
+// only one catchup runs at a time for an instance, calls that are made while a catchup is in flight are skipped
 func (c *Controller) fastChangeRoundCatchup(instance instance.Instancer) {
+	if _, running := c.changeRoundCatchups.LoadOrStore(instance, struct{}{}); running {
+		c.Logger.Debug("skipping fast change round catchup, already running")
+		return
+	}
+	defer c.changeRoundCatchups.Delete(instance)
+
 	count := 0
 	f := changeround.NewLastRoundFetcher(c.Logger, c.Network)
 	handler := func(msg *specqbft.SignedMessage) error {
@@ -244,5 +315,84 @@ func (c *Controller) fastChangeRoundCatchup(instance instance.Instancer) {
 		return
 	}
 
-	c.Logger.Info("fast change round catchup finished", zap.Int("count", count), zap.Int64("height", int64(h)))
+	c.Logger.Info("fast change round catchup finished", zap.Int("count", count), logfields.Height(h))
+}
+
+// validateDecidedValue checks that the value of the aggregated commit equals the value of the proposal
+// that was accepted by the instance in the same round.
+// the check is skipped if no such proposal was accepted (e.g. decided was received from other operators)
+func validateDecidedValue(state *qbft.State, agg *specqbft.SignedMessage) error {
+	if state == nil || agg == nil || agg.Message == nil {
+		return nil
+	}
+	proposal := state.GetProposalAcceptedForCurrentRound()
+	if proposal == nil || proposal.Message == nil || proposal.Message.Round != agg.Message.Round {
+		return nil
+	}
+	proposalData, err := proposal.Message.GetProposalData()
+	if err != nil {
+		return errors.Wrap(err, "could not get proposal data")
+	}
+	commitData, err := agg.Message.GetCommitData()
+	if err != nil {
+		return errors.Wrap(err, "could not get commit data")
+	}
+	if !bytes.Equal(proposalData.Data, commitData.Data) {
+		return errors.New("decided value does not match the accepted proposal value")
+	}
+	return nil
+}
+
+// persistInstanceState saves the given state of the running instance.
+// in async mode, a snapshot of the state is saved in the background and only the latest pending snapshot is kept,
+// therefore it should not be used for data that must be durable (e.g. decided messages)
+func (c *Controller) persistInstanceState(state *qbft.State) error {
+	if !c.asyncStatePersistence {
+		return c.InstanceStorage.SaveCurrentInstance(c.GetIdentifier(), state)
+	}
+	// taking a snapshot as the state keeps changing while the instance is running
+	data, err := state.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "could not encode instance state")
+	}
+	snapshot := &qbft.State{}
+	if err := snapshot.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(err, "could not decode instance state")
+	}
+
+	c.persistStateLock.Lock()
+	defer c.persistStateLock.Unlock()
+
+	c.pendingState = snapshot
+	if !c.persistingState {
+		c.persistingState = true
+		c.persistStateWG.Add(1)
+		go c.persistPendingStates()
+	}
+	return nil
+}
+
+// flushInstanceState blocks until the pending states (if any) were saved
+func (c *Controller) flushInstanceState() {
+	c.persistStateWG.Wait()
+}
+
+// persistPendingStates saves pending states until there are no more left
+func (c *Controller) persistPendingStates() {
+	defer c.persistStateWG.Done()
+	for {
+		c.persistStateLock.Lock()
+		state := c.pendingState
+		c.pendingState = nil
+		if state == nil {
+			c.persistingState = false
+			c.persistStateLock.Unlock()
+			return
+		}
+		c.persistStateLock.Unlock()
+
+		if err := c.InstanceStorage.SaveCurrentInstance(c.GetIdentifier(), state); err != nil {
+			c.Logger.Warn("could not save instance state", zap.Error(err))
+		}
+	}
 }
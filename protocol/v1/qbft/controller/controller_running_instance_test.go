@@ -0,0 +1,379 @@
+package controller
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
+)
+
+// changeRoundInstance is a running instance that counts round change broadcasts
+type changeRoundInstance struct {
+	instance.Instancer
+	state               *qbft.State
+	highestRoundTimeout time.Duration
+	broadcasts          int32
+}
+
+func (i *changeRoundInstance) GetState() *qbft.State {
+	return i.state
+}
+
+func (i *changeRoundInstance) ResetRoundTimer() {}
+
+func (i *changeRoundInstance) BroadcastChangeRound() error {
+	atomic.AddInt32(&i.broadcasts, 1)
+	return nil
+}
+
+func (i *changeRoundInstance) HighestRoundTimeoutSeconds() time.Duration {
+	return i.highestRoundTimeout
+}
+
+func (i *changeRoundInstance) CommittedAggregatedMsg() (*specqbft.SignedMessage, error) {
+	return nil, errors.New("no aggregated commit")
+}
+
+func (i *changeRoundInstance) Stop() {}
+
+// lastChangeRoundNetwork counts fetches of the last change round,
+// fetches are blocked until release is closed (if set)
+type lastChangeRoundNetwork struct {
+	p2pprotocol.Network
+	fetches     int32
+	inFlight    int32
+	maxInFlight int32
+	release     chan struct{}
+}
+
+func (n *lastChangeRoundNetwork) LastChangeRound(mid spectypes.MessageID, height specqbft.Height) ([]p2pprotocol.SyncResult, error) {
+	atomic.AddInt32(&n.fetches, 1)
+	inFlight := atomic.AddInt32(&n.inFlight, 1)
+	defer atomic.AddInt32(&n.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&n.maxInFlight)
+		if inFlight <= max || atomic.CompareAndSwapInt32(&n.maxInFlight, max, inFlight) {
+			break
+		}
+	}
+	if n.release != nil {
+		<-n.release
+	}
+	return nil, nil
+}
+
+func TestController_HighestRoundCatchup(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+
+	for _, disabled := range []bool{false, true} {
+		name := "enabled"
+		if disabled {
+			name = "disabled"
+		}
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			network := &lastChangeRoundNetwork{}
+			c := &Controller{
+				Ctx:                        ctx,
+				Identifier:                 identifier[:],
+				Logger:                     zap.L(),
+				Network:                    network,
+				CurrentInstanceLock:        &sync.RWMutex{},
+				disableHighestRoundCatchup: disabled,
+			}
+			inst := &changeRoundInstance{state: &qbft.State{}, highestRoundTimeout: 10 * time.Millisecond}
+			c.SetCurrentInstance(inst)
+
+			stop, err := c.instanceStageChange(qbft.RoundStateChangeRound)
+			require.NoError(t, err)
+			require.False(t, stop)
+			// round change is broadcasted in both cases
+			require.Equal(t, int32(1), atomic.LoadInt32(&inst.broadcasts))
+
+			if disabled {
+				require.Nil(t, c.highestRoundCtxCancel)
+				time.Sleep(100 * time.Millisecond)
+				require.Equal(t, int32(0), atomic.LoadInt32(&network.fetches))
+				return
+			}
+			require.NotNil(t, c.highestRoundCtxCancel)
+			require.Eventually(t, func() bool {
+				return atomic.LoadInt32(&network.fetches) > 0
+			}, time.Second, 10*time.Millisecond)
+			c.highestRoundCtxCancel()
+		})
+	}
+}
+
+func TestController_HighestRoundCancelledOnInstanceDone(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+
+	for _, stage := range []qbft.RoundState{qbft.RoundStateDecided, qbft.RoundStateStopped} {
+		t.Run(qbft.RoundStateName[int32(stage)], func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			network := &lastChangeRoundNetwork{}
+			c := &Controller{
+				Ctx:                 ctx,
+				Identifier:          identifier[:],
+				Logger:              zap.L(),
+				Network:             network,
+				CurrentInstanceLock: &sync.RWMutex{},
+			}
+			c.SetCurrentInstance(&changeRoundInstance{state: &qbft.State{}, highestRoundTimeout: 10 * time.Millisecond})
+
+			_, err := c.instanceStageChange(qbft.RoundStateChangeRound)
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				return atomic.LoadInt32(&network.fetches) > 0
+			}, time.Second, 5*time.Millisecond)
+
+			// the instance is done, decided failed to be saved in case of decided stage
+			stop, _ := c.instanceStageChange(stage)
+			require.True(t, stop)
+			require.Nil(t, c.highestRoundCtxCancel)
+
+			// waiting for in-flight catchups, and then making sure no more catchups are triggered
+			time.Sleep(20 * time.Millisecond)
+			fetches := atomic.LoadInt32(&network.fetches)
+			time.Sleep(100 * time.Millisecond)
+			require.Equal(t, fetches, atomic.LoadInt32(&network.fetches))
+		})
+	}
+}
+
+func TestController_FastChangeRoundCatchupNoOverlap(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	network := &lastChangeRoundNetwork{release: make(chan struct{})}
+	c := &Controller{
+		Ctx:                 ctx,
+		Identifier:          identifier[:],
+		Logger:              zap.L(),
+		Network:             network,
+		CurrentInstanceLock: &sync.RWMutex{},
+	}
+	inst := &changeRoundInstance{state: &qbft.State{}}
+	c.SetCurrentInstance(inst)
+
+	// many ticks while the first catchup is in flight
+	go c.highestRound(ctx, time.Millisecond)
+	for i := 0; i < 10; i++ {
+		go c.fastChangeRoundCatchup(inst)
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&network.fetches) > 0
+	}, time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(1), atomic.LoadInt32(&network.fetches))
+
+	// catchups of other instances are not blocked
+	other := &changeRoundInstance{state: &qbft.State{}}
+	go c.fastChangeRoundCatchup(other)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&network.fetches) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// once released, following ticks trigger new catchups, one at a time
+	close(network.release)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&network.fetches) > 3
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+	require.LessOrEqual(t, atomic.LoadInt32(&network.maxInFlight), int32(2))
+}
+
+// aggregatedInstance is a decided instance that holds the given aggregated commit
+type aggregatedInstance struct {
+	instance.Instancer
+	agg *specqbft.SignedMessage
+}
+
+func (i *aggregatedInstance) CommittedAggregatedMsg() (*specqbft.SignedMessage, error) {
+	if i.agg == nil {
+		return nil, errors.New("missing decided message")
+	}
+	return i.agg, nil
+}
+
+func (i *aggregatedInstance) GetState() *qbft.State {
+	return nil
+}
+
+func (i *aggregatedInstance) Stop() {}
+
+// readCountingStrategy counts reads of decided messages
+type readCountingStrategy struct {
+	strategy.Decided
+	stored []*specqbft.SignedMessage
+	reads  int
+}
+
+func (s *readCountingStrategy) GetDecided(identifier []byte, heightRange ...specqbft.Height) ([]*specqbft.SignedMessage, error) {
+	s.reads++
+	return s.stored, nil
+}
+
+func (s *readCountingStrategy) UpdateDecided(msg *specqbft.SignedMessage) (*specqbft.SignedMessage, error) {
+	return nil, nil
+}
+
+func TestController_DecidedResult(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	decided := func(height specqbft.Height, signers ...spectypes.OperatorID) *specqbft.SignedMessage {
+		return &specqbft.SignedMessage{
+			Signature: []byte("signature"),
+			Signers:   signers,
+			Message: &specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     height,
+				Round:      1,
+				Identifier: identifier[:],
+			},
+		}
+	}
+	stored := decided(2, 1, 2, 3, 4)
+
+	tests := []struct {
+		name          string
+		agg           *specqbft.SignedMessage
+		expected      *specqbft.SignedMessage
+		expectedReads int
+	}{
+		{"in memory", decided(2, 1, 2, 3), decided(2, 1, 2, 3), 0},
+		{"missing in memory", nil, stored, 1},
+		{"different height in memory", decided(1, 1, 2, 3), stored, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &readCountingStrategy{stored: []*specqbft.SignedMessage{stored}}
+			c := &Controller{
+				Identifier:      identifier[:],
+				Logger:          zap.L(),
+				DecidedStrategy: s,
+			}
+			res, err := c.decidedResult(&aggregatedInstance{agg: test.agg}, 2)
+			require.NoError(t, err)
+			require.True(t, res.Decided)
+			require.Equal(t, test.expected, res.Msg)
+			require.Equal(t, test.expectedReads, s.reads)
+		})
+	}
+
+	t.Run("missing in storage", func(t *testing.T) {
+		c := &Controller{
+			Identifier:      identifier[:],
+			Logger:          zap.L(),
+			DecidedStrategy: &readCountingStrategy{},
+		}
+		_, err := c.decidedResult(&aggregatedInstance{}, 2)
+		require.EqualError(t, err, "could not fetch decided msg with height 2 after instance finished")
+	})
+}
+
+func TestController_PurgeLateMessages(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	q, err := msgqueue.New(zap.L(), msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer()))
+	require.NoError(t, err)
+	c := &Controller{
+		Ctx:                context.Background(),
+		Identifier:         identifier[:],
+		Logger:             zap.L(),
+		Q:                  q,
+		lateMessagesWindow: 50 * time.Millisecond,
+	}
+	addMsg := func(msgType spectypes.MsgType, qbftMsgType specqbft.MessageType, height specqbft.Height) {
+		signed := &specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{1},
+			Message: &specqbft.Message{
+				MsgType:    qbftMsgType,
+				Height:     height,
+				Round:      1,
+				Identifier: identifier[:],
+				Data:       []byte("data"),
+			},
+		}
+		data, err := signed.Encode()
+		require.NoError(t, err)
+		q.Add(&spectypes.SSVMessage{
+			MsgType: msgType,
+			MsgID:   identifier,
+			Data:    data,
+		})
+	}
+	addMsg(spectypes.SSVConsensusMsgType, specqbft.CommitMsgType, 2)  // late commit
+	addMsg(spectypes.SSVConsensusMsgType, specqbft.PrepareMsgType, 3) // next instance
+	addMsg(spectypes.SSVDecidedMsgType, specqbft.CommitMsgType, 2)    // decided
+
+	lateMessagesCleaned := func() float64 {
+		return testutil.ToFloat64(metricsQueueCleaned.WithLabelValues(queueCleanReasonLateMessages))
+	}
+	before := lateMessagesCleaned()
+	decided := &specqbft.SignedMessage{Message: &specqbft.Message{Height: 2}}
+	c.afterInstance(2, &instance.Result{Decided: true, Msg: decided}, nil)
+
+	// late commit and decided are retained during the window,
+	// the decided msg is indexed twice (by height and in the decided index)
+	require.Equal(t, 4, q.Len())
+	require.Eventually(t, func() bool {
+		return q.Len() == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, before+2, lateMessagesCleaned())
+	require.Equal(t, 0, q.Count(msgqueue.SignedMsgIndex(spectypes.SSVConsensusMsgType, hex.EncodeToString(identifier[:]), 2, specqbft.CommitMsgType)[0]))
+	require.Equal(t, 0, q.Count(msgqueue.SignedMsgIndex(spectypes.SSVDecidedMsgType, hex.EncodeToString(identifier[:]), 2, specqbft.CommitMsgType)[0]))
+	require.Len(t, q.Pop(1, msgqueue.DecidedMsgIndex(hex.EncodeToString(identifier[:]))), 1)
+	require.Len(t, q.Pop(1, msgqueue.SignedMsgIndex(spectypes.SSVConsensusMsgType, hex.EncodeToString(identifier[:]), 3, specqbft.PrepareMsgType)[0]), 1)
+}
+
+func TestController_DecidedLogTraceID(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	duty := &spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: 12}
+	traceID := logfields.DutyTraceID(duty)
+	core, logs := observer.New(zap.DebugLevel)
+	c := &Controller{
+		Identifier:          identifier[:],
+		Logger:              zap.New(core),
+		CurrentInstanceLock: &sync.RWMutex{},
+		DecidedStrategy:     &readCountingStrategy{},
+		traceID:             traceID,
+	}
+	c.SetCurrentInstance(&aggregatedInstance{agg: &specqbft.SignedMessage{
+		Signature: []byte("signature"),
+		Signers:   []spectypes.OperatorID{1, 2, 3},
+		Message: &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     2,
+			Round:      1,
+			Identifier: identifier[:],
+		},
+	}})
+
+	stop, err := c.instanceStageChange(qbft.RoundStateDecided)
+	require.NoError(t, err)
+	require.False(t, stop)
+
+	entries := logs.FilterMessage("decided current instance").All()
+	require.Len(t, entries, 1)
+	require.Equal(t, traceID, entries[0].ContextMap()[logfields.TraceIDKey])
+}
@@ -0,0 +1,866 @@
+package controller
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/controller/forks"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy/factory"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy/lightnode"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/signedmsg"
+	testingprotocol "github.com/bloxapp/ssv/protocol/v1/testing"
+)
+
+func TestController_InstanceRecovery(t *testing.T) {
+	uids := []spectypes.OperatorID{1, 2, 3, 4}
+	sks, nodes := testingprotocol.GenerateBLSKeys(uids...)
+	share := &beacon.Share{
+		NodeID:    1,
+		PublicKey: sks[1].GetPublicKey(),
+		Committee: nodes,
+	}
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	instanceOpts := func(height specqbft.Height, recovered *qbft.State) *instance.Options {
+		return &instance.Options{
+			Logger:         zap.L(),
+			ValidatorShare: share,
+			Config:         qbft.DefaultConsensusParams(),
+			Identifier:     identifier[:],
+			Height:         height,
+			RecoveredState: recovered,
+		}
+	}
+
+	// the instance was prepared in round 2 and then the node crashed, before the instance was decided
+	crashed := instance.NewInstance(instanceOpts(5, nil))
+	crashed.GetState().Round.Store(specqbft.Round(2))
+	crashed.GetState().PreparedRound.Store(specqbft.Round(2))
+	crashed.GetState().PreparedValue.Store([]byte("value"))
+	require.NoError(t, store.SaveCurrentInstance(identifier[:], crashed.GetState()))
+	crashed.Stop()
+
+	c := &Controller{
+		Identifier:      identifier[:],
+		InstanceStorage: store,
+		Logger:          zap.L(),
+	}
+
+	t.Run("resume prepared instance", func(t *testing.T) {
+		saved := c.savedInstanceState(5)
+		require.NotNil(t, saved)
+
+		resumed := instance.NewInstance(instanceOpts(5, saved))
+		defer resumed.Stop()
+		require.Equal(t, specqbft.Round(2), resumed.GetState().GetRound())
+		require.Equal(t, specqbft.Round(2), resumed.GetState().GetPreparedRound())
+		require.Equal(t, []byte("value"), resumed.GetState().GetPreparedValue())
+	})
+
+	t.Run("different height", func(t *testing.T) {
+		require.Nil(t, c.savedInstanceState(6))
+
+		// saved state of another height is ignored by the instance as well
+		fresh := instance.NewInstance(instanceOpts(6, crashed.GetState()))
+		defer fresh.Stop()
+		require.Equal(t, specqbft.Round(0), fresh.GetState().GetRound())
+		require.Len(t, fresh.GetState().GetPreparedValue(), 0)
+	})
+
+	t.Run("not prepared", func(t *testing.T) {
+		notPrepared := instance.NewInstance(instanceOpts(7, nil))
+		notPrepared.GetState().Round.Store(specqbft.Round(1))
+		require.NoError(t, store.SaveCurrentInstance(identifier[:], notPrepared.GetState()))
+		notPrepared.Stop()
+
+		require.Nil(t, c.savedInstanceState(7))
+	})
+}
+
+// blockingInstanceStore blocks instance state writes until released
+type blockingInstanceStore struct {
+	qbftstorage.QBFTStore
+	release chan struct{}
+}
+
+func (s *blockingInstanceStore) SaveCurrentInstance(identifier []byte, state *qbft.State) error {
+	<-s.release
+	return s.QBFTStore.SaveCurrentInstance(identifier, state)
+}
+
+// decidedInstance is a running instance that was already decided
+type decidedInstance struct {
+	instance.Instancer
+	state   *qbft.State
+	decided *specqbft.SignedMessage
+}
+
+func (i *decidedInstance) GetState() *qbft.State {
+	return i.state
+}
+
+func (i *decidedInstance) CommittedAggregatedMsg() (*specqbft.SignedMessage, error) {
+	return i.decided, nil
+}
+
+func (i *decidedInstance) Stop() {}
+
+func TestController_AsyncStatePersistence(t *testing.T) {
+	uids := []spectypes.OperatorID{1, 2, 3, 4}
+	sks, nodes := testingprotocol.GenerateBLSKeys(uids...)
+	share := &beacon.Share{
+		NodeID:    1,
+		PublicKey: sks[1].GetPublicKey(),
+		Committee: nodes,
+	}
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	qbftStore := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	store := &blockingInstanceStore{QBFTStore: qbftStore, release: make(chan struct{})}
+
+	running := instance.NewInstance(&instance.Options{
+		Logger:         zap.L(),
+		ValidatorShare: share,
+		Config:         qbft.DefaultConsensusParams(),
+		Identifier:     identifier[:],
+		Height:         5,
+	})
+	defer running.Stop()
+	running.GetState().Round.Store(specqbft.Round(1))
+	running.GetState().PreparedRound.Store(specqbft.Round(1))
+	running.GetState().PreparedValue.Store([]byte("value"))
+
+	c := &Controller{
+		Identifier:            identifier[:],
+		InstanceStorage:       store,
+		DecidedStrategy:       lightnode.NewLightNodeStrategy(zap.L(), qbftStore, nil),
+		Logger:                zap.L(),
+		ReadMode:              true,
+		CurrentInstanceLock:   &sync.RWMutex{},
+		asyncStatePersistence: true,
+	}
+	c.SetCurrentInstance(&decidedInstance{
+		state: running.GetState(),
+		decided: &specqbft.SignedMessage{
+			Signature: []byte("signature"),
+			Signers:   []spectypes.OperatorID{1, 2, 3},
+			Message: &specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     5,
+				Round:      1,
+				Identifier: identifier[:],
+				Data:       []byte("value"),
+			},
+		},
+	})
+
+	// prepare is not blocked by the (blocked) state write
+	exit, err := c.instanceStageChange(qbft.RoundStatePrepare)
+	require.NoError(t, err)
+	require.False(t, exit)
+	_, found, err := qbftStore.GetCurrentInstance(identifier[:])
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// decided is durable once the stage was processed, regardless of the pending state write
+	_, err = c.instanceStageChange(qbft.RoundStateDecided)
+	require.NoError(t, err)
+	decided, err := qbftStore.GetLastDecided(identifier[:])
+	require.NoError(t, err)
+	require.NotNil(t, decided)
+	require.Equal(t, specqbft.Height(5), decided.Message.Height)
+
+	// closing the controller waits for the pending state to be saved
+	closed := make(chan struct{})
+	go func() {
+		require.NoError(t, c.Close())
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("controller was closed before the pending state was saved")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(store.release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("controller was not closed")
+	}
+	state, found, err := qbftStore.GetCurrentInstance(identifier[:])
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), state.GetPreparedValue())
+
+	// the saved state is flushed before it's read upon instance start
+	running.GetState().PreparedValue.Store([]byte("value2"))
+	exit, err = c.instanceStageChange(qbft.RoundStatePrepare)
+	require.NoError(t, err)
+	require.False(t, exit)
+	recovered := c.savedInstanceState(5)
+	require.NotNil(t, recovered)
+	require.Equal(t, []byte("value2"), recovered.GetPreparedValue())
+}
+
+func TestController_DecidedWriteMetric(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleProposer)
+	store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	c := &Controller{
+		Identifier:          identifier[:],
+		DecidedStrategy:     lightnode.NewLightNodeStrategy(zap.L(), store, nil),
+		Logger:              zap.L(),
+		ReadMode:            true,
+		CurrentInstanceLock: &sync.RWMutex{},
+	}
+	c.SetCurrentInstance(&decidedInstance{
+		state: &qbft.State{},
+		decided: &specqbft.SignedMessage{
+			Signature: []byte("signature"),
+			Signers:   []spectypes.OperatorID{1, 2, 3},
+			Message: &specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     1,
+				Round:      1,
+				Identifier: identifier[:],
+				Data:       []byte("value"),
+			},
+		},
+	})
+
+	samples := func() uint64 {
+		m := &dto.Metric{}
+		observer := metricsDecidedWriteDuration.WithLabelValues(spectypes.BNRoleProposer.String())
+		require.NoError(t, observer.(prometheus.Histogram).Write(m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	before := samples()
+
+	_, err := c.instanceStageChange(qbft.RoundStateDecided)
+	require.NoError(t, err)
+	require.Equal(t, before+1, samples())
+}
+
+func TestController_DecidedValueMismatch(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	proposalData, err := (&specqbft.ProposalData{Data: []byte("value")}).Encode()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		commitValue []byte
+		expectedErr string
+	}{
+		{
+			name:        "matching value",
+			commitValue: []byte("value"),
+		},
+		{
+			name:        "mismatched value",
+			commitValue: []byte("other value"),
+			expectedErr: "invalid decided value: decided value does not match the accepted proposal value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+			c := &Controller{
+				Identifier:          identifier[:],
+				DecidedStrategy:     lightnode.NewLightNodeStrategy(zap.L(), store, nil),
+				Logger:              zap.L(),
+				ReadMode:            true,
+				CurrentInstanceLock: &sync.RWMutex{},
+			}
+			commitData, err := (&specqbft.CommitData{Data: test.commitValue}).Encode()
+			require.NoError(t, err)
+
+			state := &qbft.State{}
+			state.ProposalAcceptedForCurrentRound.Store(&specqbft.SignedMessage{
+				Signers: []spectypes.OperatorID{1},
+				Message: &specqbft.Message{
+					MsgType:    specqbft.ProposalMsgType,
+					Height:     1,
+					Round:      1,
+					Identifier: identifier[:],
+					Data:       proposalData,
+				},
+			})
+			c.SetCurrentInstance(&decidedInstance{
+				state: state,
+				decided: &specqbft.SignedMessage{
+					Signature: []byte("signature"),
+					Signers:   []spectypes.OperatorID{1, 2, 3},
+					Message: &specqbft.Message{
+						MsgType:    specqbft.CommitMsgType,
+						Height:     1,
+						Round:      1,
+						Identifier: identifier[:],
+						Data:       commitData,
+					},
+				},
+			})
+
+			exit, err := c.instanceStageChange(qbft.RoundStateDecided)
+			decided, storeErr := store.GetLastDecided(identifier[:])
+			require.NoError(t, storeErr)
+			if len(test.expectedErr) > 0 {
+				require.EqualError(t, err, test.expectedErr)
+				require.True(t, exit)
+				require.Nil(t, decided)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, exit)
+			require.NotNil(t, decided)
+		})
+	}
+}
+
+func TestController_GetDecidedValue(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	c := &Controller{
+		Identifier:      identifier[:],
+		DecidedStrategy: lightnode.NewLightNodeStrategy(zap.L(), store, nil),
+		Logger:          zap.L(),
+	}
+
+	value, found, err := c.GetDecidedValue(3)
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Nil(t, value)
+
+	commitData, err := (&specqbft.CommitData{Data: []byte("value")}).Encode()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveLastDecided(&specqbft.SignedMessage{
+		Signature: []byte("signature"),
+		Signers:   []spectypes.OperatorID{1, 2, 3},
+		Message: &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     3,
+			Round:      1,
+			Identifier: identifier[:],
+			Data:       commitData,
+		},
+	}))
+
+	value, found, err = c.GetDecidedValue(3)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), value)
+
+	_, found, err = c.GetDecidedValue(4)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestOptions_MinPeers(t *testing.T) {
+	opts := Options{
+		MinPeers: 2,
+		RoleMinPeers: map[spectypes.BeaconRole]int{
+			spectypes.BNRoleSyncCommittee: 1,
+			spectypes.BNRoleProposer:      0,
+		},
+	}
+
+	opts.Role = spectypes.BNRoleSyncCommittee
+	require.Equal(t, 1, opts.minPeers())
+	opts.Role = spectypes.BNRoleAttester
+	require.Equal(t, 2, opts.minPeers())
+	// non-positive values fall back to the global minimum
+	opts.Role = spectypes.BNRoleProposer
+	require.Equal(t, 2, opts.minPeers())
+}
+
+// lastDecidedNetwork returns the given last decided heights
+type lastDecidedNetwork struct {
+	p2pprotocol.Network
+	heights []specqbft.Height
+}
+
+func (n *lastDecidedNetwork) LastDecided(mid spectypes.MessageID) ([]p2pprotocol.SyncResult, error) {
+	var results []p2pprotocol.SyncResult
+	for i, height := range n.heights {
+		data, err := (&message.SyncMessage{
+			Protocol: message.LastDecidedType,
+			Status:   message.StatusSuccess,
+			Data: []*specqbft.SignedMessage{{
+				Message: &specqbft.Message{Height: height, Identifier: mid[:]},
+			}},
+		}).Encode()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, p2pprotocol.SyncResult{
+			Msg:    &spectypes.SSVMessage{MsgType: message.SSVSyncMsgType, MsgID: mid, Data: data},
+			Sender: string(rune('a' + i)),
+		})
+	}
+	return results, nil
+}
+
+// syncCountingStrategy counts the calls to Sync
+type syncCountingStrategy struct {
+	strategy.Decided
+	syncs int
+}
+
+func (s *syncCountingStrategy) Sync(ctx context.Context, identifier []byte, from, to *specqbft.SignedMessage) ([]*specqbft.SignedMessage, error) {
+	s.syncs++
+	return nil, nil
+}
+
+func TestController_SyncHistoryAtHead(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	known := &specqbft.SignedMessage{
+		Message: &specqbft.Message{Height: 10, Identifier: identifier[:]},
+	}
+
+	tests := []struct {
+		name         string
+		known        *specqbft.SignedMessage
+		peerHeights  []specqbft.Height
+		expectedSync bool
+	}{
+		{
+			name:         "already at head",
+			known:        known,
+			peerHeights:  []specqbft.Height{10, 10, 10},
+			expectedSync: false,
+		},
+		{
+			name:         "behind head",
+			known:        known,
+			peerHeights:  []specqbft.Height{12, 12},
+			expectedSync: true,
+		},
+		{
+			name:         "peers disagree on head",
+			known:        known,
+			peerHeights:  []specqbft.Height{10, 12},
+			expectedSync: true,
+		},
+		{
+			name:         "no peers results",
+			known:        known,
+			expectedSync: true,
+		},
+		{
+			name:         "nothing known",
+			peerHeights:  []specqbft.Height{0},
+			expectedSync: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decidedStrategy := &syncCountingStrategy{}
+			c := &Controller{
+				Ctx:             context.Background(),
+				Identifier:      identifier[:],
+				Logger:          zap.L(),
+				Network:         &lastDecidedNetwork{heights: test.peerHeights},
+				DecidedStrategy: decidedStrategy,
+				ForkLock:        &sync.Mutex{},
+			}
+			require.NoError(t, c.syncHistory(test.known))
+			if test.expectedSync {
+				require.Equal(t, 1, decidedStrategy.syncs)
+			} else {
+				require.Equal(t, 0, decidedStrategy.syncs)
+			}
+		})
+	}
+}
+
+// blockingSyncStrategy blocks Sync calls until released
+type blockingSyncStrategy struct {
+	strategy.Decided
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSyncStrategy) Sync(ctx context.Context, identifier []byte, from, to *specqbft.SignedMessage) ([]*specqbft.SignedMessage, error) {
+	s.started <- struct{}{}
+	<-s.release
+	return nil, nil
+}
+
+func TestController_ConcurrentSyncs(t *testing.T) {
+	newController := func(identifier spectypes.MessageID, decidedStrategy strategy.Decided) *Controller {
+		return &Controller{
+			Ctx:             context.Background(),
+			Identifier:      identifier[:],
+			Logger:          zap.L(),
+			DecidedStrategy: decidedStrategy,
+			ForkLock:        &sync.Mutex{},
+		}
+	}
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	blocking := &blockingSyncStrategy{started: make(chan struct{}, 1), release: make(chan struct{})}
+	c := newController(identifier, blocking)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		require.NoError(t, c.syncDecided(nil, nil))
+	}()
+	<-blocking.started
+
+	// can't sync while the first sync is running
+	require.ErrorIs(t, c.syncDecided(nil, nil), ErrAlreadyRunning)
+
+	// other identifiers are not affected
+	other := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleProposer)
+	require.NoError(t, newController(other, &syncCountingStrategy{}).syncDecided(nil, nil))
+
+	close(blocking.release)
+	wg.Wait()
+
+	// sync is possible once the running sync is done
+	decidedStrategy := &syncCountingStrategy{}
+	c.DecidedStrategy = decidedStrategy
+	require.NoError(t, c.syncDecided(nil, nil))
+	require.Equal(t, 1, decidedStrategy.syncs)
+}
+
+// postFork is a controller fork that is not the genesis fork
+type postFork struct {
+	forks.Fork
+}
+
+func (f *postFork) VersionName() string {
+	return "post-fork"
+}
+
+func TestController_GetNodeMode(t *testing.T) {
+	// the node mode is set once at startup by the full node option
+	tests := []struct {
+		name     string
+		fullNode bool
+		expected strategy.Mode
+	}{
+		{"light node", false, strategy.ModeLightNode},
+		{"full node", true, strategy.ModeFullNode},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c := New(Options{
+				Context:        context.Background(),
+				Logger:         zap.L(),
+				Version:        forksprotocol.GenesisForkVersion,
+				FullNode:       test.fullNode,
+				ValidatorShare: &beacon.Share{},
+			}).(*Controller)
+			require.Equal(t, test.expected, c.GetNodeMode())
+		})
+	}
+}
+
+func TestController_DecidedStrategyMetric(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAggregator)
+	store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	strategyGauge := func(mode strategy.Mode) float64 {
+		return testutil.ToFloat64(metricsDecidedStrategy.WithLabelValues(spectypes.BNRoleAggregator.String(),
+			hex.EncodeToString(identifier.GetPubKey()), mode.String()))
+	}
+
+	for _, mode := range []strategy.Mode{strategy.ModeLightNode, strategy.ModeFullNode} {
+		t.Run(mode.String(), func(t *testing.T) {
+			c := &Controller{
+				Identifier:     identifier[:],
+				Logger:         zap.L(),
+				DecidedFactory: factory.NewDecidedFactory(zap.L(), mode, store, nil),
+			}
+			c.onDecidedStrategyChange()
+
+			require.Equal(t, float64(1), strategyGauge(mode))
+			other := strategy.ModeFullNode
+			if mode == strategy.ModeFullNode {
+				other = strategy.ModeLightNode
+			}
+			require.Equal(t, float64(0), strategyGauge(other))
+		})
+	}
+}
+
+func TestController_ProcessMsgIdentifier(t *testing.T) {
+	q, err := msgqueue.New(zap.L(), msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer()))
+	require.NoError(t, err)
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	c := &Controller{
+		Identifier:          identifier[:],
+		Logger:              zap.L(),
+		Q:                   q,
+		CurrentInstanceLock: &sync.RWMutex{},
+	}
+	msg := func(id spectypes.MessageID) *spectypes.SSVMessage {
+		signed := &specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{1},
+			Message: &specqbft.Message{
+				MsgType:    specqbft.PrepareMsgType,
+				Height:     1,
+				Round:      1,
+				Identifier: id[:],
+				Data:       []byte("data"),
+			},
+		}
+		data, err := signed.Encode()
+		require.NoError(t, err)
+		return &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   id,
+			Data:    data,
+		}
+	}
+
+	// messages of other validators or roles are rejected w/o being queued
+	for _, wrong := range []spectypes.MessageID{
+		spectypes.NewMsgID([]byte("Identifier_12"), spectypes.BNRoleAttester),
+		spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleProposer),
+	} {
+		err := c.ProcessMsg(msg(wrong))
+		var mismatchErr *signedmsg.IdentifierMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		require.Equal(t, wrong[:], mismatchErr.Actual)
+	}
+	require.Equal(t, 0, q.Len())
+
+	require.NoError(t, c.ProcessMsg(msg(identifier)))
+	require.Equal(t, 1, q.Len())
+}
+
+func TestController_QueueCleanedMetric(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	q, err := msgqueue.New(zap.L(), msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer()))
+	require.NoError(t, err)
+	c := &Controller{
+		Identifier:          identifier[:],
+		Logger:              zap.L(),
+		Q:                   q,
+		CurrentInstanceLock: &sync.RWMutex{},
+		ForkLock:            &sync.Mutex{},
+		DecidedFactory:      factory.NewDecidedFactory(zap.L(), strategy.ModeLightNode, store, nil),
+	}
+	addMsg := func(msgType specqbft.MessageType, height specqbft.Height) {
+		signed := &specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{1},
+			Message: &specqbft.Message{
+				MsgType:    msgType,
+				Height:     height,
+				Round:      1,
+				Identifier: identifier[:],
+				Data:       []byte("data"),
+			},
+		}
+		data, err := signed.Encode()
+		require.NoError(t, err)
+		q.Add(&spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   identifier,
+			Data:    data,
+		})
+	}
+	cleanedCounter := func(reason string) float64 {
+		return testutil.ToFloat64(metricsQueueCleaned.WithLabelValues(reason))
+	}
+
+	t.Run("after instance", func(t *testing.T) {
+		before, forkBefore := cleanedCounter(queueCleanReasonAfterInstance), cleanedCounter(queueCleanReasonFork)
+		addMsg(specqbft.PrepareMsgType, 1)
+		addMsg(specqbft.PrepareMsgType, 2)
+		addMsg(specqbft.CommitMsgType, 2) // late commit is kept
+		addMsg(specqbft.PrepareMsgType, 3)
+
+		c.afterInstance(2, nil, errors.New("timeout"))
+		require.Equal(t, before+2, cleanedCounter(queueCleanReasonAfterInstance))
+		require.Equal(t, forkBefore, cleanedCounter(queueCleanReasonFork))
+	})
+
+	t.Run("fork", func(t *testing.T) {
+		before, afterInstanceBefore := cleanedCounter(queueCleanReasonFork), cleanedCounter(queueCleanReasonAfterInstance)
+		cleaned := int64(q.Len())
+		require.Greater(t, cleaned, int64(0))
+
+		require.NoError(t, c.OnFork(forksprotocol.GenesisForkVersion))
+		require.Equal(t, before+float64(cleaned), cleanedCounter(queueCleanReasonFork))
+		require.Equal(t, afterInstanceBefore, cleanedCounter(queueCleanReasonAfterInstance))
+		require.Equal(t, 0, q.Len())
+	})
+}
+
+func TestController_QueueLenMetric(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	q, err := msgqueue.New(zap.L(), msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer()))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := &Controller{
+		Ctx:                 ctx,
+		Identifier:          identifier[:],
+		Logger:              zap.L(),
+		Q:                   q,
+		CurrentInstanceLock: &sync.RWMutex{},
+	}
+	queueLen := func() float64 {
+		return testutil.ToFloat64(metricsQueueLen.WithLabelValues(spectypes.BNRoleAttester.String(), hex.EncodeToString(identifier.GetPubKey())))
+	}
+	commit := func() *spectypes.SSVMessage {
+		signed := &specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{1},
+			Message: &specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     0,
+				Round:      1,
+				Identifier: identifier[:],
+				Data:       []byte("data"),
+			},
+		}
+		data, err := signed.Encode()
+		require.NoError(t, err)
+		return &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   identifier,
+			Data:    data,
+		}
+	}
+
+	// pushed messages are counted
+	require.NoError(t, c.ProcessMsg(commit()))
+	require.NoError(t, c.ProcessMsg(commit()))
+	require.Equal(t, float64(2), queueLen())
+
+	// popped messages are not
+	var handled int32
+	go func() {
+		_ = c.ConsumeQueue(func(msg *spectypes.SSVMessage) error {
+			atomic.AddInt32(&handled, 1)
+			return nil
+		}, time.Millisecond*10)
+	}()
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&handled) == 2
+	}, time.Second*2, time.Millisecond*10)
+	require.Equal(t, float64(0), queueLen())
+}
+
+func TestController_OnForkUnknownVersion(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	q, err := msgqueue.New(zap.L(), msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer()))
+	require.NoError(t, err)
+	c := &Controller{
+		Identifier:          identifier[:],
+		Logger:              zap.L(),
+		Q:                   q,
+		CurrentInstanceLock: &sync.RWMutex{},
+		ForkLock:            &sync.Mutex{},
+		State:               Ready,
+	}
+	signed := &specqbft.SignedMessage{
+		Signature: []byte("sig"),
+		Signers:   []spectypes.OperatorID{1},
+		Message: &specqbft.Message{
+			MsgType:    specqbft.PrepareMsgType,
+			Height:     1,
+			Round:      1,
+			Identifier: identifier[:],
+			Data:       []byte("data"),
+		},
+	}
+	data, err := signed.Encode()
+	require.NoError(t, err)
+	q.Add(&spectypes.SSVMessage{
+		MsgType: spectypes.SSVConsensusMsgType,
+		MsgID:   identifier,
+		Data:    data,
+	})
+	inst := &decidedInstance{}
+	c.SetCurrentInstance(inst)
+
+	err = c.OnFork("unknown")
+	require.ErrorIs(t, err, forksprotocol.ErrUnknownForkVersion)
+	require.Equal(t, Ready, atomic.LoadUint32(&c.State))
+	require.Equal(t, 1, q.Len())
+	require.Nil(t, c.Fork)
+	require.Equal(t, inst, c.GetCurrentInstance())
+}
+
+func TestController_OnForkRetainsFailedDecided(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("Identifier_11"), spectypes.BNRoleAttester)
+	store := qbftstorage.NewQBFTStore(newInMemDb(), zap.L(), "attestation")
+	q, err := msgqueue.New(zap.L(), msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer()))
+	require.NoError(t, err)
+	c := &Controller{
+		Identifier:          identifier[:],
+		Logger:              zap.L(),
+		Q:                   q,
+		CurrentInstanceLock: &sync.RWMutex{},
+		ForkLock:            &sync.Mutex{},
+		DecidedFactory:      factory.NewDecidedFactory(zap.L(), strategy.ModeLightNode, store, nil),
+	}
+	newMsg := func(msgType spectypes.MsgType, qbftMsgType specqbft.MessageType, msgIdentifier []byte) *spectypes.SSVMessage {
+		signed := &specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{1, 2, 3},
+			Message: &specqbft.Message{
+				MsgType:    qbftMsgType,
+				Height:     1,
+				Round:      1,
+				Identifier: msgIdentifier,
+				Data:       []byte("data"),
+			},
+		}
+		data, err := signed.Encode()
+		require.NoError(t, err)
+		return &spectypes.SSVMessage{
+			MsgType: msgType,
+			MsgID:   identifier,
+			Data:    data,
+		}
+	}
+	// the inner identifier doesn't match the controller, therefore processing the decided message fails
+	otherIdentifier := spectypes.NewMsgID([]byte("Identifier_12"), spectypes.BNRoleAttester)
+	failingDecided := newMsg(spectypes.SSVDecidedMsgType, specqbft.CommitMsgType, otherIdentifier[:])
+	q.Add(failingDecided)
+	q.Add(newMsg(spectypes.SSVDecidedMsgType, specqbft.CommitMsgType, otherIdentifier[:])) // same decided from another peer
+	q.Add(newMsg(spectypes.SSVConsensusMsgType, specqbft.PrepareMsgType, identifier[:]))
+
+	require.NoError(t, c.OnFork(forksprotocol.GenesisForkVersion))
+
+	// the failed decided message was re-queued once post fork, while other messages were cleaned
+	decidedIdx := msgqueue.DecidedMsgIndex(hex.EncodeToString(identifier[:]))
+	require.Equal(t, 1, q.Count(decidedIdx))
+	require.Equal(t, 0, q.Count(msgqueue.SignedMsgIndex(spectypes.SSVConsensusMsgType, hex.EncodeToString(identifier[:]), 1, specqbft.PrepareMsgType)[0]))
+	msgs := q.Pop(1, decidedIdx)
+	require.Len(t, msgs, 1)
+	require.Equal(t, failingDecided, msgs[0])
+	// the height index of the re-queued decided message is the only one left
+	require.Equal(t, 1, q.Len())
+}
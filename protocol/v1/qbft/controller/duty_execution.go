@@ -9,18 +9,43 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 )
 
 // ProcessPostConsensusMessage aggregates partial signature messages and broadcasting when quorum achieved
 func (c *Controller) ProcessPostConsensusMessage(msg *specssv.SignedPartialSignatureMessage) error {
+	quorum, err := c.collectPostConsensusSignature(msg)
+	if err != nil || quorum == nil {
+		return err
+	}
+	// the signature state is not locked while broadcasting, so a slow beacon node won't block messages of the next duty
+	return c.broadcastSignature(quorum)
+}
+
+// signaturesQuorum is a snapshot of the signature state, taken once enough signatures were collected
+type signaturesQuorum struct {
+	signatures  map[spectypes.OperatorID][]byte
+	root        []byte
+	valueStruct *beaconprotocol.DutyData
+	duty        *spectypes.Duty
+}
+
+// collectPostConsensusSignature adds the partial signature of the given message to the signature state.
+// once quorum is reached, it returns a snapshot of the collected signatures and clears the state
+func (c *Controller) collectPostConsensusSignature(msg *specssv.SignedPartialSignatureMessage) (*signaturesQuorum, error) {
+	// the collection might time out concurrently
+	c.SignatureState.lock.Lock()
+	defer c.SignatureState.lock.Unlock()
+
 	if c.SignatureState.getState() != StateRunning {
 		c.Logger.Warn(
 			"trying to process post consensus signature message but timer state is not running. can't process message.",
 			zap.String("state", c.SignatureState.getState().toString()),
 		)
-		return nil
+		return nil, nil
 	}
 
 	// adjust share committee to the spec
@@ -33,7 +58,7 @@ func (c *Controller) ProcessPostConsensusMessage(msg *specssv.SignedPartialSigna
 	}
 
 	if err := message.ValidatePartialSigMsg(msg, committee, c.SignatureState.duty.Slot); err != nil {
-		return errors.WithMessage(err, "could not validate partial signature message")
+		return nil, errors.WithMessage(err, "could not validate partial signature message")
 	}
 	logger := c.Logger.With(zap.Uint64("signer_id", uint64(msg.GetSigners()[0])))
 	logger.Info("received valid partial signature message",
@@ -45,35 +70,42 @@ func (c *Controller) ProcessPostConsensusMessage(msg *specssv.SignedPartialSigna
 	//	check if already exist, if so, ignore
 	if _, found := c.SignatureState.signatures[msg.GetSigners()[0]]; found {
 		c.Logger.Debug("sig already known, skip")
-		return nil
+		return nil, nil
 	}
 
 	c.SignatureState.signatures[msg.GetSigners()[0]] = msg.Messages[0].PartialSignature
-	if len(c.SignatureState.signatures) >= c.SignatureState.sigCount {
-		c.Logger.Info("collected enough signature to reconstruct",
-			zap.Int("signatures", len(c.SignatureState.signatures)),
-		)
-		c.SignatureState.stopTimer()
+	if len(c.SignatureState.signatures) < c.SignatureState.sigCount {
+		return nil, nil
+	}
+	c.Logger.Info("collected enough signature to reconstruct",
+		zap.Int("signatures", len(c.SignatureState.signatures)),
+	)
+	c.SignatureState.stopTimer()
 
-		// clean queue consensus & default messages that is <= c.signatureState.duty.Slot, we don't need them anymore
-		c.Q.Clean(
-			msgqueue.SignedPostConsensusMsgCleaner(message.ToMessageID(c.Identifier), c.SignatureState.duty.Slot),
-		)
+	// clean queue consensus & default messages that is <= c.signatureState.duty.Slot, we don't need them anymore
+	c.Q.Clean(
+		msgqueue.SignedPostConsensusMsgCleaner(message.ToMessageID(c.Identifier), c.SignatureState.duty.Slot),
+	)
 
-		err := c.broadcastSignature()
-		c.SignatureState.clear()
-		return err
+	quorum := &signaturesQuorum{
+		signatures:  c.SignatureState.signatures,
+		root:        c.SignatureState.root,
+		valueStruct: c.SignatureState.valueStruct,
+		duty:        c.SignatureState.duty,
 	}
-	return nil
+	// the signatures are owned by the snapshot from now on
+	c.SignatureState.signatures = nil
+	c.SignatureState.clear()
+	return quorum, nil
 }
 
 // broadcastSignature reconstruct sigs and broadcast to network
-func (c *Controller) broadcastSignature() error {
+func (c *Controller) broadcastSignature(quorum *signaturesQuorum) error {
 	// Reconstruct signatures
-	if err := c.reconstructAndBroadcastSignature(c.SignatureState.signatures, c.SignatureState.root, c.SignatureState.valueStruct, c.SignatureState.duty); err != nil {
+	if err := c.reconstructAndBroadcastSignature(quorum.signatures, quorum.root, quorum.valueStruct, quorum.duty); err != nil {
 		return errors.Wrap(err, "failed to reconstruct and broadcast signature")
 	}
-	c.Logger.Info("Successfully submitted role!")
+	c.Logger.Info("Successfully submitted role!", logfields.TraceID(logfields.DutyTraceID(quorum.duty)))
 	return nil
 }
 
@@ -93,10 +125,20 @@ func (c *Controller) PostConsensusDutyExecution(logger *zap.Logger, decidedValue
 	}
 
 	//	start timer, clear new map and set var's
-	c.SignatureState.start(c.Logger, signaturesCount, root, valueStruct, duty)
+	c.SignatureState.start(c.Logger, signaturesCount, root, valueStruct, duty, c.onPostConsensusTimeout)
 	return nil
 }
 
+// onPostConsensusTimeout abandons the post consensus signatures collection of the given slot,
+// it purges the remaining partial signature messages of that slot (and older) from the queue
+func (c *Controller) onPostConsensusTimeout(slot spec.Slot) {
+	mid := message.ToMessageID(c.Identifier)
+	cleaned := c.Q.Clean(msgqueue.SignedPostConsensusMsgCleaner(mid, slot))
+	reportPostConsensusAbandoned(mid)
+	c.Logger.Debug("post consensus signatures collection was abandoned",
+		zap.Uint64("slot", uint64(slot)), zap.Int64("cleaned", cleaned))
+}
+
 // signAndBroadcast checks and adds the signed message to the appropriate round state type
 func (c *Controller) signAndBroadcast(logger *zap.Logger, psm specssv.PartialSignatureMessages) error {
 	pk, err := c.ValidatorShare.OperatorSharePubKey()
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
+	testingprotocol "github.com/bloxapp/ssv/protocol/v1/testing"
+	"github.com/bloxapp/ssv/protocol/v1/types"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+func signedPartialSignatureMsg(t *testing.T, sk *bls.SecretKey, signer spectypes.OperatorID, domain spectypes.DomainType, slot phase0.Slot, root []byte) *specssv.SignedPartialSignatureMessage {
+	psm := specssv.PartialSignatureMessages{
+		&specssv.PartialSignatureMessage{
+			Slot:             slot,
+			PartialSignature: sk.SignByte(root).Serialize(),
+			SigningRoot:      root,
+			Signers:          []spectypes.OperatorID{signer},
+		},
+	}
+	signingRoot, err := spectypes.ComputeSigningRoot(psm, spectypes.ComputeSignatureDomain(domain, spectypes.PartialSignatureType))
+	require.NoError(t, err)
+	return &specssv.SignedPartialSignatureMessage{
+		Type:      specssv.PostConsensusPartialSig,
+		Messages:  psm,
+		Signature: sk.SignByte(signingRoot).Serialize(),
+		Signers:   []spectypes.OperatorID{signer},
+	}
+}
+
+func TestPostConsensusQuorumTimeout(t *testing.T) {
+	sks, _ := testingprotocol.GenerateBLSKeys(1, 2, 3, 4)
+	committee := make(map[spectypes.OperatorID]*beaconprotocol.Node, len(sks))
+	for oid, sk := range sks {
+		committee[oid] = &beaconprotocol.Node{IbftID: uint64(oid), Pk: sk.GetPublicKey().Serialize()}
+	}
+	share := &beaconprotocol.Share{NodeID: 1, Committee: committee}
+
+	q, err := msgqueue.New(
+		logex.GetLogger().With(zap.String("who", "msg_q")),
+		msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer(), msgqueue.SignedPostConsensusMsgIndexer()),
+	)
+	require.NoError(t, err)
+	id := spectypes.NewMsgID([]byte("quorum-timeout"), spectypes.BNRoleAttester)
+	ctrl := Controller{
+		Ctx:                 context.Background(),
+		Logger:              logex.GetLogger().With(zap.String("who", "controller")),
+		Q:                   q,
+		ValidatorShare:      share,
+		SignatureState:      SignatureState{SignatureCollectionTimeout: time.Millisecond * 200},
+		Identifier:          id[:],
+		CurrentInstanceLock: &sync.RWMutex{},
+		ForkLock:            &sync.Mutex{},
+	}
+	abandoned := metricsPostConsensusAbandoned.WithLabelValues(spectypes.BNRoleAttester.String(), hex.EncodeToString(id.GetPubKey()))
+	before := testutil.ToFloat64(abandoned)
+
+	root := make([]byte, 32)
+	copy(root, "post consensus root")
+	slot := phase0.Slot(1)
+	ctrl.SignatureState.start(ctrl.Logger, 3, root, nil, &spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: slot}, ctrl.onPostConsensusTimeout)
+
+	// only 2 out of the required 3 partial signatures arrive
+	for _, oid := range []spectypes.OperatorID{1, 2} {
+		require.NoError(t, ctrl.ProcessPostConsensusMessage(signedPartialSignatureMsg(t, sks[oid], oid, types.GetDefaultDomain(), slot, root)))
+	}
+	ctrl.SignatureState.lock.Lock()
+	require.Len(t, ctrl.SignatureState.signatures, 2)
+	ctrl.SignatureState.lock.Unlock()
+
+	require.Eventually(t, func() bool {
+		return ctrl.SignatureState.getState() == StateTimeout
+	}, time.Second*2, time.Millisecond*10)
+	require.Equal(t, before+1, testutil.ToFloat64(abandoned))
+	ctrl.SignatureState.lock.Lock()
+	require.Nil(t, ctrl.SignatureState.signatures)
+	require.Nil(t, ctrl.SignatureState.duty)
+	ctrl.SignatureState.lock.Unlock()
+
+	// a late partial signature doesn't complete the abandoned quorum (beacon is nil, so a submission would panic)
+	require.NoError(t, ctrl.ProcessPostConsensusMessage(signedPartialSignatureMsg(t, sks[3], 3, types.GetDefaultDomain(), slot, root)))
+	require.Equal(t, StateTimeout, int(ctrl.SignatureState.getState()))
+	require.Equal(t, before+1, testutil.ToFloat64(abandoned))
+}
+
+// lockCheckingBeacon checks that the signature state is not locked while an attestation is submitted
+type lockCheckingBeacon struct {
+	*testBeacon
+	state     *SignatureState
+	submitted chan struct{}
+}
+
+func (b *lockCheckingBeacon) SubmitAttestation(attestation *phase0.Attestation) error {
+	// blocks if the signature state is locked
+	_ = b.state.getLastSlot()
+	close(b.submitted)
+	return b.testBeacon.SubmitAttestation(attestation)
+}
+
+func TestPostConsensusQuorumBroadcastUnlocked(t *testing.T) {
+	keySet := testingutils.Testing4SharesSet()
+	committee := make(map[spectypes.OperatorID]*beaconprotocol.Node, len(keySet.Shares))
+	for oid, sk := range keySet.Shares {
+		committee[oid] = &beaconprotocol.Node{IbftID: uint64(oid), Pk: sk.GetPublicKey().Serialize()}
+	}
+	share := &beaconprotocol.Share{NodeID: 1, PublicKey: keySet.ValidatorPK, Committee: committee}
+
+	q, err := msgqueue.New(
+		logex.GetLogger().With(zap.String("who", "msg_q")),
+		msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer(), msgqueue.SignedPostConsensusMsgIndexer()),
+	)
+	require.NoError(t, err)
+	id := spectypes.NewMsgID(keySet.ValidatorPK.Serialize(), spectypes.BNRoleAttester)
+	ctrl := &Controller{
+		Ctx:                 context.Background(),
+		Logger:              logex.GetLogger().With(zap.String("who", "controller")),
+		Q:                   q,
+		ValidatorShare:      share,
+		SignatureState:      SignatureState{SignatureCollectionTimeout: time.Second * 5},
+		Identifier:          id[:],
+		CurrentInstanceLock: &sync.RWMutex{},
+		ForkLock:            &sync.Mutex{},
+	}
+	beacon := &lockCheckingBeacon{testBeacon: newTestBeacon(t), state: &ctrl.SignatureState, submitted: make(chan struct{})}
+	ctrl.Beacon = beacon
+
+	root := make([]byte, 32)
+	copy(root, "post consensus root")
+	slot := phase0.Slot(1)
+	valueStruct := &beaconprotocol.DutyData{
+		SignedData: &beaconprotocol.InputValueAttestation{Attestation: &phase0.Attestation{}},
+	}
+	ctrl.SignatureState.start(ctrl.Logger, 3, root, valueStruct, &spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: slot}, nil)
+
+	done := make(chan error, 1)
+	go func() {
+		for _, oid := range []spectypes.OperatorID{1, 2, 3} {
+			if err := ctrl.ProcessPostConsensusMessage(signedPartialSignatureMsg(t, keySet.Shares[oid], oid, types.GetDefaultDomain(), slot, root)); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	select {
+	case <-beacon.submitted:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "attestation was not submitted")
+	}
+	require.NoError(t, <-done)
+	require.NotNil(t, beacon.LastSubmittedAttestation)
+	require.Equal(t, StateSleep, int(ctrl.SignatureState.getState()))
+}
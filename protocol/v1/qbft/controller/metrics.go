@@ -1,10 +1,16 @@
 package controller
 
 import (
+	"encoding/hex"
 	"log"
+	"time"
 
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
 )
 
 var (
@@ -21,6 +27,38 @@ var (
 		Name: "ssv:validator:running_ibfts_count",
 		Help: "Count running IBFTs by validator pub key",
 	}, []string{"pubKey"})
+	metricsPostConsensusAbandoned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:validator:post_consensus_abandoned",
+		Help: "Count post consensus signatures collections that timed out before reaching quorum",
+	}, []string{"identifier", "pubKey"})
+	metricsDecidedWriteDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ssv:qbft:decided_write_seconds",
+		Help:    "The time it takes to save the highest decided message",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"role"})
+	metricsDecidedStrategy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:qbft:decided_strategy",
+		Help: "The active decided strategy (1) per identifier",
+	}, []string{"identifier", "pubKey", "strategy"})
+	metricsQueueCleaned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:qbft:queue_cleaned",
+		Help: "Count messages that were purged from the message queue by reason",
+	}, []string{"reason"})
+	metricsSyncProgress = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:qbft:sync_progress",
+		Help: "The highest height that was fetched by the running decided sync",
+	}, []string{"identifier", "pubKey"})
+	metricsQueueLen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:validator:queue_len",
+		Help: "The amount of messages in the message queue of a role",
+	}, []string{"role", "pubKey"})
+)
+
+// reasons of message queue clean operations
+const (
+	queueCleanReasonFork          = "fork"
+	queueCleanReasonAfterInstance = "after_instance"
+	queueCleanReasonLateMessages  = "late_messages"
 )
 
 func init() {
@@ -33,6 +71,24 @@ func init() {
 	if err := prometheus.Register(metricsRunningIBFTs); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsPostConsensusAbandoned); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDecidedWriteDuration); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDecidedStrategy); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsQueueCleaned); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsSyncProgress); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsQueueLen); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 type ibftStatus int32
@@ -70,3 +126,40 @@ func ReportIBFTStatus(pk string, finished, errorFound bool) {
 		}
 	}
 }
+
+// reportPostConsensusAbandoned reports a post consensus signatures collection that timed out
+func reportPostConsensusAbandoned(mid spectypes.MessageID) {
+	metricsPostConsensusAbandoned.WithLabelValues(mid.GetRoleType().String(), hex.EncodeToString(mid.GetPubKey())).Inc()
+}
+
+// reportDecidedWrite reports the duration of saving a decided message
+func reportDecidedWrite(role spectypes.BeaconRole, d time.Duration) {
+	metricsDecidedWriteDuration.WithLabelValues(role.String()).Observe(d.Seconds())
+}
+
+// reportDecidedStrategy reports the active decided strategy of the given identifier
+func reportDecidedStrategy(mid spectypes.MessageID, mode strategy.Mode) {
+	role, pk := mid.GetRoleType().String(), hex.EncodeToString(mid.GetPubKey())
+	for _, m := range []strategy.Mode{strategy.ModeLightNode, strategy.ModeFullNode} {
+		value := float64(0)
+		if m == mode {
+			value = 1
+		}
+		metricsDecidedStrategy.WithLabelValues(role, pk, m.String()).Set(value)
+	}
+}
+
+// reportQueueCleaned reports the amount of messages that were purged from the message queue
+func reportQueueCleaned(reason string, cleaned int64) {
+	metricsQueueCleaned.WithLabelValues(reason).Add(float64(cleaned))
+}
+
+// reportSyncProgress reports the highest height that was fetched by a decided sync
+func reportSyncProgress(mid spectypes.MessageID, height specqbft.Height) {
+	metricsSyncProgress.WithLabelValues(mid.GetRoleType().String(), hex.EncodeToString(mid.GetPubKey())).Set(float64(height))
+}
+
+// reportQueueLen reports the amount of messages in the message queue of the given identifier
+func reportQueueLen(mid spectypes.MessageID, n int) {
+	metricsQueueLen.WithLabelValues(mid.GetRoleType().String(), hex.EncodeToString(mid.GetPubKey())).Set(float64(n))
+}
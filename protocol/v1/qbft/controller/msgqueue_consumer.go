@@ -10,6 +10,7 @@ import (
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 
 	"github.com/patrickmn/go-cache"
@@ -38,6 +39,8 @@ func (c *Controller) ConsumeQueue(handler MessageHandler, interval time.Duration
 	ctx, cancel := context.WithCancel(c.Ctx)
 	defer cancel()
 
+	handler = c.withQueueLenReport(handler)
+
 	identifier := hex.EncodeToString(c.Identifier)
 	higherCache := cache.New(time.Second*12, time.Second*24)
 
@@ -55,7 +58,7 @@ func (c *Controller) ConsumeQueue(handler MessageHandler, interval time.Duration
 			continue
 		}
 
-		lastSlot := c.SignatureState.lastSlot // no slot - 0.
+		lastSlot := c.SignatureState.getLastSlot() // no slot - 0.
 		lastHeight := c.GetHeight()
 
 		if processed := c.processNoRunningInstance(handler, identifier, lastHeight, lastSlot); processed {
@@ -83,12 +86,21 @@ func (c *Controller) ConsumeQueue(handler MessageHandler, interval time.Duration
 		})
 		if cleaned > 0 {
 			c.Logger.Debug("indexes cleaned from queue", zap.Int64("count", cleaned))
+			c.reportQueueLen()
 		}
 	}
 	c.Logger.Warn("queue consumer is closed")
 	return nil
 }
 
+// withQueueLenReport wraps the given handler to report the queue length once a message was popped
+func (c *Controller) withQueueLenReport(handler MessageHandler) MessageHandler {
+	return func(msg *spectypes.SSVMessage) error {
+		c.reportQueueLen()
+		return handler(msg)
+	}
+}
+
 // processNoRunningInstance pop msg's only if no current instance running
 func (c *Controller) processNoRunningInstance(
 	handler MessageHandler,
@@ -103,7 +115,7 @@ func (c *Controller) processNoRunningInstance(
 
 	logger := c.Logger.With(
 		zap.String("sig state", c.SignatureState.getState().toString()),
-		zap.Int32("height", int32(lastHeight)),
+		logfields.Height(lastHeight),
 		zap.Int32("slot", int32(lastSlot)))
 
 	iterator := msgqueue.NewIndexIterator().Add(func() msgqueue.Index {
@@ -145,8 +157,8 @@ func (c *Controller) processByState(handler MessageHandler, identifier string) b
 	}
 	c.Logger.Debug("queue found message for state",
 		zap.Int32("stage", currentState.Stage.Load()),
-		zap.Int32("seq", int32(currentState.GetHeight())),
-		zap.Int32("round", int32(currentState.GetRound())),
+		logfields.Height(currentState.GetHeight()),
+		logfields.Round(currentState.GetRound()),
 	)
 
 	err := handler(msg)
@@ -240,17 +252,28 @@ func (c *Controller) getNextMsgForState(state *qbft.State, identifier string) *s
 	return msgs[0]
 }
 
-// processOnFork this phase is to allow process remaining decided messages that arrived late to the msg queue
-func (c *Controller) processAllDecided(handler MessageHandler) {
+// processAllDecided this phase is to allow process remaining decided messages that arrived late to the msg queue.
+// returns the messages that could not be processed, so they won't get lost once the queue is cleaned
+// processAllDecided pops and processes all the decided messages in the queue,
+// returns the messages that failed to be processed, without duplicates (e.g. the same decided received from several peers)
+func (c *Controller) processAllDecided(handler MessageHandler) []*spectypes.SSVMessage {
+	var failed []*spectypes.SSVMessage
+	seen := make(map[string]bool)
 	idx := msgqueue.DecidedMsgIndex(hex.EncodeToString(c.Identifier))
 	msgs := c.Q.Pop(1, idx)
 	for len(msgs) > 0 {
 		err := handler(msgs[0])
 		if err != nil {
 			c.Logger.Warn("could not handle msg", zap.Error(err))
+			key := hex.EncodeToString(msgs[0].Data)
+			if !seen[key] {
+				seen[key] = true
+				failed = append(failed, msgs[0])
+			}
 		}
 		msgs = c.Q.Pop(1, idx)
 	}
+	return failed
 }
 
 func stateIndex(identifier string, stage qbft.RoundState, height specqbft.Height) []msgqueue.Index {
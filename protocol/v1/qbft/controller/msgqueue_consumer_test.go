@@ -464,6 +464,47 @@ func TestConsumeMessages(t *testing.T) {
 	}
 }
 
+func TestPostConsensusTimeoutCleanup(t *testing.T) {
+	q, err := msgqueue.New(
+		logex.GetLogger().With(zap.String("who", "msg_q")),
+		msgqueue.WithIndexers(msgqueue.SignedMsgIndexer(), msgqueue.DecidedMsgIndexer(), msgqueue.SignedPostConsensusMsgIndexer()),
+	)
+	require.NoError(t, err)
+	id := spectypes.NewMsgID([]byte("1"), spectypes.BNRoleAttester)
+	ctrl := Controller{
+		Ctx:                 context.Background(),
+		Logger:              logex.GetLogger().With(zap.String("who", "controller")),
+		Q:                   q,
+		SignatureState:      SignatureState{SignatureCollectionTimeout: time.Millisecond * 100},
+		Identifier:          id[:],
+		CurrentInstanceLock: &sync.RWMutex{},
+		ForkLock:            &sync.Mutex{},
+	}
+
+	// only one partial signature out of the required 3 arrives, for the current and next slots
+	ctrl.Q.Add(generatePartialSignatureMsg(t, spectypes.SSVPartialSignatureMsgType, phase0.Slot(1), id))
+	ctrl.Q.Add(generatePartialSignatureMsg(t, spectypes.SSVPartialSignatureMsgType, phase0.Slot(2), id))
+	require.Equal(t, 2, ctrl.Q.Len())
+
+	ctrl.SignatureState.start(ctrl.Logger, 3, []byte("root"), nil, &spectypes.Duty{Slot: 1}, ctrl.onPostConsensusTimeout)
+	require.Equal(t, StateRunning, int(ctrl.SignatureState.getState()))
+
+	// messages of the abandoned slot are purged, next slot is kept
+	require.Eventually(t, func() bool {
+		return ctrl.SignatureState.getState() == StateTimeout && ctrl.Q.Len() == 1
+	}, time.Second, time.Millisecond*10)
+	// collected data of the abandoned slot was dropped
+	require.Nil(t, ctrl.SignatureState.signatures)
+	require.Nil(t, ctrl.SignatureState.root)
+	require.Nil(t, ctrl.SignatureState.duty)
+	require.Zero(t, ctrl.SignatureState.sigCount)
+	msgs := ctrl.Q.Pop(1, msgqueue.SignedPostConsensusMsgIndex(id.String(), phase0.Slot(2)))
+	require.Len(t, msgs, 1)
+
+	// late messages are rejected once the collection was abandoned
+	require.NoError(t, ctrl.ProcessPostConsensusMessage(&specssv.SignedPartialSignatureMessage{}))
+}
+
 func generateInstance(height specqbft.Height, round specqbft.Round, stage qbft.RoundState) instance.Instancer {
 	i := &InstanceMock{state: &qbft.State{
 		Height: qbft.NewHeight(height),
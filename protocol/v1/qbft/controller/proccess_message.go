@@ -10,13 +10,14 @@ import (
 
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 )
 
 func (c *Controller) processConsensusMsg(signedMessage *specqbft.SignedMessage) (bool, error) {
 	logger := c.Logger.With(zap.Int("type", int(signedMessage.Message.MsgType)),
 		zap.Int64("ctrl height", int64(c.GetHeight())),
 		zap.Int64("new msg height", int64(signedMessage.Message.Height)),
-		zap.Int64("round", int64(signedMessage.Message.Round)),
+		logfields.Round(signedMessage.Message.Round),
 		zap.Any("sender", signedMessage.GetSigners()))
 
 	if err := pipelines.Combine(
@@ -82,8 +83,8 @@ func (c *Controller) processPostConsensusSig(signedPostConsensusMessage *specssv
 // if height is the same as last decided msg height, update the last decided with the updated one.
 func (c *Controller) processCommitMsg(logger *zap.Logger, signedMessage *specqbft.SignedMessage) (bool, error) {
 	logger = logger.With(zap.String("who", "ProcessLateCommitMsg"),
-		zap.Uint64("seq", uint64(signedMessage.Message.Height)),
-		zap.String("identifier", message.ToMessageID(signedMessage.Message.Identifier).String()),
+		logfields.Height(signedMessage.Message.Height),
+		logfields.Identifier(signedMessage.Message.Identifier),
 		zap.Any("signers", signedMessage.GetSigners()))
 
 	if agg, err := c.ProcessLateCommitMsg(logger, signedMessage); err != nil {
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"encoding/base64"
+	"sync"
 	"time"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -12,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/utils/threshold"
 )
 
@@ -39,6 +41,8 @@ func (s TimerState) toString() string {
 
 // SignatureState represents the signature state.
 type SignatureState struct {
+	// lock guards the collected data, which is accessed by the message processing and the timeout
+	lock       sync.Mutex
 	timer      *time.Timer
 	state      atomic.Int32
 	signatures map[spectypes.OperatorID][]byte
@@ -51,7 +55,12 @@ type SignatureState struct {
 	lastSlot                   spec.Slot // for queue in order the know the last slot
 }
 
-func (s *SignatureState) start(logger *zap.Logger, signaturesCount int, root []byte, valueStruct *beaconprotocol.DutyData, duty *spectypes.Duty) {
+// start initializes the state for a new duty and starts the collection timer.
+// onTimeout (if provided) is called with the duty slot once the collection was abandoned
+func (s *SignatureState) start(logger *zap.Logger, signaturesCount int, root []byte, valueStruct *beaconprotocol.DutyData, duty *spectypes.Duty, onTimeout func(slot spec.Slot)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	// set var's
 	s.sigCount = signaturesCount
 	s.root = root
@@ -60,12 +69,24 @@ func (s *SignatureState) start(logger *zap.Logger, signaturesCount int, root []b
 	s.lastSlot = duty.Slot
 
 	// start timer
+	slot := duty.Slot
 	s.timer = time.AfterFunc(s.SignatureCollectionTimeout, func() {
+		s.lock.Lock()
 		if !s.state.CAS(StateRunning, StateTimeout) {
-			logger.Debug("signatures were collected before timeout", zap.Int("received", len(s.signatures)))
+			s.lock.Unlock()
+			logger.Debug("signatures were collected before timeout")
 			return
 		}
-		logger.Warn("could not process post consensus signature", zap.Error(errors.Errorf("timed out waiting for post consensus signatures, received %d", len(s.signatures))))
+		logger.Warn("post consensus quorum not reached",
+			zap.Uint64("slot", uint64(slot)),
+			zap.Int("received", len(s.signatures)),
+			zap.Int("required", s.sigCount),
+			zap.Duration("timeout", s.SignatureCollectionTimeout))
+		s.abandon()
+		s.lock.Unlock()
+		if onTimeout != nil {
+			onTimeout(slot)
+		}
 	})
 	//s.timer = time.NewTimer(s.SignatureCollectionTimeout)
 	s.state.Store(StateRunning)
@@ -88,6 +109,24 @@ func (s *SignatureState) clear() {
 	// don't reset height until new height set
 }
 
+// abandon drops the collected data of the current duty, the state stays on timeout
+// so late messages of the abandoned duty are rejected. the caller must hold the lock
+func (s *SignatureState) abandon() {
+	s.signatures = nil
+	s.sigCount = 0
+	s.root = nil
+	s.valueStruct = nil
+	s.duty = nil
+}
+
+// getLastSlot returns the slot of the last duty
+func (s *SignatureState) getLastSlot() spec.Slot {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.lastSlot
+}
+
 func (s *SignatureState) getState() TimerState {
 	return TimerState(s.state.Load())
 }
@@ -168,12 +207,13 @@ func (c *Controller) reconstructAndBroadcastSignature(signatures map[spectypes.O
 		return errors.New("could not reconstruct a valid signature")
 	}
 
-	c.Logger.Info("signatures successfully reconstructed", zap.String("signature", base64.StdEncoding.EncodeToString(signature.Serialize())), zap.Int("signature count", len(signatures)))
+	logger := c.Logger.With(logfields.TraceID(logfields.DutyTraceID(duty)))
+	logger.Info("signatures successfully reconstructed", zap.String("signature", base64.StdEncoding.EncodeToString(signature.Serialize())), zap.Int("signature count", len(signatures)))
 
 	// Submit validation to beacon node
 	switch duty.Type {
 	case spectypes.BNRoleAttester:
-		c.Logger.Debug("submitting attestation")
+		logger.Debug("submitting attestation")
 		blsSig := spec.BLSSignature{}
 		copy(blsSig[:], signature.Serialize()[:])
 		inputValue.GetAttestation().Signature = blsSig
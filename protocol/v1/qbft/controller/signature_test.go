@@ -157,6 +157,81 @@ func TestVerifyPartialSignature(t *testing.T) {
 	}
 }
 
+func TestRoleSigTimeout(t *testing.T) {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+
+	pk := &bls.PublicKey{}
+	require.NoError(t, pk.Deserialize(refPk))
+	share := &beacon.Share{
+		NodeID:    1,
+		PublicKey: pk,
+		Committee: map[spectypes.OperatorID]*beacon.Node{
+			1: {IbftID: 1, Pk: refSplitSharesPubKeys[0]},
+			2: {IbftID: 2, Pk: refSplitSharesPubKeys[1]},
+			3: {IbftID: 3, Pk: refSplitSharesPubKeys[2]},
+			4: {IbftID: 4, Pk: refSplitSharesPubKeys[3]},
+		},
+	}
+	roleTimeouts := map[spectypes.BeaconRole]time.Duration{
+		spectypes.BNRoleSyncCommittee: time.Second * 12,
+	}
+
+	newCtrl := func(role spectypes.BeaconRole) *Controller {
+		identifier := spectypes.NewMsgID(share.PublicKey.Serialize(), role)
+		return New(Options{
+			Role:            role,
+			Identifier:      identifier[:],
+			Logger:          zap.L(),
+			InstanceConfig:  qbft.DefaultConsensusParams(),
+			ValidatorShare:  share,
+			SigTimeout:      time.Second * 2,
+			RoleSigTimeouts: roleTimeouts,
+			Version:         forksprotocol.GenesisForkVersion,
+		}).(*Controller)
+	}
+
+	t.Run("role specific timeout", func(t *testing.T) {
+		ctrl := newCtrl(spectypes.BNRoleSyncCommittee)
+		require.Equal(t, time.Second*12, ctrl.SignatureState.SignatureCollectionTimeout)
+	})
+
+	t.Run("fallback to global timeout", func(t *testing.T) {
+		ctrl := newCtrl(spectypes.BNRoleAttester)
+		require.Equal(t, time.Second*2, ctrl.SignatureState.SignatureCollectionTimeout)
+	})
+}
+
+func TestSignatureStateConcurrency(t *testing.T) {
+	s := &SignatureState{SignatureCollectionTimeout: time.Millisecond}
+
+	// the last slot is read by the queue consumer while duties are started and abandoned on timeout
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for slot := spec.Slot(1); slot <= 10; slot++ {
+			s.start(zap.L(), 3, []byte("root"), nil, &spectypes.Duty{Slot: slot}, nil)
+			time.Sleep(time.Millisecond * 2)
+		}
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+			require.LessOrEqual(t, s.getLastSlot(), spec.Slot(10))
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return s.getState() == StateTimeout
+	}, time.Second, time.Millisecond*10)
+	require.Equal(t, spec.Slot(10), s.getLastSlot())
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	require.Nil(t, s.signatures)
+	require.Nil(t, s.duty)
+}
+
 var (
 	refAttestationDataByts = _byteArray("000000000000000000000000000000003a43a4bf26fb5947e809c1f24f7dc6857c8ac007e535d48e6e4eca2122fd776b0000000000000000000000000000000000000000000000000000000000000000000000000000000002000000000000003a43a4bf26fb5947e809c1f24f7dc6857c8ac007e535d48e6e4eca2122fd776b")
 
@@ -253,6 +328,10 @@ func (b *testBeacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommit
 	panic("implement me")
 }
 
+func (b *testBeacon) SubmitProposalPreparation(preparations []*api.ProposalPreparation) error {
+	panic("implement me")
+}
+
 func (b *testBeacon) AddShare(shareKey *bls.SecretKey) error {
 	panic("implement me")
 }
@@ -7,8 +7,58 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/signedmsg"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
+	protocolsync "github.com/bloxapp/ssv/protocol/v1/sync"
 )
 
+// syncHistory syncs decided history from peers, unless the node is already at head
+func (c *Controller) syncHistory(known *specqbft.SignedMessage) error {
+	if c.atHead(known) {
+		c.Logger.Debug("already at head, skipping history sync", logfields.Height(known.Message.Height))
+		return nil
+	}
+	return c.syncDecided(known, nil)
+}
+
+// atHead returns true if all the peers agree on a last decided height that is not higher than the known height.
+// if peers disagree on head, or no last decided was found, a full sync is required
+func (c *Controller) atHead(known *specqbft.SignedMessage) bool {
+	if known == nil || known.Message == nil || c.Network == nil {
+		return false
+	}
+	results, err := c.Network.LastDecided(message.ToMessageID(c.Identifier))
+	if err != nil {
+		c.Logger.Debug("could not get last decided from peers", zap.Error(err))
+		return false
+	}
+	var head *specqbft.Height
+	for _, res := range results {
+		if res.Msg == nil {
+			continue
+		}
+		sm, err := protocolsync.ExtractSyncMsg(res.Msg)
+		if err != nil || sm == nil || len(sm.Data) == 0 {
+			continue
+		}
+		msg := sm.Data[0]
+		if msg == nil || msg.Message == nil {
+			continue
+		}
+		height := msg.Message.Height
+		if head == nil {
+			head = &height
+			continue
+		}
+		if *head != height {
+			c.Logger.Debug("peers disagree on head", logfields.Height(*head), zap.Uint64("other height", uint64(height)))
+			return false
+		}
+	}
+	return head != nil && *head <= known.Message.Height
+}
+
 func (c *Controller) processHigherHeightMsg(logger *zap.Logger, msg *specqbft.SignedMessage) error {
 	if err := pipelines.Combine(
 		signedmsg.BasicMsgValidation(),
@@ -29,7 +79,7 @@ func (c *Controller) processHigherHeightMsg(logger *zap.Logger, msg *specqbft.Si
 			return errors.Wrap(err, "failed to get known decided")
 		}
 		logger.Debug("f+1 higher height, trigger decided sync", zap.Any("map", c.HigherReceivedMessages))
-		if err := c.syncDecided(knownDecided, nil); err != nil {
+		if err := c.syncDecided(knownDecided, nil); err != nil && err != ErrAlreadyRunning {
 			return errors.Wrap(err, "failed to sync decided")
 		}
 	}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+)
+
+// DefaultSyncStuckTimeout is the default duration without sync progress, after which a decided sync is considered stuck
+const DefaultSyncStuckTimeout = 2 * time.Minute
+
+// syncTracker keeps the time of the last progress of the running decided syncs,
+// it helps to distinguish long syncs from stuck ones
+type syncTracker struct {
+	lock     sync.Mutex
+	progress map[string]time.Time
+}
+
+// runningSyncs tracks the decided syncs of all the controllers
+var runningSyncs = newSyncTracker()
+
+func newSyncTracker() *syncTracker {
+	return &syncTracker{
+		progress: make(map[string]time.Time),
+	}
+}
+
+// start marks the sync of the given identifier as running, returns a function to be called once the sync is done
+func (t *syncTracker) start(identifier []byte) func() {
+	id := string(identifier)
+	t.touch(id)
+	return func() {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+
+		delete(t.progress, id)
+	}
+}
+
+// touch updates the time of the last progress of the given sync
+func (t *syncTracker) touch(id string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.progress[id] = time.Now()
+}
+
+// stuck returns the identifiers of the syncs without progress in the given timeout
+func (t *syncTracker) stuck(timeout time.Duration) map[string]time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	res := make(map[string]time.Duration)
+	for id, last := range t.progress {
+		if since := time.Since(last); since > timeout {
+			res[id] = since
+		}
+	}
+	return res
+}
+
+// SyncHealthCheck is a health check agent of decided syncs,
+// a sync that is making progress is healthy while a sync without progress in the given timeout is reported
+type SyncHealthCheck struct {
+	Timeout time.Duration
+}
+
+// HealthCheck returns the stuck decided syncs
+func (h *SyncHealthCheck) HealthCheck() []string {
+	return h.check(runningSyncs)
+}
+
+func (h *SyncHealthCheck) check(tracker *syncTracker) []string {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSyncStuckTimeout
+	}
+	var errs []string
+	for id, since := range tracker.stuck(timeout) {
+		errs = append(errs, fmt.Sprintf("decided sync of %s is stuck, no progress for %s",
+			message.ToMessageID([]byte(id)).String(), since.Round(time.Second)))
+	}
+	return errs
+}
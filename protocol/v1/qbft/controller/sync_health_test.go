@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncHealthCheck(t *testing.T) {
+	tracker := newSyncTracker()
+	check := &SyncHealthCheck{Timeout: time.Minute}
+	identifier := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
+
+	done := tracker.start(identifier[:])
+	require.Empty(t, check.check(tracker), "a sync that just started is healthy")
+
+	// no progress for a while
+	tracker.lock.Lock()
+	tracker.progress[string(identifier[:])] = time.Now().Add(-2 * time.Minute)
+	tracker.lock.Unlock()
+	require.Len(t, check.check(tracker), 1)
+
+	// progress was made
+	tracker.touch(string(identifier[:]))
+	require.Empty(t, check.check(tracker))
+
+	done()
+	require.Empty(t, tracker.stuck(0))
+}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/proposal"
@@ -33,7 +34,7 @@ func (i *Instance) ChangeRoundMsgPipeline() pipelines.SignedMessagePipeline {
 			i.Logger.Info("received valid change round message for round",
 				zap.Any("sender_ibft_id", signedMessage.GetSigners()),
 				zap.Any("msg", signedMessage.Message),
-				zap.Uint64("round", uint64(signedMessage.Message.Round)))
+				logfields.Round(signedMessage.Message.Round))
 
 			changeRoundData, err := signedMessage.Message.GetRoundChangeData()
 			if err != nil {
@@ -81,7 +82,7 @@ func (i *Instance) uponChangeRoundFullQuorum() pipelines.SignedMessagePipeline {
 		// change round if quorum reached
 		if !quorum {
 			i.Logger.Info("change round - quorum not reached",
-				zap.Uint64("round", uint64(signedMessage.Message.Round)),
+				logfields.Round(signedMessage.Message.Round),
 				zap.Int("msgsCount", msgsCount),
 				zap.Int("committeeSize", committeeSize),
 				zap.Uint64("leader", i.ThisRoundLeader()),
@@ -96,7 +97,7 @@ func (i *Instance) uponChangeRoundFullQuorum() pipelines.SignedMessagePipeline {
 
 		i.processChangeRoundQuorumOnce.Do(func() {
 			i.ProcessStageChange(qbft.RoundStateReady)
-			logger := i.Logger.With(zap.Uint64("round", uint64(signedMessage.Message.Round)),
+			logger := i.Logger.With(logfields.Round(signedMessage.Message.Round),
 				zap.Bool("is_leader", i.IsLeader()),
 				zap.Uint64("leader", i.ThisRoundLeader()),
 				zap.Bool("round_justified", true))
@@ -216,7 +217,7 @@ func (i *Instance) handleDeprecatedPrepareJustification(msgs []*specqbft.SignedM
 }
 
 func (i *Instance) uponChangeRoundTrigger() {
-	i.Logger.Info("round timeout, changing round", zap.Uint64("round", uint64(i.GetState().GetRound())))
+	i.Logger.Info("round timeout, changing round", logfields.Round(i.GetState().GetRound()))
 	// reset proposal for round
 	i.GetState().ProposalAcceptedForCurrentRound.Store((*specqbft.SignedMessage)(nil))
 	// bump round
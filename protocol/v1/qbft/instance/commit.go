@@ -2,7 +2,6 @@ package instance
 
 import (
 	"bytes"
-	"encoding/hex"
 	"fmt"
 
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
@@ -12,6 +11,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/msgcont"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 )
 
@@ -37,7 +37,7 @@ func (i *Instance) CommitMsgValidationPipeline() pipelines.SignedMessagePipeline
 		pipelines.WrapFunc("add commit msg", func(signedMessage *specqbft.SignedMessage) error {
 			i.Logger.Info("received valid commit message for round",
 				zap.Any("sender_ibft_id", signedMessage.GetSigners()),
-				zap.Uint64("round", uint64(signedMessage.Message.Round)))
+				logfields.Round(signedMessage.Message.Round))
 
 			commitData, err := signedMessage.Message.GetCommitData()
 			if err != nil {
@@ -67,8 +67,8 @@ func (i *Instance) uponCommitMsg() pipelines.SignedMessagePipeline {
 		var onceErr error
 		i.processCommitQuorumOnce.Do(func() {
 			i.Logger.Info("commit iBFT instance",
-				zap.String("identifier", hex.EncodeToString(i.GetState().GetIdentifier())),
-				zap.Uint64("round", uint64(i.GetState().GetRound())),
+				logfields.Identifier(i.GetState().GetIdentifier()),
+				logfields.Round(i.GetState().GetRound()),
 				zap.Int("got_votes", len(commitMsgs)))
 
 			agg, err := aggregateCommitMsgs(commitMsgs)
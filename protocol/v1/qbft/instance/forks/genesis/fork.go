@@ -39,13 +39,22 @@ func (g *ForkGenesis) VersionName() string {
 // ProposalMsgValidationPipeline is the validation pipeline for proposal messages
 func (g *ForkGenesis) ProposalMsgValidationPipeline(share *beacon.Share, state *qbft.State, roundLeader proposal.LeaderResolver) pipelines.SignedMessagePipeline {
 	identifier := state.GetIdentifier()
+	var justificationCache *proposal.JustificationCache
+	maxValueSize := 0
+	if g.instance != nil {
+		justificationCache = g.instance.JustificationCache()
+		if g.instance.Config != nil {
+			maxValueSize = g.instance.Config.MaxValueSize
+		}
+	}
 	return pipelines.Combine(
 		signedmsg.BasicMsgValidation(),
 		signedmsg.MsgTypeCheck(specqbft.ProposalMsgType),
 		signedmsg.ValidateSequenceNumber(state.GetHeight()),
 		signedmsg.ValidateIdentifiers(identifier[:]),
+		proposal.ValidateValueSize(maxValueSize),
 		signedmsg.AuthorizeMsg(share),
-		proposal.ValidateProposalMsg(share, state, roundLeader),
+		proposal.ValidateProposalMsgWithCache(share, state, roundLeader, justificationCache),
 	)
 }
 
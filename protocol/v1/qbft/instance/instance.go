@@ -22,8 +22,10 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/msgcont"
 	msgcontinmem "github.com/bloxapp/ssv/protocol/v1/qbft/instance/msgcont/inmem"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/roundtimer"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/proposal"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/signedmsg"
 )
 
@@ -43,6 +45,9 @@ type Options struct {
 	Fork             forks.Fork
 	SSVSigner        spectypes.SSVSigner
 	ChangeRoundStore qbftstorage.ChangeRoundStore
+	// RecoveredState is a persisted state of a prepared instance with the same height,
+	// used to resume the instance after a restart
+	RecoveredState *qbft.State
 }
 
 // Instance defines the instance attributes
@@ -80,6 +85,9 @@ type Instance struct {
 	changeRoundStore qbftstorage.ChangeRoundStore
 	ctx              context.Context
 	cancelCtx        context.CancelFunc
+
+	// justificationCache memoizes proposal justification results
+	justificationCache *proposal.JustificationCache
 }
 
 // NewInstanceWithState used for testing, not PROD!
@@ -93,7 +101,7 @@ func NewInstanceWithState(state *qbft.State) Instancer {
 func NewInstance(opts *Options) Instancer {
 	messageID := message.ToMessageID(opts.Identifier)
 	metricsIBFTStage.WithLabelValues(messageID.GetRoleType().String(), hex.EncodeToString(messageID.GetPubKey())).Set(float64(qbft.RoundStateNotStarted))
-	logger := opts.Logger.With(zap.Uint64("instance height", uint64(opts.Height)))
+	logger := opts.Logger.With(logfields.Height(opts.Height))
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
 	ret := &Instance{
@@ -121,6 +129,8 @@ func NewInstance(opts *Options) Instancer {
 		changeRoundStore: opts.ChangeRoundStore,
 
 		stopped: *atomic.NewBool(false),
+
+		justificationCache: proposal.NewJustificationCache(),
 	}
 
 	ret.ContainersMap = map[specqbft.MessageType]msgcont.MessageContainer{
@@ -132,9 +142,27 @@ func NewInstance(opts *Options) Instancer {
 
 	ret.setFork(opts.Fork)
 
+	if opts.RecoveredState != nil && ret.recoverState(opts.RecoveredState) {
+		logger.Info("instance was recovered from saved state",
+			logfields.Round(ret.State.GetRound()),
+			zap.Uint64("preparedRound", uint64(ret.State.GetPreparedRound())))
+	}
+
 	return ret
 }
 
+// recoverState restores the round and the prepared round & value from the given saved state.
+// the saved state is used only if it is a prepared state of the same height
+func (i *Instance) recoverState(saved *qbft.State) bool {
+	if saved.GetHeight() != i.State.GetHeight() || len(saved.GetPreparedValue()) == 0 {
+		return false
+	}
+	i.State.Round.Store(saved.GetRound())
+	i.State.PreparedRound.Store(saved.GetPreparedRound())
+	i.State.PreparedValue.Store(saved.GetPreparedValue())
+	return true
+}
+
 // Init must be called before start can be
 func (i *Instance) Init() {
 	i.runInitOnce.Do(func() {
@@ -149,6 +177,11 @@ func (i *Instance) GetState() *qbft.State {
 	return i.State
 }
 
+// JustificationCache returns the proposal justification cache of the instance
+func (i *Instance) JustificationCache() *proposal.JustificationCache {
+	return i.justificationCache
+}
+
 // Containers returns map of containers
 func (i *Instance) Containers() map[specqbft.MessageType]msgcont.MessageContainer {
 	return i.ContainersMap
@@ -173,15 +206,20 @@ func (i *Instance) Start(inputValue []byte) error {
 	}
 
 	messageID := message.ToMessageID(i.GetState().GetIdentifier())
-	i.Logger.Info("Node is starting iBFT instance", zap.String("identifier", hex.EncodeToString(i.GetState().GetIdentifier())))
+	i.Logger.Info("Node is starting iBFT instance", logfields.Identifier(i.GetState().GetIdentifier()))
 	i.GetState().InputValue.Store(inputValue)
-	i.GetState().Round.Store(specqbft.Round(1))           // start from 1
+	// start from 1, unless the instance was recovered from a saved state
+	round := i.GetState().GetRound()
+	if round < 1 {
+		round = specqbft.Round(1)
+	}
+	i.GetState().Round.Store(round)
 	i.GetState().Stage.Store(int32(qbft.RoundStateReady)) // for the queue to process by state only from this point
 	metricsIBFTStage.WithLabelValues(messageID.GetRoleType().String(), hex.EncodeToString(messageID.GetPubKey())).Set(float64(qbft.RoundStateReady))
-	metricsIBFTRound.WithLabelValues(messageID.GetRoleType().String(), hex.EncodeToString(messageID.GetPubKey())).Set(1)
+	metricsIBFTRound.WithLabelValues(messageID.GetRoleType().String(), hex.EncodeToString(messageID.GetPubKey())).Set(float64(round))
 
-	i.Logger.Debug("state", zap.Uint64("round", uint64(i.GetState().GetRound())))
-	if i.IsLeader() {
+	i.Logger.Debug("state", logfields.Round(i.GetState().GetRound()))
+	if round == 1 && i.IsLeader() {
 		go func() {
 			i.Logger.Info("Node is leader for round 1")
 			//i.ProcessStageChange(qbft.RoundStateProposal) we need to process the proposal msg in order to broadcast to prepare msg
@@ -330,8 +368,8 @@ func (i *Instance) GetStageChan() chan qbft.RoundState {
 func (i *Instance) SignAndBroadcast(msg *specqbft.Message) error {
 	i.Logger.Debug("broadcasting consensus msg",
 		zap.Int("type", int(msg.MsgType)),
-		zap.Int64("height", int64(msg.Height)),
-		zap.Int64("round", int64(msg.Round)),
+		logfields.Height(msg.Height),
+		logfields.Round(msg.Round),
 	)
 	pk, err := i.ValidatorShare.OperatorSharePubKey()
 	if err != nil {
@@ -18,6 +18,8 @@ type ControllerStartInstanceOptions struct {
 	// RequireMinPeers flag to require minimum peers before starting an instance
 	// useful for tests where we want (sometimes) to avoid networking
 	RequireMinPeers bool
+	// TraceID is the trace id of the duty that started the instance, used for logging
+	TraceID string
 }
 
 // Result is a struct holding the result of a single iBFT instance
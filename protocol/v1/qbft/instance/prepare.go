@@ -12,6 +12,7 @@ import (
 
 	"github.com/bloxapp/ssv/protocol/v1/message"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/signedmsg"
 )
@@ -39,7 +40,7 @@ func (i *Instance) PrepareMsgValidationPipeline() pipelines.SignedMessagePipelin
 		pipelines.WrapFunc("add prepare msg", func(signedMessage *specqbft.SignedMessage) error {
 			i.Logger.Info("received valid prepare message from round",
 				zap.Any("sender_ibft_id", signedMessage.GetSigners()),
-				zap.Uint64("round", uint64(signedMessage.Message.Round)))
+				logfields.Round(signedMessage.Message.Round))
 
 			prepareMsg, err := signedMessage.Message.GetPrepareData()
 			if err != nil {
@@ -112,7 +113,7 @@ func (i *Instance) uponPrepareMsg() pipelines.SignedMessagePipeline {
 		var errorPrp error
 		i.processPrepareQuorumOnce.Do(func() {
 			i.Logger.Info("prepared instance",
-				zap.String("identifier", hex.EncodeToString(i.GetState().GetIdentifier())), zap.Any("round", i.GetState().GetRound()))
+				logfields.Identifier(i.GetState().GetIdentifier()), logfields.Round(i.GetState().GetRound()))
 
 			// set prepared state
 			i.GetState().PreparedValue.Store(prepareData.Data) // passing the data as is, and not get the specqbft.PrepareData cause of msgCount saves that way
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 )
 
@@ -26,7 +27,7 @@ func (i *Instance) ProposalMsgPipeline() pipelines.SignedMessagePipeline {
 		pipelines.WrapFunc("add proposal msg", func(signedMessage *specqbft.SignedMessage) error {
 			i.Logger.Info("received valid proposal message for round",
 				zap.Any("sender_ibft_id", signedMessage.GetSigners()),
-				zap.Uint64("round", uint64(signedMessage.Message.Round)))
+				logfields.Round(signedMessage.Message.Round))
 
 			proposalData, err := signedMessage.Message.GetProposalData()
 			if err != nil {
@@ -3,6 +3,8 @@ package instance
 import (
 	"context"
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 )
 
 func (i *Instance) startRoundTimerLoop() {
@@ -20,7 +22,7 @@ loop:
 			if res { // timed out
 				i.uponChangeRoundTrigger()
 			} else { // stopped
-				i.Logger.Info("stopped timeout clock", zap.Uint64("round", uint64(i.GetState().GetRound())))
+				i.Logger.Info("stopped timeout clock", logfields.Round(i.GetState().GetRound()))
 			}
 		}
 
@@ -43,5 +45,5 @@ func (i *Instance) ResetRoundTimer() {
 	// stat new timer
 	roundTimeout := i.roundTimeoutSeconds()
 	i.roundTimer.Reset(roundTimeout)
-	i.Logger.Info("started timeout clock", zap.Float64("seconds", roundTimeout.Seconds()), zap.Uint64("round", uint64(i.GetState().GetRound())))
+	i.Logger.Info("started timeout clock", zap.Float64("seconds", roundTimeout.Seconds()), logfields.Round(i.GetState().GetRound()))
 }
@@ -0,0 +1,69 @@
+package logfields
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+)
+
+// keys of the shared log fields, should be kept stable as logs are aggregated by them
+const (
+	HeightKey     = "height"
+	RoundKey      = "round"
+	RoleKey       = "role"
+	IdentifierKey = "identifier"
+	PubKeyKey     = "pubKey"
+	TraceIDKey    = "traceID"
+)
+
+// traceIDSize is the size (in bytes) of a duty trace id
+const traceIDSize = 8
+
+// Height returns the log field of a qbft height
+func Height(height specqbft.Height) zap.Field {
+	return zap.Uint64(HeightKey, uint64(height))
+}
+
+// Round returns the log field of a qbft round
+func Round(round specqbft.Round) zap.Field {
+	return zap.Uint64(RoundKey, uint64(round))
+}
+
+// Role returns the log field of a beacon role
+func Role(role spectypes.BeaconRole) zap.Field {
+	return zap.String(RoleKey, role.String())
+}
+
+// Identifier returns the log field of a message identifier, encoded as hex
+func Identifier(identifier []byte) zap.Field {
+	return zap.String(IdentifierKey, hex.EncodeToString(identifier))
+}
+
+// PubKey returns the log field of a validator public key, encoded as hex
+func PubKey(pubKey []byte) zap.Field {
+	return zap.String(PubKeyKey, hex.EncodeToString(pubKey))
+}
+
+// TraceID returns the log field of a duty trace id
+func TraceID(traceID string) zap.Field {
+	return zap.String(TraceIDKey, traceID)
+}
+
+// DutyTraceID returns the trace id of the given duty, derived from the validator public key, role and slot.
+// the id is deterministic, therefore the lifecycle of a duty is greppable across components (and operators)
+func DutyTraceID(duty *spectypes.Duty) string {
+	if duty == nil {
+		return ""
+	}
+	h := sha256.New()
+	_, _ = h.Write(duty.PubKey[:])
+	var buf [12]byte
+	binary.LittleEndian.PutUint64(buf[:8], uint64(duty.Slot))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(duty.Type))
+	_, _ = h.Write(buf[:])
+	return hex.EncodeToString(h.Sum(nil)[:traceIDSize])
+}
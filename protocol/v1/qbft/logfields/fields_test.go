@@ -0,0 +1,59 @@
+package logfields
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		field         zap.Field
+		expectedKey   string
+		expectedType  zapcore.FieldType
+		expectedValue interface{}
+	}{
+		{"height", Height(specqbft.Height(12)), "height", zapcore.Uint64Type, int64(12)},
+		{"round", Round(specqbft.Round(3)), "round", zapcore.Uint64Type, int64(3)},
+		{"role", Role(spectypes.BNRoleAttester), "role", zapcore.StringType, "ATTESTER"},
+		{"identifier", Identifier([]byte{1, 2, 3}), "identifier", zapcore.StringType, "010203"},
+		{"pubKey", PubKey([]byte{0xab, 0xcd}), "pubKey", zapcore.StringType, "abcd"},
+		{"traceID", TraceID("0102"), "traceID", zapcore.StringType, "0102"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedKey, test.field.Key)
+			require.Equal(t, test.expectedType, test.field.Type)
+			switch v := test.expectedValue.(type) {
+			case int64:
+				require.Equal(t, v, test.field.Integer)
+			case string:
+				require.Equal(t, v, test.field.String)
+			}
+		})
+	}
+}
+
+func TestDutyTraceID(t *testing.T) {
+	duty := &spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: phase0.BLSPubKey{1, 2, 3}, Slot: 100}
+	traceID := DutyTraceID(duty)
+	require.Len(t, traceID, traceIDSize*2)
+	require.Equal(t, traceID, DutyTraceID(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: phase0.BLSPubKey{1, 2, 3}, Slot: 100}))
+
+	// other slot, role or validator have a different trace id
+	for _, other := range []*spectypes.Duty{
+		{Type: spectypes.BNRoleAttester, PubKey: phase0.BLSPubKey{1, 2, 3}, Slot: 101},
+		{Type: spectypes.BNRoleAggregator, PubKey: phase0.BLSPubKey{1, 2, 3}, Slot: 100},
+		{Type: spectypes.BNRoleAttester, PubKey: phase0.BLSPubKey{1, 2, 4}, Slot: 100},
+	} {
+		require.NotEqual(t, traceID, DutyTraceID(other))
+	}
+	require.Empty(t, DutyTraceID(nil))
+}
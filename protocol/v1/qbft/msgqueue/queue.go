@@ -46,8 +46,10 @@ type MsgQueue interface {
 	Clean(cleaners ...Cleaner) int64
 	// Count counts messages for the given index
 	Count(idx Index) int
-	// Len counts all messages
+	// Len counts all indices
 	Len() int
+	// Size counts all the messages in all indices
+	Size() int
 }
 
 // New creates a new MsgQueue
@@ -277,6 +279,17 @@ func (q *queue) Len() int {
 	return len(q.items)
 }
 
+func (q *queue) Size() int {
+	q.itemsLock.RLock()
+	defer q.itemsLock.RUnlock()
+
+	size := 0
+	for _, containers := range q.items {
+		size += len(containers)
+	}
+	return size
+}
+
 // indexMessage returns indexes for the given message.
 // NOTE: this function is not thread safe
 func (q *queue) indexMessage(msg *spectypes.SSVMessage) []Index {
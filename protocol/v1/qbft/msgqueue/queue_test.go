@@ -51,6 +51,19 @@ func TestNewMsgQueue(t *testing.T) {
 		require.Equal(t, 0, q.Count(idx2))
 	})
 
+	t.Run("size", func(t *testing.T) {
+		q, err := New(logger, WithIndexers(DefaultMsgIndexer()))
+		require.NoError(t, err)
+		q.Add(msg1)
+		q.Add(msg2)
+		q.Add(msg3)
+		require.Equal(t, 2, q.Len())
+		require.Equal(t, 3, q.Size())
+		idx := DefaultMsgIndex(spectypes.SSVConsensusMsgType, spectypes.NewMsgID([]byte("dummy-id-1"), spectypes.BNRoleAttester))
+		q.Pop(1, idx)
+		require.Equal(t, 2, q.Size())
+	})
+
 	t.Run("clean", func(t *testing.T) {
 		q, err := New(logger, WithIndexers(DefaultMsgIndexer()))
 		require.NoError(t, err)
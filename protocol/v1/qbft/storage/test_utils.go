@@ -136,7 +136,7 @@ func (i *ibftStorage) GetCurrentInstance(identifier []byte) (*qbft.State, bool,
 	if err := json.Unmarshal(val, ret); err != nil {
 		return nil, false, errors.Wrap(err, "un-marshaling error")
 	}
-	return ret, false, nil
+	return ret, found, nil
 }
 
 // SaveLastChangeRoundMsg updates last change round message
@@ -24,6 +24,18 @@ const (
 	ModeFullNode
 )
 
+// String returns the name of the mode
+func (m Mode) String() string {
+	switch m {
+	case ModeLightNode:
+		return "light"
+	case ModeFullNode:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
 // Decided helps to decouple light from full-node mode where the node is saving decided history.
 // in light mode, the node doesn't save history, only last/highest decided messages.
 type Decided interface {
@@ -27,6 +27,11 @@ func NewDecidedFactory(logger *zap.Logger, mode strategy.Mode, decidedStore qbft
 	}
 }
 
+// Mode returns the mode of the created strategies
+func (f *Factory) Mode() strategy.Mode {
+	return f.mode
+}
+
 // GetStrategy returns the decided strategy
 func (f *Factory) GetStrategy() strategy.Decided {
 	switch f.mode {
@@ -14,6 +14,7 @@ import (
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy"
+	protocolsync "github.com/bloxapp/ssv/protocol/v1/sync"
 	"github.com/bloxapp/ssv/protocol/v1/sync/lastdecided"
 )
 
@@ -45,6 +46,7 @@ func (ln *lightNode) Sync(ctx context.Context, identifier []byte, from, to *spec
 		to = highest
 	}
 	if to != nil {
+		protocolsync.ReportProgress(ctx, to.Message.Height, 1)
 		return []*specqbft.SignedMessage{to}, nil
 	}
 	return []*specqbft.SignedMessage{}, nil
@@ -177,12 +177,18 @@ func NewByteValue(val []byte) atomic.Value {
 type InstanceConfig struct {
 	RoundChangeDurationSeconds float32
 	LeaderProposalDelaySeconds float32
+	// MaxValueSize is the max size (bytes) of a proposed value, 0 means no limit
+	MaxValueSize int
 }
 
+// DefaultMaxValueSize is the default max size (bytes) of a proposed value
+const DefaultMaxValueSize = 1 << 20
+
 //DefaultConsensusParams returns the default round change duration time
 func DefaultConsensusParams() *InstanceConfig {
 	return &InstanceConfig{
 		RoundChangeDurationSeconds: 3,
 		LeaderProposalDelaySeconds: 1,
+		MaxValueSize:               DefaultMaxValueSize,
 	}
 }
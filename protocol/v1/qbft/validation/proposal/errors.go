@@ -0,0 +1,82 @@
+package proposal
+
+import (
+	"errors"
+	"fmt"
+
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+)
+
+// LeaderMismatchError is returned when the proposal was not sent by the round leader
+type LeaderMismatchError struct{}
+
+func (e *LeaderMismatchError) Error() string {
+	return "proposal leader invalid"
+}
+
+// MalformedDataError is returned when the proposal data could not be decoded or is invalid
+type MalformedDataError struct {
+	Err error
+}
+
+func (e *MalformedDataError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *MalformedDataError) Unwrap() error {
+	return e.Err
+}
+
+// NotJustifiedError is returned when the proposal justification is invalid
+type NotJustifiedError struct {
+	Err error
+}
+
+func (e *NotJustifiedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *NotJustifiedError) Unwrap() error {
+	return e.Err
+}
+
+// ValueTooLargeError is returned when the proposed value exceeds the max value size
+type ValueTooLargeError struct {
+	Size    int
+	MaxSize int
+}
+
+func (e *ValueTooLargeError) Error() string {
+	return fmt.Sprintf("proposal value is too large: %d > %d", e.Size, e.MaxSize)
+}
+
+// StaleStateError is returned when the proposal is not valid with the current state of the instance
+type StaleStateError struct{}
+
+func (e *StaleStateError) Error() string {
+	return "proposal is not valid with current state"
+}
+
+// ValidationResult maps the given proposal validation error to its validation result
+func ValidationResult(err error) protocolp2p.MsgValidationResult {
+	if err == nil {
+		return protocolp2p.ValidationAccept
+	}
+	var leaderErr *LeaderMismatchError
+	var malformedErr *MalformedDataError
+	var notJustifiedErr *NotJustifiedError
+	var staleErr *StaleStateError
+	var tooLargeErr *ValueTooLargeError
+	switch {
+	case errors.As(err, &leaderErr), errors.As(err, &malformedErr), errors.As(err, &tooLargeErr):
+		return protocolp2p.ValidationRejectHigh
+	case errors.As(err, &notJustifiedErr):
+		return protocolp2p.ValidationRejectMedium
+	case errors.As(err, &staleErr):
+		return protocolp2p.ValidationRejectLow
+	default:
+		return protocolp2p.ValidationRejectMedium
+	}
+}
@@ -0,0 +1,93 @@
+package proposal
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+)
+
+// justificationKey is the key of a cached justification result
+type justificationKey struct {
+	height specqbft.Height
+	round  specqbft.Round
+	root   [32]byte
+}
+
+// JustificationCache memoizes the results of Justify within an instance,
+// results are keyed by (height, round, justification root) and are dropped once the round advances
+type JustificationCache struct {
+	lock    sync.Mutex
+	round   specqbft.Round
+	results map[justificationKey]error
+}
+
+// NewJustificationCache creates a new cache
+func NewJustificationCache() *JustificationCache {
+	return &JustificationCache{
+		results: make(map[justificationKey]error),
+	}
+}
+
+// Justify returns the cached result of Justify for the given justification, or calls Justify and caches its result.
+// a nil cache simply calls Justify
+func (c *JustificationCache) Justify(share *beacon.Share, state *qbft.State, round specqbft.Round, roundChanges, prepares []*specqbft.SignedMessage, proposedValue []byte) error {
+	if c == nil {
+		return Justify(share, state, round, roundChanges, prepares, proposedValue)
+	}
+	root, err := justificationRoot(roundChanges, prepares, proposedValue)
+	if err != nil {
+		return Justify(share, state, round, roundChanges, prepares, proposedValue)
+	}
+	key := justificationKey{height: state.GetHeight(), round: round, root: root}
+
+	c.lock.Lock()
+	if currentRound := state.GetRound(); currentRound > c.round {
+		c.round = currentRound
+		c.results = make(map[justificationKey]error)
+	}
+	res, ok := c.results[key]
+	c.lock.Unlock()
+	if ok {
+		return res
+	}
+
+	res = Justify(share, state, round, roundChanges, prepares, proposedValue)
+
+	c.lock.Lock()
+	c.results[key] = res
+	c.lock.Unlock()
+
+	return res
+}
+
+// justificationRoot returns the hash of the given justification messages and value
+func justificationRoot(roundChanges, prepares []*specqbft.SignedMessage, proposedValue []byte) ([32]byte, error) {
+	h := sha256.New()
+	lenBuf := make([]byte, 8)
+	write := func(b []byte) {
+		binary.LittleEndian.PutUint64(lenBuf, uint64(len(b)))
+		_, _ = h.Write(lenBuf)
+		_, _ = h.Write(b)
+	}
+	for _, msgs := range [][]*specqbft.SignedMessage{roundChanges, prepares} {
+		binary.LittleEndian.PutUint64(lenBuf, uint64(len(msgs)))
+		_, _ = h.Write(lenBuf)
+		for _, msg := range msgs {
+			encoded, err := msg.Encode()
+			if err != nil {
+				return [32]byte{}, err
+			}
+			write(encoded)
+		}
+	}
+	write(proposedValue)
+
+	var root [32]byte
+	copy(root[:], h.Sum(nil))
+	return root, nil
+}
@@ -0,0 +1,100 @@
+package proposal
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+)
+
+func TestJustificationCache(t *testing.T) {
+	sks, nodes := GenerateNodes(4)
+	share := &beacon.Share{
+		Committee: nodes,
+	}
+	state := &qbft.State{}
+	state.Height.Store(specqbft.Height(1))
+	state.Round.Store(specqbft.Round(2))
+
+	valid := roundChanges(t, sks, 1, 2, []spectypes.OperatorID{1, 2, 3})
+	noQuorum := roundChanges(t, sks, 1, 2, []spectypes.OperatorID{1, 2})
+	wrongHeight := roundChanges(t, sks, 2, 2, []spectypes.OperatorID{1, 2, 3})
+
+	cache := NewJustificationCache()
+	for _, rcs := range [][]*specqbft.SignedMessage{valid, noQuorum, wrongHeight} {
+		expected := Justify(share, state, 2, rcs, nil, []byte("value"))
+		// first call fills the cache while the second is served from it
+		for i := 0; i < 2; i++ {
+			err := cache.Justify(share, state, 2, rcs, nil, []byte("value"))
+			if expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, expected.Error())
+			}
+		}
+	}
+	require.Len(t, cache.results, 3)
+
+	// round advances -> cache is invalidated
+	state.Round.Store(specqbft.Round(3))
+	require.NoError(t, cache.Justify(share, state, 2, valid, nil, []byte("value")))
+	require.Len(t, cache.results, 1)
+	require.Equal(t, specqbft.Round(3), cache.round)
+
+	// nil cache works without caching
+	var nilCache *JustificationCache
+	require.NoError(t, nilCache.Justify(share, state, 2, valid, nil, []byte("value")))
+}
+
+func BenchmarkJustificationCache(b *testing.B) {
+	sks, nodes := GenerateNodes(4)
+	share := &beacon.Share{
+		Committee: nodes,
+	}
+	state := &qbft.State{}
+	state.Height.Store(specqbft.Height(1))
+	state.Round.Store(specqbft.Round(2))
+	rcs := roundChanges(b, sks, 1, 2, []spectypes.OperatorID{1, 2, 3})
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, Justify(share, state, 2, rcs, nil, []byte("value")))
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		cache := NewJustificationCache()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, cache.Justify(share, state, 2, rcs, nil, []byte("value")))
+		}
+	})
+}
+
+// roundChanges creates signed round change messages (w/o prepared value) of the given signers
+func roundChanges(tb testing.TB, sks map[spectypes.OperatorID]*bls.SecretKey, height specqbft.Height, round specqbft.Round, signers []spectypes.OperatorID) []*specqbft.SignedMessage {
+	data, err := (&specqbft.RoundChangeData{}).Encode()
+	require.NoError(tb, err)
+	var res []*specqbft.SignedMessage
+	for _, id := range signers {
+		msg := &specqbft.Message{
+			MsgType:    specqbft.RoundChangeMsgType,
+			Height:     height,
+			Round:      round,
+			Identifier: []byte("Identifier"),
+			Data:       data,
+		}
+		sig, err := signMessage(msg, sks[id])
+		require.NoError(tb, err)
+		res = append(res, &specqbft.SignedMessage{
+			Message:   msg,
+			Signature: sig.Serialize(),
+			Signers:   []spectypes.OperatorID{id},
+		})
+	}
+	return res
+}
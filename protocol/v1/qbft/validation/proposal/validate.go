@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/validation/changeround"
@@ -26,6 +27,11 @@ type LeaderResolver func(round specqbft.Round) uint64
 
 // ValidateProposalMsg validates proposal message
 func ValidateProposalMsg(share *beacon.Share, state *qbft.State, resolver LeaderResolver) pipelines.SignedMessagePipeline {
+	return ValidateProposalMsgWithCache(share, state, resolver, nil)
+}
+
+// ValidateProposalMsgWithCache validates proposal message, justification results are memoized in the given cache
+func ValidateProposalMsgWithCache(share *beacon.Share, state *qbft.State, resolver LeaderResolver, cache *JustificationCache) pipelines.SignedMessagePipeline {
 	return pipelines.WrapFunc("validate proposal", func(signedMessage *specqbft.SignedMessage) error {
 		signers := signedMessage.GetSigners()
 		if len(signers) != 1 {
@@ -34,20 +40,20 @@ func ValidateProposalMsg(share *beacon.Share, state *qbft.State, resolver Leader
 
 		leader := spectypes.OperatorID(resolver(signedMessage.Message.Round))
 		if !signedMessage.MatchedSigners([]spectypes.OperatorID{leader}) {
-			return fmt.Errorf("proposal leader invalid")
+			return &LeaderMismatchError{}
 		}
 
 		proposalData, err := signedMessage.Message.GetProposalData()
 		if err != nil {
-			return fmt.Errorf("could not get proposal data: %w", err)
+			return &MalformedDataError{Err: fmt.Errorf("could not get proposal data: %w", err)}
 		}
 
 		if err := proposalData.Validate(); err != nil {
-			return errors.Wrap(err, "proposalData invalid")
+			return &MalformedDataError{Err: errors.Wrap(err, "proposalData invalid")}
 		}
 
-		if err := Justify(share, state, signedMessage.Message.Round, proposalData.RoundChangeJustification, proposalData.PrepareJustification, proposalData.Data); err != nil {
-			return fmt.Errorf("proposal not justified: %w", err)
+		if err := cache.Justify(share, state, signedMessage.Message.Round, proposalData.RoundChangeJustification, proposalData.PrepareJustification, proposalData.Data); err != nil {
+			return &NotJustifiedError{Err: fmt.Errorf("proposal not justified: %w", err)}
 		}
 
 		proposalAcceptedForCurrentRound := state.GetProposalAcceptedForCurrentRound()
@@ -56,10 +62,69 @@ func ValidateProposalMsg(share *beacon.Share, state *qbft.State, resolver Leader
 			(proposalAcceptedForCurrentRound != nil && signedMessage.Message.Round > round) {
 			return nil
 		}
-		return errors.New("proposal is not valid with current state")
+		return &StaleStateError{}
+	})
+}
+
+// ValidateValueSize rejects proposals with a value larger than maxSize, 0 means no limit.
+// it should be placed before signature verification so oversized values are dropped early
+func ValidateValueSize(maxSize int) pipelines.SignedMessagePipeline {
+	return pipelines.WrapFunc("validate proposal value size", func(signedMessage *specqbft.SignedMessage) error {
+		if maxSize <= 0 {
+			return nil
+		}
+		proposalData, err := signedMessage.Message.GetProposalData()
+		if err != nil {
+			// malformed data is reported by ValidateProposalMsg
+			return nil
+		}
+		if size := len(proposalData.Data); size > maxSize {
+			return &ValueTooLargeError{Size: size, MaxSize: maxSize}
+		}
+		return nil
 	})
 }
 
+// ValidateFormat runs the checks of a proposal message that don't depend on the instance state (signers, data and value size),
+// therefore it can be used before the message reaches the instance, e.g. by the topic validator
+func ValidateFormat(maxValueSize int) pipelines.SignedMessagePipeline {
+	return pipelines.WrapFunc("validate proposal format", func(signedMessage *specqbft.SignedMessage) error {
+		if len(signedMessage.GetSigners()) != 1 {
+			return ErrInvalidSignersNum
+		}
+		proposalData, err := signedMessage.Message.GetProposalData()
+		if err != nil {
+			return &MalformedDataError{Err: fmt.Errorf("could not get proposal data: %w", err)}
+		}
+		if err := proposalData.Validate(); err != nil {
+			return &MalformedDataError{Err: errors.Wrap(err, "proposalData invalid")}
+		}
+		if size := len(proposalData.Data); maxValueSize > 0 && size > maxValueSize {
+			return &ValueTooLargeError{Size: size, MaxSize: maxValueSize}
+		}
+		return nil
+	})
+}
+
+// MsgValidator returns a network message validator that validates the format of proposal messages,
+// the result is based on ValidationResult. other messages are accepted as they are validated by the instance
+func MsgValidator(maxValueSize int) func(msg *spectypes.SSVMessage) protocolp2p.MsgValidationResult {
+	validate := ValidateFormat(maxValueSize)
+	return func(msg *spectypes.SSVMessage) protocolp2p.MsgValidationResult {
+		if msg.MsgType != spectypes.SSVConsensusMsgType {
+			return protocolp2p.ValidationAccept
+		}
+		signedMsg := &specqbft.SignedMessage{}
+		if err := signedMsg.Decode(msg.GetData()); err != nil || signedMsg.Message == nil {
+			return protocolp2p.ValidationRejectHigh
+		}
+		if signedMsg.Message.MsgType != specqbft.ProposalMsgType {
+			return protocolp2p.ValidationAccept
+		}
+		return ValidationResult(validate.Run(signedMsg))
+	}
+}
+
 // Justify implements:
 // predicate JustifyProposal(hPROPOSAL, λi, round, value)
 // 	return
@@ -154,7 +219,9 @@ func Justify(share *beacon.Share, state *qbft.State, round specqbft.Round, round
 	return nil
 }
 
-// highestPrepared returns a round change message with the highest prepared round, returns nil if none found
+// highestPrepared returns a round change message with the highest prepared round, returns nil if none found.
+// in case several round changes share the highest prepared round with different values,
+// the one with the lexicographically smallest value is selected so all operators pick the same round change
 func highestPrepared(roundChanges []*specqbft.SignedMessage) (*specqbft.SignedMessage, error) {
 	var ret *specqbft.SignedMessage
 	for _, rc := range roundChanges {
@@ -174,7 +241,8 @@ func highestPrepared(roundChanges []*specqbft.SignedMessage) (*specqbft.SignedMe
 			if err != nil {
 				return nil, errors.Wrap(err, "could not get round change data")
 			}
-			if retRCData.PreparedRound < rcData.PreparedRound {
+			if retRCData.PreparedRound < rcData.PreparedRound ||
+				(retRCData.PreparedRound == rcData.PreparedRound && bytes.Compare(rcData.PreparedValue, retRCData.PreparedValue) < 0) {
 				ret = rc
 			}
 		}
@@ -1,6 +1,7 @@
 package proposal
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/protocol/v1/types"
 )
@@ -135,3 +137,250 @@ func TestValidateProposalValue(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateProposalMsg_ErrorTypes(t *testing.T) {
+	sks, nodes := GenerateNodes(4)
+	share := &beacon.Share{
+		Committee: nodes,
+	}
+
+	proposalData := &specqbft.ProposalData{Data: []byte("value")}
+	encodedProposal, err := proposalData.Encode()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		stateRound specqbft.Round
+		msg        *specqbft.SignedMessage
+		check      func(err error) bool
+		result     protocolp2p.MsgValidationResult
+	}{
+		{
+			"leader mismatch",
+			1,
+			SignMsg(t, 2, sks[2], &specqbft.Message{
+				MsgType:    specqbft.ProposalMsgType,
+				Round:      1,
+				Identifier: []byte("Identifier"),
+				Data:       encodedProposal,
+			}),
+			func(err error) bool {
+				var target *LeaderMismatchError
+				return errors.As(err, &target)
+			},
+			protocolp2p.ValidationRejectHigh,
+		},
+		{
+			"malformed data",
+			1,
+			SignMsg(t, 1, sks[1], &specqbft.Message{
+				MsgType:    specqbft.ProposalMsgType,
+				Round:      1,
+				Identifier: []byte("Identifier"),
+				Data:       []byte("wrong value"),
+			}),
+			func(err error) bool {
+				var target *MalformedDataError
+				return errors.As(err, &target)
+			},
+			protocolp2p.ValidationRejectHigh,
+		},
+		{
+			"not justified",
+			2,
+			SignMsg(t, 1, sks[1], &specqbft.Message{
+				MsgType:    specqbft.ProposalMsgType,
+				Round:      2,
+				Identifier: []byte("Identifier"),
+				Data:       encodedProposal,
+			}),
+			func(err error) bool {
+				var target *NotJustifiedError
+				return errors.As(err, &target)
+			},
+			protocolp2p.ValidationRejectMedium,
+		},
+		{
+			"stale with state",
+			2,
+			SignMsg(t, 1, sks[1], &specqbft.Message{
+				MsgType:    specqbft.ProposalMsgType,
+				Round:      1,
+				Identifier: []byte("Identifier"),
+				Data:       encodedProposal,
+			}),
+			func(err error) bool {
+				var target *StaleStateError
+				return errors.As(err, &target)
+			},
+			protocolp2p.ValidationRejectLow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := &qbft.State{}
+			state.Round.Store(test.stateRound)
+
+			err := ValidateProposalMsg(share, state, func(round specqbft.Round) uint64 {
+				return 1
+			}).Run(test.msg)
+			require.Error(t, err)
+			require.True(t, test.check(err))
+			require.Equal(t, test.result, ValidationResult(err))
+		})
+	}
+}
+
+func TestHighestPrepared(t *testing.T) {
+	roundChange := func(preparedRound specqbft.Round, preparedValue []byte) *specqbft.SignedMessage {
+		data := &specqbft.RoundChangeData{
+			PreparedRound: preparedRound,
+			PreparedValue: preparedValue,
+		}
+		encoded, err := data.Encode()
+		require.NoError(t, err)
+		return &specqbft.SignedMessage{
+			Message: &specqbft.Message{
+				MsgType: specqbft.RoundChangeMsgType,
+				Round:   3,
+				Data:    encoded,
+			},
+		}
+	}
+	getPreparedValue := func(rc *specqbft.SignedMessage) []byte {
+		data, err := rc.Message.GetRoundChangeData()
+		require.NoError(t, err)
+		return data.PreparedValue
+	}
+
+	t.Run("highest round", func(t *testing.T) {
+		highest, err := highestPrepared([]*specqbft.SignedMessage{
+			roundChange(0, nil),
+			roundChange(1, []byte("a")),
+			roundChange(2, []byte("b")),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []byte("b"), getPreparedValue(highest))
+	})
+
+	t.Run("equal rounds", func(t *testing.T) {
+		rc1 := roundChange(2, []byte("value 2"))
+		rc2 := roundChange(2, []byte("value 1"))
+		rc3 := roundChange(1, []byte("value 0"))
+
+		highest, err := highestPrepared([]*specqbft.SignedMessage{rc1, rc2, rc3})
+		require.NoError(t, err)
+		require.Equal(t, []byte("value 1"), getPreparedValue(highest))
+
+		// order of round changes doesn't affect the selection
+		highest, err = highestPrepared([]*specqbft.SignedMessage{rc3, rc2, rc1})
+		require.NoError(t, err)
+		require.Equal(t, []byte("value 1"), getPreparedValue(highest))
+	})
+
+	t.Run("none prepared", func(t *testing.T) {
+		highest, err := highestPrepared([]*specqbft.SignedMessage{roundChange(0, nil)})
+		require.NoError(t, err)
+		require.Nil(t, highest)
+	})
+}
+
+func TestValidateValueSize(t *testing.T) {
+	maxSize := 64
+	proposalMsg := func(size int) *specqbft.SignedMessage {
+		data, err := (&specqbft.ProposalData{Data: make([]byte, size)}).Encode()
+		require.NoError(t, err)
+		return &specqbft.SignedMessage{
+			Message: &specqbft.Message{
+				MsgType:    specqbft.ProposalMsgType,
+				Round:      1,
+				Identifier: []byte("Identifier"),
+				Data:       data,
+			},
+		}
+	}
+
+	t.Run("at limit", func(t *testing.T) {
+		require.NoError(t, ValidateValueSize(maxSize).Run(proposalMsg(maxSize)))
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		err := ValidateValueSize(maxSize).Run(proposalMsg(maxSize + 1))
+		var target *ValueTooLargeError
+		require.True(t, errors.As(err, &target))
+		require.Equal(t, maxSize+1, target.Size)
+		require.EqualError(t, err, "proposal value is too large: 65 > 64")
+		require.Equal(t, protocolp2p.ValidationRejectHigh, ValidationResult(err))
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		require.NoError(t, ValidateValueSize(0).Run(proposalMsg(maxSize*10)))
+	})
+}
+
+func TestMsgValidator(t *testing.T) {
+	sks, _ := GenerateNodes(4)
+	mv := MsgValidator(16)
+
+	encode := func(data *specqbft.ProposalData) []byte {
+		encoded, err := data.Encode()
+		require.NoError(t, err)
+		return encoded
+	}
+	ssvMsg := func(signedMsg *specqbft.SignedMessage) *spectypes.SSVMessage {
+		data, err := signedMsg.Encode()
+		require.NoError(t, err)
+		return &spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, Data: data}
+	}
+	proposalMsg := func(data []byte) *specqbft.Message {
+		return &specqbft.Message{
+			MsgType:    specqbft.ProposalMsgType,
+			Round:      1,
+			Identifier: []byte("Identifier"),
+			Data:       data,
+		}
+	}
+
+	t.Run("valid proposal", func(t *testing.T) {
+		msg := ssvMsg(SignMsg(t, 1, sks[1], proposalMsg(encode(&specqbft.ProposalData{Data: []byte("value")}))))
+		require.Equal(t, protocolp2p.ValidationAccept, mv(msg))
+	})
+
+	t.Run("other consensus messages", func(t *testing.T) {
+		prepareData, err := (&specqbft.PrepareData{Data: []byte("value")}).Encode()
+		require.NoError(t, err)
+		msg := ssvMsg(SignMsg(t, 1, sks[1], &specqbft.Message{
+			MsgType:    specqbft.PrepareMsgType,
+			Round:      1,
+			Identifier: []byte("Identifier"),
+			Data:       prepareData,
+		}))
+		require.Equal(t, protocolp2p.ValidationAccept, mv(msg))
+	})
+
+	t.Run("other message types", func(t *testing.T) {
+		msg := &spectypes.SSVMessage{MsgType: spectypes.SSVPartialSignatureMsgType, Data: []byte("xxx")}
+		require.Equal(t, protocolp2p.ValidationAccept, mv(msg))
+	})
+
+	t.Run("undecodable message", func(t *testing.T) {
+		msg := &spectypes.SSVMessage{MsgType: spectypes.SSVConsensusMsgType, Data: []byte("xxx")}
+		require.Equal(t, protocolp2p.ValidationRejectHigh, mv(msg))
+	})
+
+	t.Run("malformed data", func(t *testing.T) {
+		msg := ssvMsg(SignMsg(t, 1, sks[1], proposalMsg([]byte("wrong value"))))
+		require.Equal(t, protocolp2p.ValidationRejectHigh, mv(msg))
+	})
+
+	t.Run("value too large", func(t *testing.T) {
+		msg := ssvMsg(SignMsg(t, 1, sks[1], proposalMsg(encode(&specqbft.ProposalData{Data: make([]byte, 17)}))))
+		require.Equal(t, protocolp2p.ValidationRejectHigh, mv(msg))
+	})
+
+	t.Run("multiple signers", func(t *testing.T) {
+		signedMsg := SignMsg(t, 1, sks[1], proposalMsg(encode(&specqbft.ProposalData{Data: []byte("value")})))
+		signedMsg.Signers = []spectypes.OperatorID{1, 2}
+		require.Equal(t, protocolp2p.ValidationRejectMedium, mv(ssvMsg(signedMsg)))
+	})
+}
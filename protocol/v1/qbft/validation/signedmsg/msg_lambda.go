@@ -10,13 +10,28 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/pipelines"
 )
 
+// IdentifierMismatchError is returned when a message identifier does not equal the expected identifier
+type IdentifierMismatchError struct {
+	Expected []byte
+	Actual   []byte
+}
+
+func (e *IdentifierMismatchError) Error() string {
+	return fmt.Sprintf("message identifier (%s) does not equal expected identifier (%s)",
+		hex.EncodeToString(e.Actual), hex.EncodeToString(e.Expected))
+}
+
+// CheckIdentifier returns IdentifierMismatchError if the given identifier does not equal the expected one
+func CheckIdentifier(identifier, expected []byte) error {
+	if !bytes.Equal(identifier, expected) {
+		return &IdentifierMismatchError{Expected: expected, Actual: identifier}
+	}
+	return nil
+}
+
 // ValidateIdentifiers validates current and previous identifiers
 func ValidateIdentifiers(identifier []byte) pipelines.SignedMessagePipeline {
 	return pipelines.WrapFunc("identifier", func(signedMessage *specqbft.SignedMessage) error {
-		if !bytes.Equal(signedMessage.Message.Identifier, identifier) {
-			return fmt.Errorf("message identifier (%s) does not equal expected identifier (%s)",
-				hex.EncodeToString(signedMessage.Message.Identifier), hex.EncodeToString(identifier))
-		}
-		return nil
+		return CheckIdentifier(signedMessage.Message.Identifier, identifier)
 	})
 }
@@ -38,6 +38,9 @@ func HistoryHandler(plogger *zap.Logger, store qbftstorage.DecidedMsgStore, repo
 			msdID := msg.GetID()
 			results, err := store.GetDecided(msdID[:], sm.Params.Height[0], sm.Params.Height[1])
 			sm.UpdateResults(err, results...)
+			if sm.Status == message.StatusNotFound {
+				sm.LowestAvailable = lowestAvailableHeight(logger, store, msdID, sm.Params.Height[0])
+			}
 		}
 
 		data, err := sm.Encode()
@@ -49,3 +52,18 @@ func HistoryHandler(plogger *zap.Logger, store qbftstorage.DecidedMsgStore, repo
 		return msg, nil
 	}
 }
+
+// lowestAvailableHeight returns the lowest height that can be served, in case the requested range is missing.
+// only the last decided is guaranteed to exist, therefore it is used as a hint if it is higher than the requested range start.
+func lowestAvailableHeight(logger *zap.Logger, store qbftstorage.DecidedMsgStore, msgID spectypes.MessageID, from specqbft.Height) *specqbft.Height {
+	last, err := store.GetLastDecided(msgID[:])
+	if err != nil {
+		logger.Debug("could not get last decided", zap.Error(err))
+		return nil
+	}
+	if last == nil || last.Message == nil || last.Message.Height < from {
+		return nil
+	}
+	height := last.Message.Height
+	return &height
+}
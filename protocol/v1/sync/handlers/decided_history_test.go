@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+type nopReporting struct{}
+
+func (r *nopReporting) ReportValidation(msg *spectypes.SSVMessage, res protocolp2p.MsgValidationResult) {
+}
+
+func TestHistoryHandler_MissingRange(t *testing.T) {
+	db, err := kv.New(basedb.Options{
+		Type:   "badger-memory",
+		Path:   "",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	store := qbftstorage.NewQBFTStore(db, zap.L(), "attestation")
+	identifier := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
+	// light node, only the last decided is kept
+	require.NoError(t, store.SaveLastDecided(&specqbft.SignedMessage{
+		Signature: []byte("signature"),
+		Signers:   []spectypes.OperatorID{1, 2, 3},
+		Message: &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     20,
+			Round:      1,
+			Identifier: identifier[:],
+		},
+	}))
+	handler := HistoryHandler(zap.L(), store, &nopReporting{}, 25)
+
+	request := func(from, to specqbft.Height) *message.SyncMessage {
+		data, err := (&message.SyncMessage{
+			Protocol: message.DecidedHistoryType,
+			Params: &message.SyncParams{
+				Height:     []specqbft.Height{from, to},
+				Identifier: identifier,
+			},
+		}).Encode()
+		require.NoError(t, err)
+		res, err := handler(&spectypes.SSVMessage{
+			MsgType: message.SSVSyncMsgType,
+			MsgID:   identifier,
+			Data:    data,
+		})
+		require.NoError(t, err)
+		sm := &message.SyncMessage{}
+		require.NoError(t, sm.Decode(res.Data))
+		return sm
+	}
+
+	t.Run("pruned range", func(t *testing.T) {
+		sm := request(5, 10)
+		require.Equal(t, message.StatusNotFound, sm.Status)
+		require.NotNil(t, sm.LowestAvailable)
+		require.Equal(t, specqbft.Height(20), *sm.LowestAvailable)
+	})
+
+	t.Run("future range", func(t *testing.T) {
+		sm := request(25, 30)
+		require.Equal(t, message.StatusNotFound, sm.Status)
+		require.Nil(t, sm.LowestAvailable)
+	})
+}
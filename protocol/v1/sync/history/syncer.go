@@ -55,6 +55,7 @@ func (s syncer) SyncRange(ctx context.Context, identifier spectypes.MessageID, h
 				return err
 			}
 			s.processMessages(ctx, msgs, handler, visited)
+			sync.ReportProgress(ctx, lastBatch, len(visited))
 			elapsed := time.Since(start)
 			s.logger.Debug("received and processed history batch", zap.Int64("currentHighest", int64(lastBatch)), zap.Int64("needToSync", int64(to)), zap.Float64("duration", elapsed.Seconds()))
 			return nil
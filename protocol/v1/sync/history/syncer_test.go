@@ -0,0 +1,84 @@
+package history
+
+import (
+	"context"
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v1/message"
+	p2pprotocol "github.com/bloxapp/ssv/protocol/v1/p2p"
+	protocolsync "github.com/bloxapp/ssv/protocol/v1/sync"
+)
+
+// batchSyncer returns decided history in batches of the given size
+type batchSyncer struct {
+	p2pprotocol.Syncer
+	t     *testing.T
+	batch specqbft.Height
+}
+
+func (s *batchSyncer) GetHistory(mid spectypes.MessageID, from, to specqbft.Height, targets ...string) ([]p2pprotocol.SyncResult, specqbft.Height, error) {
+	last := from + s.batch
+	if last > to {
+		last = to
+	}
+	sm := &message.SyncMessage{
+		Protocol: message.DecidedHistoryType,
+		Params: &message.SyncParams{
+			Height:     []specqbft.Height{from, last},
+			Identifier: mid,
+		},
+		Status: message.StatusSuccess,
+	}
+	for h := from + 1; h <= last; h++ {
+		sm.Data = append(sm.Data, &specqbft.SignedMessage{
+			Signature: []byte("signature"),
+			Signers:   []spectypes.OperatorID{1, 2, 3},
+			Message: &specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     h,
+				Round:      1,
+				Identifier: mid[:],
+			},
+		})
+	}
+	data, err := sm.Encode()
+	require.NoError(s.t, err)
+	return []p2pprotocol.SyncResult{{
+		Msg:    &spectypes.SSVMessage{MsgType: message.SSVSyncMsgType, MsgID: mid, Data: data},
+		Sender: "peer",
+	}}, last, nil
+}
+
+func TestSyncer_SyncRangeProgress(t *testing.T) {
+	identifier := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
+	s := NewSyncer(zap.L(), &batchSyncer{t: t, batch: 4})
+
+	type progress struct {
+		height  specqbft.Height
+		fetched int
+	}
+	var reported []progress
+	ctx := protocolsync.WithProgress(context.Background(), func(height specqbft.Height, fetched int) {
+		reported = append(reported, progress{height, fetched})
+	})
+
+	var handled int
+	require.NoError(t, s.SyncRange(ctx, identifier, func(msg *specqbft.SignedMessage) error {
+		handled++
+		return nil
+	}, 0, 10, "peer"))
+	require.Equal(t, 10, handled)
+	// a progress report for each batch
+	require.Equal(t, []progress{{4, 4}, {8, 8}, {10, 10}}, reported)
+
+	t.Run("without progress handler", func(t *testing.T) {
+		require.NoError(t, s.SyncRange(context.Background(), identifier, func(msg *specqbft.SignedMessage) error {
+			return nil
+		}, 0, 10, "peer"))
+	})
+}
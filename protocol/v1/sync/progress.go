@@ -0,0 +1,28 @@
+package sync
+
+import (
+	"context"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+)
+
+type progressKey struct{}
+
+// ProgressHandler is called periodically during a sync with the highest fetched height and the amount of fetched messages
+type ProgressHandler func(height specqbft.Height, fetched int)
+
+// WithProgress returns a context that carries the given progress handler,
+// syncs that are running with the returned context will report their progress to the handler
+func WithProgress(ctx context.Context, handler ProgressHandler) context.Context {
+	return context.WithValue(ctx, progressKey{}, handler)
+}
+
+// ReportProgress reports the progress of a sync to the handler in the given context, if any
+func ReportProgress(ctx context.Context, height specqbft.Height, fetched int) {
+	if ctx == nil {
+		return
+	}
+	if handler, ok := ctx.Value(progressKey{}).(ProgressHandler); ok && handler != nil {
+		handler(height, fetched)
+	}
+}
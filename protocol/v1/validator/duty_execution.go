@@ -1,28 +1,51 @@
 package validator
 
 import (
+	"bytes"
 	"encoding/hex"
+	"fmt"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
-func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *spectypes.Duty) (controller.IController, int, []byte, error) {
-	var inputByts []byte
-	var err error
+// InvalidDutyError is returned for duties that can't be executed by the validator
+type InvalidDutyError struct {
+	Duty   *spectypes.Duty
+	Reason string
+}
 
-	qbftCtrl, ok := v.ibfts[duty.Type]
-	if !ok {
-		return nil, 0, nil, errors.Errorf("no ibft for this role [%s]", duty.Type.String())
+func (e *InvalidDutyError) Error() string {
+	return fmt.Sprintf("invalid %s duty for slot %d: %s", e.Duty.Type.String(), e.Duty.Slot, e.Reason)
+}
+
+// validateDuty checks that the given duty belongs to the validator and that its role is supported,
+// otherwise an instance with a wrong identifier would be started
+func (v *Validator) validateDuty(duty *spectypes.Duty) error {
+	if pk := v.Share.PublicKey.Serialize(); !bytes.Equal(duty.PubKey[:], pk) {
+		return &InvalidDutyError{Duty: duty, Reason: fmt.Sprintf("pubkey %x does not match validator %x", duty.PubKey[:], pk)}
+	}
+	if _, ok := v.ibfts[duty.Type]; !ok {
+		return &InvalidDutyError{Duty: duty, Reason: "role is not supported"}
 	}
+	return nil
+}
 
+// consensusInput returns the encoded input value of the given duty.
+// it only reads from the beacon node, so duties of different roles can fetch their input concurrently
+func (v *Validator) consensusInput(duty *spectypes.Duty) ([]byte, error) {
 	switch duty.Type {
 	case spectypes.BNRoleAttester:
 		attData, err := v.beacon.GetAttestationData(duty.Slot, duty.CommitteeIndex)
 		if err != nil {
-			return nil, 0, nil, errors.Wrap(err, "failed to get attestation data")
+			return nil, errors.Wrap(err, "failed to get attestation data")
 		}
 		v.logger.Debug("attestation data", zap.Any("attData", attData))
 		// TODO(olegshmuelov): use SSZ encoding
@@ -30,13 +53,29 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *specty
 			Duty:            duty,
 			AttestationData: attData,
 		}
-		inputByts, err = input.Encode()
+		inputByts, err := input.Encode()
 		if err != nil {
-			return nil, 0, nil, errors.Wrap(err, "could not encode ConsensusData")
+			return nil, errors.Wrap(err, "could not encode ConsensusData")
 		}
 		// TODO(olegshmuelov): validate the consensus data using the spec "BeaconAttestationValueCheck"
+		return inputByts, nil
 	default:
-		return nil, 0, nil, errors.Errorf("unknown role: %s", duty.Type.String())
+		// TODO: add the input of other roles (e.g. beacon block for proposer) once supported by the beacon client
+		return nil, errors.Errorf("unknown role: %s", duty.Type.String())
+	}
+}
+
+// comeToConsensusOnInputValue runs a consensus instance for the given duty on the controller of its role.
+// each role has its own controller (i.e. queue and instance), therefore duties of different roles run independently
+func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *spectypes.Duty) (controller.IController, int, []byte, error) {
+	qbftCtrl, ok := v.ibfts[duty.Type]
+	if !ok {
+		return nil, 0, nil, errors.Errorf("no ibft for this role [%s]", duty.Type.String())
+	}
+
+	inputByts, err := v.consensusInput(duty)
+	if err != nil {
+		return nil, 0, nil, err
 	}
 
 	// calculate next seq
@@ -45,12 +84,20 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *specty
 		return nil, 0, nil, errors.Wrap(err, "failed to calculate next sequence number")
 	}
 
+	if v.dutyTimeout > 0 {
+		timer := time.AfterFunc(v.dutyTimeout, func() {
+			v.onDutyTimeout(logger, qbftCtrl, duty, height)
+		})
+		defer timer.Stop()
+	}
+
 	logger.Debug("start instance", zap.Int64("with height", int64(height)))
 	result, err := qbftCtrl.StartInstance(instance.ControllerStartInstanceOptions{
 		Logger:          logger,
 		Height:          height,
 		Value:           inputByts,
 		RequireMinPeers: true,
+		TraceID:         logfields.DutyTraceID(duty),
 	}, nil)
 	if err != nil {
 		return nil, 0, nil, errors.Wrap(err, "could not start ibft instance")
@@ -76,7 +123,27 @@ func (v *Validator) StartDuty(duty *spectypes.Duty) {
 		zap.Time("start_time", v.network.GetSlotStartTime(uint64(duty.Slot))),
 		zap.Uint64("committee_index", uint64(duty.CommitteeIndex)),
 		zap.Uint64("slot", uint64(duty.Slot)),
-		zap.String("duty_type", duty.Type.String()))
+		zap.String("duty_type", duty.Type.String()),
+		logfields.TraceID(logfields.DutyTraceID(duty)))
+
+	// validators in read mode only follow decided messages, they never sign or broadcast
+	if v.readMode {
+		logger.Debug("skipping duty of validator in read mode")
+		return
+	}
+	if err := v.requireState("start duty", Ready); err != nil {
+		logger.Warn("skipping duty", zap.Error(err))
+		return
+	}
+	if err := v.validateDuty(duty); err != nil {
+		logger.Warn("skipping invalid duty", zap.Error(err))
+		return
+	}
+
+	if !v.markDutyStarted(duty) {
+		logger.Debug("duty was already started, skipping")
+		return
+	}
 
 	metricsCurrentSlot.WithLabelValues(v.Share.PublicKey.SerializeToHexStr()).Set(float64(duty.Slot))
 	logger.Debug("executing duty")
@@ -96,3 +163,43 @@ func (v *Validator) StartDuty(duty *spectypes.Duty) {
 		return
 	}
 }
+
+// onDutyTimeout abandons a duty that didn't reach consensus in time by stopping its instance,
+// the controller then cleans up the instance and its messages. the duty is unmarked so it can be started again
+func (v *Validator) onDutyTimeout(logger *zap.Logger, qbftCtrl controller.IController, duty *spectypes.Duty, height specqbft.Height) {
+	inst := qbftCtrl.GetCurrentInstance()
+	if inst == nil || inst.GetState().GetHeight() != height {
+		return // the instance is already done
+	}
+	logger.Warn("duty timed out, stopping instance", logfields.Height(height), zap.Duration("timeout", v.dutyTimeout))
+	metricsDutyTimeouts.WithLabelValues(duty.Type.String(), v.Share.PublicKey.SerializeToHexStr()).Inc()
+	v.unmarkDutyStarted(duty)
+	inst.Stop()
+}
+
+// markDutyStarted marks the given duty as started, returns false if the duty (or a later duty of the same role)
+// was already started. duties might be received more than once (e.g. when duties are re-fetched from beacon),
+// therefore only the first one is executed to avoid running another consensus instance for the same duty
+func (v *Validator) markDutyStarted(duty *spectypes.Duty) bool {
+	v.startedDutiesLock.Lock()
+	defer v.startedDutiesLock.Unlock()
+
+	if v.startedDuties == nil {
+		v.startedDuties = make(map[spectypes.BeaconRole]spec.Slot)
+	}
+	if last, ok := v.startedDuties[duty.Type]; ok && duty.Slot <= last {
+		return false
+	}
+	v.startedDuties[duty.Type] = duty.Slot
+	return true
+}
+
+// unmarkDutyStarted reverts markDutyStarted for the given duty, unless a later duty of the same role was started since
+func (v *Validator) unmarkDutyStarted(duty *spectypes.Duty) {
+	v.startedDutiesLock.Lock()
+	defer v.startedDutiesLock.Unlock()
+
+	if last, ok := v.startedDuties[duty.Type]; ok && last == duty.Slot {
+		delete(v.startedDuties, duty.Type)
+	}
+}
@@ -1,15 +1,22 @@
 package validator
 
 import (
+	"sync"
 	"testing"
 	"time"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/protocol/v1/message"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/instance"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/logfields"
 )
 
 func marshalInputValueStructForAttestation(t *testing.T, attByts []byte) []byte {
@@ -96,6 +103,7 @@ func TestConsensusOnInputValue(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
+			require.Equal(t, logfields.DutyTraceID(duty), node.ibfts[test.role].(*testIBFT).startOpts.TraceID)
 			require.EqualValues(t, 3, signaturesCount)
 			require.NotNil(t, decidedByts)
 			consensusData := &spectypes.ConsensusData{}
@@ -106,6 +114,233 @@ func TestConsensusOnInputValue(t *testing.T) {
 	}
 }
 
+func TestStartDutyDuplicate(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	node := testingValidator(t, false, 3, identifier)
+	ibft := node.ibfts[spectypes.BNRoleAttester].(*testIBFT)
+
+	pk := spec.BLSPubKey{}
+	copy(pk[:], node.Share.PublicKey.Serialize())
+
+	duty := &spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 10}
+	node.StartDuty(duty)
+	require.Equal(t, 1, ibft.starts)
+
+	// the same duty is received again
+	node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 10})
+	require.Equal(t, 1, ibft.starts)
+	// a stale duty
+	node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 9})
+	require.Equal(t, 1, ibft.starts)
+
+	node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 11})
+	require.Equal(t, 2, ibft.starts)
+}
+
+func TestValidateDuty(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	node := testingValidator(t, false, 3, identifier)
+	ibft := node.ibfts[spectypes.BNRoleAttester].(*testIBFT)
+
+	pk := spec.BLSPubKey{}
+	copy(pk[:], node.Share.PublicKey.Serialize())
+	otherPK := pk
+	otherPK[0]++
+
+	tests := []struct {
+		name  string
+		duty  *spectypes.Duty
+		valid bool
+	}{
+		{"valid", &spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 10}, true},
+		{"pubkey mismatch", &spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: otherPK, Slot: 11}, false},
+		{"empty pubkey", &spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: 12}, false},
+		{"unsupported role", &spectypes.Duty{Type: spectypes.BNRoleProposer, PubKey: pk, Slot: 13}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := node.validateDuty(test.duty)
+			if test.valid {
+				require.NoError(t, err)
+				return
+			}
+			var dutyErr *InvalidDutyError
+			require.ErrorAs(t, err, &dutyErr)
+			require.Equal(t, test.duty, dutyErr.Duty)
+
+			// invalid duties are not started
+			starts := ibft.starts
+			node.StartDuty(test.duty)
+			require.Equal(t, starts, ibft.starts)
+		})
+	}
+}
+
+// hangingInstance is an instance that never decides, it only returns once stopped
+type hangingInstance struct {
+	instance.Instancer
+	state    *qbft.State
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (i *hangingInstance) GetState() *qbft.State {
+	return i.state
+}
+
+func (i *hangingInstance) Stop() {
+	i.stopOnce.Do(func() {
+		close(i.stopped)
+	})
+}
+
+// hangingIBFT runs hanging instances
+type hangingIBFT struct {
+	*testIBFT
+	lock    sync.Mutex
+	current *hangingInstance
+}
+
+func (h *hangingIBFT) StartInstance(opts instance.ControllerStartInstanceOptions, getInstance func(instance instance.Instancer)) (*instance.Result, error) {
+	inst := &hangingInstance{state: &qbft.State{}, stopped: make(chan struct{})}
+	inst.state.Height.Store(opts.Height)
+	h.lock.Lock()
+	h.current = inst
+	h.starts++
+	h.lock.Unlock()
+
+	<-inst.stopped
+
+	h.lock.Lock()
+	h.current = nil
+	h.lock.Unlock()
+	return nil, errors.New("instance stopped")
+}
+
+func (h *hangingIBFT) GetCurrentInstance() instance.Instancer {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.current == nil {
+		return nil
+	}
+	return h.current
+}
+
+func TestStartDutyTimeout(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	node := testingValidator(t, false, 3, identifier)
+	node.dutyTimeout = time.Millisecond * 100
+	ibft := &hangingIBFT{testIBFT: node.ibfts[spectypes.BNRoleAttester].(*testIBFT)}
+	node.ibfts[spectypes.BNRoleAttester] = ibft
+
+	pk := spec.BLSPubKey{}
+	copy(pk[:], node.Share.PublicKey.Serialize())
+	timeouts := metricsDutyTimeouts.WithLabelValues(spectypes.BNRoleAttester.String(), node.Share.PublicKey.SerializeToHexStr())
+	before := testutil.ToFloat64(timeouts)
+
+	startDuty := func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 10})
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second * 5):
+			t.Fatal("duty did not time out")
+		}
+	}
+
+	startDuty()
+	require.Equal(t, 1, ibft.starts)
+	require.Nil(t, ibft.GetCurrentInstance())
+	require.Equal(t, before+1, testutil.ToFloat64(timeouts))
+
+	// the timed out duty is abandoned, hence it can be started again
+	startDuty()
+	require.Equal(t, 2, ibft.starts)
+	require.Equal(t, before+2, testutil.ToFloat64(timeouts))
+}
+
+// blockingIBFT decides once released, and records its post consensus executions
+type blockingIBFT struct {
+	*testIBFT
+	started       chan struct{}
+	release       chan struct{}
+	postConsensus chan spectypes.BeaconRole
+}
+
+func newBlockingIBFT(t *testIBFT) *blockingIBFT {
+	return &blockingIBFT{
+		testIBFT:      t,
+		started:       make(chan struct{}, 1),
+		release:       make(chan struct{}),
+		postConsensus: make(chan spectypes.BeaconRole, 1),
+	}
+}
+
+func (b *blockingIBFT) StartInstance(opts instance.ControllerStartInstanceOptions, getInstance func(instance instance.Instancer)) (*instance.Result, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return b.testIBFT.StartInstance(opts, getInstance)
+}
+
+func (b *blockingIBFT) PostConsensusDutyExecution(logger *zap.Logger, decidedValue []byte, signaturesCount int, role spectypes.BeaconRole) error {
+	b.postConsensus <- role
+	return nil
+}
+
+func TestStartDutyConcurrentRoles(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	node := testingValidator(t, true, 3, identifier)
+	attesterIBFT := newBlockingIBFT(node.ibfts[spectypes.BNRoleAttester].(*testIBFT))
+	proposerIBFT := newBlockingIBFT(&testIBFT{share: node.Share, identifier: identifier})
+	close(proposerIBFT.release)
+	node.ibfts[spectypes.BNRoleAttester] = attesterIBFT
+	node.ibfts[spectypes.BNRoleProposer] = proposerIBFT
+
+	pk := spec.BLSPubKey{}
+	copy(pk[:], node.Share.PublicKey.Serialize())
+
+	attesterDone := make(chan struct{})
+	go func() {
+		defer close(attesterDone)
+		node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 10})
+	}()
+	select {
+	case <-attesterIBFT.started:
+	case <-time.After(time.Second * 5):
+		t.Fatal("attester instance was not started")
+	}
+
+	// the proposer duty of the same slot is executed while the attester instance is running
+	proposerDone := make(chan struct{})
+	go func() {
+		defer close(proposerDone)
+		node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleProposer, PubKey: pk, Slot: 10})
+	}()
+	select {
+	case <-proposerDone:
+	case <-time.After(time.Second * 5):
+		t.Fatal("proposer duty was blocked by the running attester duty")
+	}
+	// TODO: the proposer duty should reach post consensus once the beacon client supports block production
+	require.Len(t, proposerIBFT.started, 0)
+
+	close(attesterIBFT.release)
+	select {
+	case role := <-attesterIBFT.postConsensus:
+		require.Equal(t, spectypes.BNRoleAttester, role)
+	case <-time.After(time.Second * 5):
+		t.Fatal("attester duty did not reach post consensus")
+	}
+	<-attesterDone
+	require.Equal(t, 1, attesterIBFT.starts)
+	require.Equal(t, 0, proposerIBFT.starts)
+	require.Len(t, proposerIBFT.postConsensus, 0)
+}
+
 func TestPostConsensusSignatureAndAggregation(t *testing.T) {
 	tests := []struct {
 		name                        string
@@ -19,6 +19,10 @@ var (
 		Name: "ssv:validator:status1",
 		Help: "Validator status",
 	}, []string{"pubKey"})
+	metricsDutyTimeouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:validator:duty_timeouts",
+		Help: "Count of duties that were abandoned as consensus was not reached in time",
+	}, []string{"role", "pubKey"})
 )
 
 func init() {
@@ -28,6 +32,9 @@ func init() {
 	if err := prometheus.Register(metricsValidatorStatus); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsDutyTimeouts); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 // ReportValidatorStatus reports the current status of validator
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// State is the lifecycle state of a validator
+type State uint32
+
+const (
+	// NotStarted is the initial state, the validator is not subscribed to its topic
+	NotStarted State = iota
+	// Starting is set while the validator is subscribing to its topic and initializing its controllers
+	Starting
+	// Ready is set once the validator was started, duties can be executed only in this state
+	Ready
+	// Stopping is set while the validator is being stopped
+	Stopping
+	// Stopped is the final state
+	Stopped
+)
+
+// allowedTransitions maps each state to the states it can move to
+var allowedTransitions = map[State][]State{
+	NotStarted: {Starting, Stopping},
+	Starting:   {Ready, NotStarted, Stopping},
+	Ready:      {Stopping},
+	Stopping:   {Stopped},
+}
+
+func (s State) String() string {
+	switch s {
+	case NotStarted:
+		return "not_started"
+	case Starting:
+		return "starting"
+	case Ready:
+		return "ready"
+	case Stopping:
+		return "stopping"
+	case Stopped:
+		return "stopped"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint32(s))
+	}
+}
+
+// canTransition returns true if the given transition is allowed
+func canTransition(from, to State) bool {
+	for _, s := range allowedTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidStateError is returned for operations that are not allowed in the current state of the validator
+type InvalidStateError struct {
+	Op    string
+	State State
+}
+
+func (e *InvalidStateError) Error() string {
+	return fmt.Sprintf("could not %s, validator is %s", e.Op, e.State.String())
+}
+
+// State returns the current lifecycle state of the validator
+func (v *Validator) State() State {
+	return State(atomic.LoadUint32(&v.state))
+}
+
+// transition atomically moves the validator to the given state,
+// returns InvalidStateError if the transition is not allowed from the current state
+func (v *Validator) transition(op string, to State) error {
+	for {
+		from := v.State()
+		if !canTransition(from, to) {
+			return &InvalidStateError{Op: op, State: from}
+		}
+		if atomic.CompareAndSwapUint32(&v.state, uint32(from), uint32(to)) {
+			return nil
+		}
+	}
+}
+
+// requireState returns InvalidStateError if the validator is not in the given state
+func (v *Validator) requireState(op string, s State) error {
+	if current := v.State(); current != s {
+		return &InvalidStateError{Op: op, State: current}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v1/blockchain/eth1"
 )
@@ -16,4 +18,6 @@ type ICollection interface {
 	GetOperatorIDValidatorShares(operatorID uint32, enabled bool) ([]*beacon.Share, error)
 	GetValidatorSharesByOwnerAddress(ownerAddress string) ([]*beacon.Share, error)
 	DeleteValidatorShare(key []byte) error
+	SetValidatorPaused(key []byte, paused bool) error
+	SetValidatorFeeRecipient(key []byte, feeRecipient common.Address) error
 }
@@ -79,6 +79,8 @@ type testIBFT struct {
 	share           *beaconprotocol.Share
 	signatureMu     sync.Mutex
 	signatures      map[spectypes.OperatorID][]byte
+	startOpts       instance.ControllerStartInstanceOptions
+	starts          int
 }
 
 func (t *testIBFT) GetCurrentInstance() instance.Instancer {
@@ -94,6 +96,8 @@ func (t *testIBFT) Init() error {
 }
 
 func (t *testIBFT) StartInstance(opts instance.ControllerStartInstanceOptions, getInstance func(instance instance.Instancer)) (*instance.Result, error) {
+	t.startOpts = opts
+	t.starts++
 	commitData, err := (&specqbft.CommitData{Data: opts.Value}).Encode()
 	if err != nil {
 		return nil, err
@@ -139,6 +143,14 @@ func (t *testIBFT) NextHeightNumber() (specqbft.Height, error) {
 	return 0, nil
 }
 
+func (t *testIBFT) GetDecidedValue(height specqbft.Height) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (t *testIBFT) Close() error {
+	return nil
+}
+
 func (t *testIBFT) OnFork(forkVersion forksprotocol.ForkVersion) error {
 	return nil
 }
@@ -242,6 +254,13 @@ func NewTestBeacon(t *testing.T) *TestBeacon {
 	return ret
 }
 
+// NewTestBeaconWithDomain creates a new TestBeacon which signs with the given domain instead of the global one
+func NewTestBeaconWithDomain(t *testing.T, domain spectypes.DomainType) *TestBeacon {
+	ret := NewTestBeacon(t)
+	ret.KeyManager = NewTestKeyManagerWithDomain(domain)
+	return ret
+}
+
 // StartReceivingBlocks iml
 func (b *TestBeacon) StartReceivingBlocks() {
 }
@@ -272,6 +291,11 @@ func (b *TestBeacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommit
 	panic("implement me")
 }
 
+// SubmitProposalPreparation impl
+func (b *TestBeacon) SubmitProposalPreparation(preparations []*api.ProposalPreparation) error {
+	panic("implement me")
+}
+
 // AddShare impl
 func (b *TestBeacon) AddShare(shareKey *bls.SecretKey) error {
 	return b.KeyManager.AddShare(shareKey)
@@ -355,6 +379,7 @@ func testingValidator(t *testing.T, decided bool, signaturesCount int, identifie
 	ret.p2pNetwork = p2pNet
 
 	ret.Share = share
+	ret.state = uint32(Ready)
 
 	return ret
 }
@@ -380,11 +405,18 @@ func GenerateNodes(cnt int) (map[spectypes.OperatorID]*bls.SecretKey, map[specty
 type testKeyManager struct {
 	lock sync.Locker
 	keys map[string]*bls.SecretKey
+	// domain overrides the global domain if set
+	domain spectypes.DomainType
 }
 
 // NewTestKeyManager creates a new ssvSigner for tests
 func NewTestKeyManager() spectypes.KeyManager {
-	return &testKeyManager{&sync.Mutex{}, make(map[string]*bls.SecretKey)}
+	return NewTestKeyManagerWithDomain(nil)
+}
+
+// NewTestKeyManagerWithDomain creates a new ssvSigner for tests, which signs with the given domain
+func NewTestKeyManagerWithDomain(domain spectypes.DomainType) spectypes.KeyManager {
+	return &testKeyManager{lock: &sync.Mutex{}, keys: make(map[string]*bls.SecretKey), domain: domain}
 }
 
 func (km *testKeyManager) IsAttestationSlashable(data *spec.AttestationData) error {
@@ -436,7 +468,11 @@ func (km *testKeyManager) SignRoot(data spectypes.Root, sigType spectypes.Signat
 	defer km.lock.Unlock()
 
 	if key := km.keys[hex.EncodeToString(pk)]; key != nil {
-		domain := spectypes.ComputeSignatureDomain(types.GetDefaultDomain(), sigType)
+		d := km.domain
+		if len(d) == 0 {
+			d = types.GetDefaultDomain()
+		}
+		domain := spectypes.ComputeSignatureDomain(d, sigType)
 		computedRoot, err := spectypes.ComputeSigningRoot(data, domain)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not compute signing root")
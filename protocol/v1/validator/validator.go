@@ -1,11 +1,17 @@
 package validator
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"sync"
 	"time"
 
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -17,12 +23,41 @@ import (
 	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 )
 
+// ErrNoControllerForRole is returned (wrapped by UnsupportedRoleError) when processing a message of a role that the validator doesn't run
+var ErrNoControllerForRole = errors.New("no controller for role")
+
+// UnsupportedRoleError is returned when processing a message of a role that the validator doesn't run,
+// it wraps ErrNoControllerForRole
+type UnsupportedRoleError struct {
+	Role spectypes.BeaconRole
+}
+
+func (e *UnsupportedRoleError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrNoControllerForRole.Error(), e.Role.String())
+}
+
+// Unwrap returns ErrNoControllerForRole
+func (e *UnsupportedRoleError) Unwrap() error {
+	return ErrNoControllerForRole
+}
+
+// InvalidMessageError is returned when processing a message that can't be processed by the validator
+type InvalidMessageError struct {
+	MsgID  spectypes.MessageID
+	Reason string
+}
+
+func (e *InvalidMessageError) Error() string {
+	return fmt.Sprintf("invalid message %s: %s", hex.EncodeToString(e.MsgID[:]), e.Reason)
+}
+
 // IValidator is the interface for validator
 type IValidator interface {
 	Start() error
 	StartDuty(duty *spectypes.Duty)
 	ProcessMsg(msg *spectypes.SSVMessage) error // TODO need to be as separate interface?
 	GetShare() *beaconprotocol.Share
+	FeeRecipient() common.Address
 
 	forksprotocol.ForkHandler
 	io.Closer
@@ -46,6 +81,22 @@ type Options struct {
 	FullNode                   bool
 	NewDecidedHandler          controller.NewDecidedHandler
 	DutyRoles                  []spectypes.BeaconRole
+	AsyncStatePersistence      bool
+	// DisableHighestRoundCatchup disables the fast catchup of the highest round upon round change
+	DisableHighestRoundCatchup bool
+	// LateMessagesWindow is the time to wait for late messages once an instance is done
+	LateMessagesWindow time.Duration
+	// DutyTimeout is the deadline of a duty's consensus, 0 means no deadline
+	DutyTimeout time.Duration
+	// MaxValueSize is the max size (bytes) of a proposed value, 0 means the default size
+	MaxValueSize int
+	// DefaultFeeRecipient is used for block proposals of validators w/o a fee recipient override
+	DefaultFeeRecipient common.Address
+
+	// RoleSignatureCollectionTimeouts overrides SignatureCollectionTimeout for specific roles
+	RoleSignatureCollectionTimeouts map[spectypes.BeaconRole]time.Duration
+	// RoleMinPeers overrides MinPeers for specific roles
+	RoleMinPeers map[spectypes.BeaconRole]int
 }
 
 // Validator represents the validator
@@ -61,6 +112,18 @@ type Validator struct {
 
 	ibfts controller.Controllers
 
+	defaultFeeRecipient common.Address
+	dutyTimeout         time.Duration
+
+	// state is the lifecycle State of the validator, accessed atomically
+	state uint32
+	// startLock serializes Start calls, so concurrent calls won't race on the subscription
+	startLock sync.Mutex
+
+	// startedDuties holds the slot of the last started duty of each role
+	startedDuties     map[spectypes.BeaconRole]spec.Slot
+	startedDutiesLock sync.Mutex
+
 	// flags
 	readMode    bool
 	saveHistory bool
@@ -96,18 +159,61 @@ func NewValidator(opt *Options) IValidator {
 		ibfts:       ibfts,
 		readMode:    opt.ReadMode,
 		saveHistory: opt.FullNode,
+
+		defaultFeeRecipient: opt.DefaultFeeRecipient,
+		dutyTimeout:         opt.DutyTimeout,
 	}
 }
 
+// FeeRecipient returns the fee recipient that should be used for block proposals of the validator,
+// the share override takes precedence over the default fee recipient
+func (v *Validator) FeeRecipient() common.Address {
+	if v.Share != nil && v.Share.FeeRecipient != (common.Address{}) {
+		return v.Share.FeeRecipient
+	}
+	return v.defaultFeeRecipient
+}
+
 // Close implements io.Closer
 func (v *Validator) Close() error {
+	return v.Stop()
+}
+
+// Stop stops the validator by canceling its context.
+// it is safe to call Stop more than once and concurrently, only the first call cancels the context
+// while subsequent calls return nil
+func (v *Validator) Stop() error {
+	if err := v.transition("stop", Stopping); err != nil {
+		var stateErr *InvalidStateError
+		if errors.As(err, &stateErr) && (stateErr.State == Stopping || stateErr.State == Stopped) {
+			return nil // already stopped (or being stopped) by another call
+		}
+		return err
+	}
 	v.cancelCtx()
-	return nil
+	for role, ib := range v.ibfts {
+		if err := ib.Close(); err != nil {
+			v.logger.Warn("could not close ibft controller", zap.String("role", role.String()), zap.Error(err))
+		}
+	}
+	return v.transition("stop", Stopped)
 }
 
-// Start starts the validator
+// Start starts the validator, it does nothing if the validator was already started
 func (v *Validator) Start() error {
+	v.startLock.Lock()
+	defer v.startLock.Unlock()
+
+	if v.State() == Ready {
+		return nil
+	}
+	if err := v.transition("start", Starting); err != nil {
+		return err
+	}
 	if err := v.p2pNetwork.Subscribe(v.GetShare().PublicKey.Serialize()); err != nil {
+		if terr := v.transition("start", NotStarted); terr != nil {
+			v.logger.Debug("could not reset validator state", zap.Error(terr))
+		}
 		return errors.Wrap(err, "failed to subscribe topic")
 	}
 
@@ -124,7 +230,7 @@ func (v *Validator) Start() error {
 		}(ib)
 	}
 
-	return nil
+	return v.transition("start", Ready)
 }
 
 // GetShare returns the validator share
@@ -135,10 +241,28 @@ func (v *Validator) GetShare() *beaconprotocol.Share {
 
 // ProcessMsg processes a new msg
 func (v *Validator) ProcessMsg(msg *spectypes.SSVMessage) error {
+	if err := v.validateMessage(msg); err != nil {
+		return err
+	}
 	identifier := msg.GetID()
-	ibftController := v.ibfts.ControllerForIdentifier(identifier[:])
 	// synchronize process
-	return ibftController.ProcessMsg(msg)
+	return v.ibfts.ControllerForIdentifier(identifier[:]).ProcessMsg(msg)
+}
+
+// validateMessage checks that the given message belongs to the validator, that it has data,
+// and that its role is one of the roles that the validator runs
+func (v *Validator) validateMessage(msg *spectypes.SSVMessage) error {
+	mid := msg.GetID()
+	if pk := v.Share.PublicKey.Serialize(); !bytes.Equal(mid.GetPubKey(), pk) {
+		return &InvalidMessageError{MsgID: mid, Reason: fmt.Sprintf("pubkey does not match validator %x", pk)}
+	}
+	if len(msg.GetData()) == 0 {
+		return &InvalidMessageError{MsgID: mid, Reason: "empty data"}
+	}
+	if _, ok := v.ibfts[mid.GetRoleType()]; !ok {
+		return &UnsupportedRoleError{Role: mid.GetRoleType()}
+	}
+	return nil
 }
 
 // OnFork updates all QFBT controllers with the new fork version
@@ -169,17 +293,32 @@ func setupIbftController(role spectypes.BeaconRole, logger *zap.Logger, opt *Opt
 		Logger:            logger,
 		Storage:           opt.IbftStorage,
 		Network:           opt.P2pNetwork,
-		InstanceConfig:    qbft.DefaultConsensusParams(),
+		InstanceConfig:    instanceConfig(opt),
 		ValidatorShare:    opt.Share,
 		Version:           opt.ForkVersion,
 		Beacon:            opt.Beacon,
 		KeyManager:        opt.KeyManager,
 		SyncRateLimit:     opt.SyncRateLimit,
 		SigTimeout:        opt.SignatureCollectionTimeout,
+		RoleSigTimeouts:   opt.RoleSignatureCollectionTimeouts,
 		MinPeers:          opt.MinPeers,
+		RoleMinPeers:      opt.RoleMinPeers,
 		ReadMode:          opt.ReadMode,
 		FullNode:          opt.FullNode,
 		NewDecidedHandler: opt.NewDecidedHandler,
+
+		AsyncStatePersistence:      opt.AsyncStatePersistence,
+		DisableHighestRoundCatchup: opt.DisableHighestRoundCatchup,
+		LateMessagesWindow:         opt.LateMessagesWindow,
 	}
 	return controller.New(opts)
 }
+
+// instanceConfig returns the default consensus params with the configured max value size
+func instanceConfig(opt *Options) *qbft.InstanceConfig {
+	cfg := qbft.DefaultConsensusParams()
+	if opt.MaxValueSize > 0 {
+		cfg.MaxValueSize = opt.MaxValueSize
+	}
+	return cfg
+}
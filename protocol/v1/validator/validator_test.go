@@ -2,10 +2,19 @@ package validator
 
 import (
 	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
 	"github.com/bloxapp/ssv/utils/logex"
 )
 
@@ -27,3 +36,159 @@ func oneOfIBFTIdentifiers(v *Validator, toMatch []byte) bool {
 	}
 	return false
 }
+
+func TestValidator_FeeRecipient(t *testing.T) {
+	defaultFeeRecipient := common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	override := common.HexToAddress("0xcEEfd323DD28a8d9514EDDfeC45a6c81800A7D49")
+
+	v := &Validator{Share: &beaconprotocol.Share{}, defaultFeeRecipient: defaultFeeRecipient}
+	require.Equal(t, defaultFeeRecipient, v.FeeRecipient())
+
+	v.Share.FeeRecipient = override
+	require.Equal(t, override, v.FeeRecipient())
+}
+
+func TestInstanceConfig(t *testing.T) {
+	require.Equal(t, qbft.DefaultMaxValueSize, instanceConfig(&Options{}).MaxValueSize)
+	require.Equal(t, 1024, instanceConfig(&Options{MaxValueSize: 1024}).MaxValueSize)
+}
+
+func TestValidator_StateTransitions(t *testing.T) {
+	identifier := []byte{1, 2, 3, 4}
+	pk := spec.BLSPubKey{}
+
+	t.Run("duties are started only when ready", func(t *testing.T) {
+		node := testingValidator(t, false, 3, identifier)
+		ibft := node.ibfts[spectypes.BNRoleAttester].(*testIBFT)
+		copy(pk[:], node.Share.PublicKey.Serialize())
+		node.state = uint32(NotStarted)
+
+		node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 10})
+		require.Equal(t, 0, ibft.starts)
+
+		require.NoError(t, node.Start())
+		require.Equal(t, Ready, node.State())
+		require.NoError(t, node.Start()) // already started
+
+		node.StartDuty(&spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 10})
+		require.Equal(t, 1, ibft.starts)
+	})
+
+	t.Run("stopped validator can't be started", func(t *testing.T) {
+		node := testingValidator(t, false, 3, identifier)
+		ctx, cancel := context.WithCancel(context.Background())
+		node.ctx, node.cancelCtx = ctx, cancel
+
+		require.NoError(t, node.Close())
+		require.Equal(t, Stopped, node.State())
+		require.Error(t, ctx.Err())
+
+		err := node.Start()
+		var stateErr *InvalidStateError
+		require.ErrorAs(t, err, &stateErr)
+		require.Equal(t, Stopped, stateErr.State)
+	})
+
+	t.Run("illegal transitions", func(t *testing.T) {
+		tests := []struct {
+			from State
+			to   State
+		}{
+			{NotStarted, Ready},
+			{NotStarted, Stopped},
+			{Starting, Stopped},
+			{Ready, Starting},
+			{Ready, NotStarted},
+			{Ready, Stopped},
+			{Stopping, Ready},
+			{Stopped, Starting},
+			{Stopped, Stopping},
+		}
+		for _, test := range tests {
+			v := &Validator{state: uint32(test.from)}
+			err := v.transition("test", test.to)
+			var stateErr *InvalidStateError
+			require.ErrorAs(t, err, &stateErr, "%s -> %s", test.from, test.to)
+			require.Equal(t, test.from, stateErr.State)
+			require.Equal(t, test.from, v.State())
+		}
+	})
+
+	t.Run("lifecycle", func(t *testing.T) {
+		v := &Validator{}
+		require.Equal(t, NotStarted, v.State())
+		for _, s := range []State{Starting, Ready, Stopping, Stopped} {
+			require.NoError(t, v.transition("test", s))
+			require.Equal(t, s, v.State())
+		}
+	})
+}
+
+func TestValidator_StopConcurrently(t *testing.T) {
+	node := testingValidator(t, false, 3, []byte{1, 2, 3, 4})
+	ctx, cancel := context.WithCancel(context.Background())
+	var cancels int32
+	node.ctx, node.cancelCtx = ctx, func() {
+		atomic.AddInt32(&cancels, 1)
+		cancel()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, node.Stop())
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&cancels))
+	require.Equal(t, Stopped, node.State())
+	require.Error(t, ctx.Err())
+
+	// subsequent calls are no-op
+	require.NoError(t, node.Stop())
+	require.NoError(t, node.Close())
+	require.Equal(t, int32(1), atomic.LoadInt32(&cancels))
+}
+
+func TestValidator_ValidateMessage(t *testing.T) {
+	node := testingValidator(t, true, 4, []byte{1, 2, 3, 4})
+	pk := node.Share.PublicKey.Serialize()
+	otherPK := make([]byte, len(pk))
+	copy(otherPK, pk)
+	otherPK[0]++
+
+	tests := []struct {
+		name        string
+		msg         *spectypes.SSVMessage
+		invalid     bool
+		unsupported bool
+	}{
+		{"supported role", &spectypes.SSVMessage{MsgID: spectypes.NewMsgID(pk, spectypes.BNRoleAttester), Data: []byte{1}}, false, false},
+		{"unsupported role", &spectypes.SSVMessage{MsgID: spectypes.NewMsgID(pk, spectypes.BNRoleProposer), Data: []byte{1}}, false, true},
+		{"other validator", &spectypes.SSVMessage{MsgID: spectypes.NewMsgID(otherPK, spectypes.BNRoleAttester), Data: []byte{1}}, true, false},
+		{"empty data", &spectypes.SSVMessage{MsgID: spectypes.NewMsgID(pk, spectypes.BNRoleAttester)}, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := node.validateMessage(test.msg)
+			var invalidErr *InvalidMessageError
+			var roleErr *UnsupportedRoleError
+			switch {
+			case test.invalid:
+				require.ErrorAs(t, err, &invalidErr)
+			case test.unsupported:
+				require.ErrorAs(t, err, &roleErr)
+				require.Equal(t, spectypes.BNRoleProposer, roleErr.Role)
+				require.ErrorIs(t, err, ErrNoControllerForRole)
+				// fails fast w/o reaching a controller
+				require.ErrorIs(t, node.ProcessMsg(test.msg), ErrNoControllerForRole)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ type OperatorData struct {
 	PublicKey    string         `json:"publicKey"`
 	Name         string         `json:"name"`
 	OwnerAddress common.Address `json:"ownerAddress"`
+	// Removed is set once the operator was removed from the contract, the record is kept for historical data
+	Removed bool `json:"removed,omitempty"`
 }
 
 // GetOperatorData is a function that returns the operator data
@@ -35,6 +37,7 @@ type OperatorsCollection interface {
 	GetOperatorData(index uint64) (*OperatorData, bool, error)
 	SaveOperatorData(operatorData *OperatorData) error
 	DeleteOperatorData(index uint64) error
+	MarkOperatorRemoved(index uint64) error
 	ListOperators(from uint64, to uint64) ([]OperatorData, error)
 	GetOperatorsPrefix() []byte
 }
@@ -61,7 +64,7 @@ func (s *operatorsStorage) GetOperatorsPrefix() []byte {
 }
 
 // ListOperators returns data of the all known operators by index range (from, to)
-// when 'to' equals zero, all operators will be returned
+// when 'to' equals zero, all operators will be returned. removed operators are not listed
 func (s *operatorsStorage) ListOperators(from, to uint64) ([]OperatorData, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -69,7 +72,9 @@ func (s *operatorsStorage) ListOperators(from, to uint64) ([]OperatorData, error
 	return s.listOperators(from, to)
 }
 
-// GetOperatorData returns data of the given operator by index
+// GetOperatorData returns data of the given operator by index.
+// removed operators are returned as well (see OperatorData.Removed), as committees of existing shares
+// and historical decided data still refer to them by index
 func (s *operatorsStorage) GetOperatorData(index uint64) (*OperatorData, bool, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -77,7 +82,7 @@ func (s *operatorsStorage) GetOperatorData(index uint64) (*OperatorData, bool, e
 	return s.getOperatorData(index)
 }
 
-// GetOperatorDataByPubKey returns data of the given operator by public key
+// GetOperatorDataByPubKey returns data of the given operator by public key, removed operators are not returned
 func (s *operatorsStorage) GetOperatorDataByPubKey(operatorPubKey string) (*OperatorData, bool, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -113,11 +118,14 @@ func (s *operatorsStorage) getOperatorData(index uint64) (*OperatorData, bool, e
 
 func (s *operatorsStorage) listOperators(from, to uint64) ([]OperatorData, error) {
 	var operators []OperatorData
-	err := s.db.GetAll(append(s.prefix, operatorsPrefix...), func(i int, obj basedb.Obj) error {
+	err := s.db.GetAllPaged(append(s.prefix, operatorsPrefix...), basedb.DefaultPageSize, func(i int, obj basedb.Obj) error {
 		var od OperatorData
 		if err := json.Unmarshal(obj.Value, &od); err != nil {
 			return err
 		}
+		if od.Removed {
+			return nil
+		}
 		if (od.Index >= from && od.Index <= to) || (to == 0) {
 			operators = append(operators, od)
 		}
@@ -165,6 +173,26 @@ func (s *operatorsStorage) DeleteOperatorData(index uint64) error {
 	return s.db.Delete(s.prefix, buildOperatorKey(index))
 }
 
+// MarkOperatorRemoved marks the given operator as removed w/o deleting its data
+func (s *operatorsStorage) MarkOperatorRemoved(index uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	od, found, err := s.getOperatorData(index)
+	if err != nil {
+		return errors.Wrap(err, "could not get operator's data")
+	}
+	if !found {
+		return errors.Errorf("could not find operator %d", index)
+	}
+	od.Removed = true
+	raw, err := json.Marshal(od)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal operator information")
+	}
+	return s.db.Set(s.prefix, buildOperatorKey(index), raw)
+}
+
 // buildOperatorKey builds operator key using operatorsPrefix & index, e.g. "operators/1"
 func buildOperatorKey(index uint64) []byte {
 	return bytes.Join([][]byte{operatorsPrefix[:], []byte(strconv.FormatUint(index, 10))}, []byte("/"))
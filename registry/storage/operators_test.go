@@ -152,6 +152,42 @@ func TestStorage_ListOperators(t *testing.T) {
 	})
 }
 
+func TestStorage_MarkOperatorRemoved(t *testing.T) {
+	storage, done := newStorageForTest()
+	require.NotNil(t, storage)
+	defer done()
+
+	pk, _, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveOperatorData(&OperatorData{
+		PublicKey: string(pk),
+		Name:      "my_operator",
+		Index:     1,
+	}))
+
+	require.NoError(t, storage.MarkOperatorRemoved(1))
+
+	// the record is kept and marked as removed
+	od, found, err := storage.GetOperatorData(1)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, od.Removed)
+	require.Equal(t, "my_operator", od.Name)
+
+	// removed operators are not listed nor resolved by public key
+	operators, err := storage.ListOperators(0, 0)
+	require.NoError(t, err)
+	require.Len(t, operators, 0)
+	operators, err = storage.ListOperators(1, 1)
+	require.NoError(t, err)
+	require.Len(t, operators, 0)
+	_, found, err = storage.GetOperatorDataByPubKey(string(pk))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.Error(t, storage.MarkOperatorRemoved(2))
+}
+
 func newStorageForTest() (OperatorsCollection, func()) {
 	logger := zap.L()
 	db, err := ssvstorage.GetStorageFactory(basedb.Options{
@@ -0,0 +1,128 @@
+package fixtures
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// OfflineEnv is the env variable that turns on offline mode, i.e. fixtures are never fetched from the network
+	OfflineEnv = "SPECTEST_OFFLINE"
+	// VendorDirEnv is the env variable of a directory used to pre-seed the cache,
+	// it is expected to have the same layout as the cache (<version>/<path>)
+	VendorDirEnv = "SPECTEST_VENDOR_DIR"
+)
+
+// Fixture represents a spec test file of a pinned ssv-spec version
+type Fixture struct {
+	// Version is the ssv-spec git ref (tag, branch or commit) of the fixture
+	Version string
+	// Path is the path of the fixture in the ssv-spec repo
+	Path string
+}
+
+// URL returns the url of the raw fixture file
+func (f Fixture) URL() string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/bloxapp/ssv-spec/%s/%s", f.Version, f.Path)
+}
+
+func (f Fixture) String() string {
+	return fmt.Sprintf("%s@%s", f.Path, f.Version)
+}
+
+// NotCachedError is returned in offline mode when a fixture is not cached
+type NotCachedError struct {
+	Fixture   Fixture
+	CachePath string
+}
+
+func (e *NotCachedError) Error() string {
+	return fmt.Sprintf("spec test fixture %s is not cached at %s and %s is set, "+
+		"run once with network access or pre-seed the cache with %s", e.Fixture, e.CachePath, OfflineEnv, VendorDirEnv)
+}
+
+// Fetcher fetches the content of the given url
+type Fetcher func(url string) ([]byte, error)
+
+// Cache is a disk cache of spec test fixtures
+type Cache struct {
+	// Dir is the directory of the cache
+	Dir string
+	// VendorDir is an optional directory that is used to pre-seed the cache
+	VendorDir string
+	// Offline prevents fetching fixtures from the network
+	Offline bool
+	// Fetch is used to fetch fixtures that are not cached, defaults to an http get
+	Fetch Fetcher
+}
+
+// NewCache creates a cache in the given directory, offline mode and vendor dir are taken from the env
+func NewCache(dir string) *Cache {
+	offline, _ := strconv.ParseBool(os.Getenv(OfflineEnv))
+	return &Cache{
+		Dir:       dir,
+		VendorDir: os.Getenv(VendorDirEnv),
+		Offline:   offline,
+		Fetch:     httpFetch,
+	}
+}
+
+// Get returns the content of the given fixture.
+// the network is used only if the fixture is not cached or vendored, and offline mode is off
+func (c *Cache) Get(f Fixture) ([]byte, error) {
+	cachePath := c.path(c.Dir, f)
+	if data, err := ioutil.ReadFile(cachePath); err == nil {
+		return data, nil
+	}
+
+	if len(c.VendorDir) > 0 {
+		if data, err := ioutil.ReadFile(c.path(c.VendorDir, f)); err == nil {
+			return data, c.store(cachePath, data)
+		}
+	}
+
+	if c.Offline {
+		return nil, &NotCachedError{Fixture: f, CachePath: cachePath}
+	}
+
+	fetch := c.Fetch
+	if fetch == nil {
+		fetch = httpFetch
+	}
+	data, err := fetch(f.URL())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not fetch spec test fixture %s", f)
+	}
+	return data, c.store(cachePath, data)
+}
+
+func (c *Cache) path(dir string, f Fixture) string {
+	return filepath.Join(dir, f.Version, filepath.FromSlash(f.Path))
+}
+
+func (c *Cache) store(cachePath string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return errors.Wrap(err, "could not create cache dir")
+	}
+	return errors.Wrap(ioutil.WriteFile(cachePath, data, 0644), "could not write cached fixture")
+}
+
+func httpFetch(url string) ([]byte, error) {
+	resp, err := http.Get(url) // #nosec G107
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
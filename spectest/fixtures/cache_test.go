@@ -0,0 +1,76 @@
+package fixtures
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFixture = Fixture{Version: "v0.0.1", Path: "qbft/spectest/generate/tests.json"}
+
+func writeFixture(t *testing.T, dir string, f Fixture, data []byte) {
+	p := filepath.Join(dir, f.Version, filepath.FromSlash(f.Path))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+	require.NoError(t, ioutil.WriteFile(p, data, 0644))
+}
+
+func noFetch(t *testing.T) Fetcher {
+	return func(url string) ([]byte, error) {
+		t.Fatalf("unexpected network call to %s", url)
+		return nil, nil
+	}
+}
+
+func TestCache_Get(t *testing.T) {
+	t.Run("cached", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFixture(t, dir, testFixture, []byte("cached"))
+
+		c := &Cache{Dir: dir, Fetch: noFetch(t)}
+		data, err := c.Get(testFixture)
+		require.NoError(t, err)
+		require.Equal(t, []byte("cached"), data)
+	})
+
+	t.Run("vendored", func(t *testing.T) {
+		dir, vendorDir := t.TempDir(), t.TempDir()
+		writeFixture(t, vendorDir, testFixture, []byte("vendored"))
+
+		c := &Cache{Dir: dir, VendorDir: vendorDir, Offline: true, Fetch: noFetch(t)}
+		data, err := c.Get(testFixture)
+		require.NoError(t, err)
+		require.Equal(t, []byte("vendored"), data)
+
+		// the cache is seeded
+		c.VendorDir = ""
+		data, err = c.Get(testFixture)
+		require.NoError(t, err)
+		require.Equal(t, []byte("vendored"), data)
+	})
+
+	t.Run("offline and not cached", func(t *testing.T) {
+		c := &Cache{Dir: t.TempDir(), Offline: true, Fetch: noFetch(t)}
+		_, err := c.Get(testFixture)
+		var notCached *NotCachedError
+		require.ErrorAs(t, err, &notCached)
+		require.Equal(t, testFixture, notCached.Fixture)
+	})
+
+	t.Run("fetched", func(t *testing.T) {
+		var urls []string
+		c := &Cache{Dir: t.TempDir(), Fetch: func(url string) ([]byte, error) {
+			urls = append(urls, url)
+			return []byte("fetched"), nil
+		}}
+		for i := 0; i < 2; i++ {
+			data, err := c.Get(testFixture)
+			require.NoError(t, err)
+			require.Equal(t, []byte("fetched"), data)
+		}
+		// fetched once, then cached
+		require.Equal(t, []string{"https://raw.githubusercontent.com/bloxapp/ssv-spec/v0.0.1/qbft/spectest/generate/tests.json"}, urls)
+	})
+}
@@ -20,7 +20,6 @@ import (
 	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/storage"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/strategy/factory"
-	types2 "github.com/bloxapp/ssv/protocol/v1/types"
 	"github.com/bloxapp/ssv/protocol/v1/validator"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
@@ -79,8 +78,8 @@ func NewController(ctx context.Context, t *testing.T, logger *zap.Logger, identi
 	return ctrl
 }
 
-// GetControllerRoot return controller root by spec
-func GetControllerRoot(t *testing.T, c *controller.Controller, storedInstances []instance.Instancer) ([]byte, error) {
+// GetControllerRoot return controller root by spec, the domain is the one that the controller messages are signed with
+func GetControllerRoot(t *testing.T, c *controller.Controller, storedInstances []instance.Instancer, domain types.DomainType) ([]byte, error) {
 	rootStruct := struct {
 		Identifier             []byte
 		Height                 qbft2.Height
@@ -93,14 +92,14 @@ func GetControllerRoot(t *testing.T, c *controller.Controller, storedInstances [
 		Height:                 c.GetHeight(),
 		InstanceRoots:          make([][]byte, len(storedInstances)),
 		HigherReceivedMessages: c.HigherReceivedMessages,
-		Domain:                 types2.GetDefaultDomain(), // might need to be dynamic
-		Share:                  toSpecShare(c.ValidatorShare),
+		Domain:                 domain,
+		Share:                  toSpecShare(c.ValidatorShare, domain),
 	}
 
 	instances := make([][]byte, qbft2.HistoricalInstanceCapacity) // spec default history size
 	for i, inst := range storedInstances {
 		if inst != nil {
-			mappedInstance := MapToSpecInstance(t, c.Identifier, inst, c.ValidatorShare)
+			mappedInstance := MapToSpecInstance(t, c.Identifier, inst, c.ValidatorShare, domain)
 			r, err := mappedInstance.GetRoot()
 			if err != nil {
 				return nil, errors.Wrap(err, "failed getting instance root")
@@ -146,7 +145,7 @@ func NewQbftInstance(logger *zap.Logger, qbftStorage qbftstorage.QBFTStore, net
 }
 
 // MapToSpecInstance mapping instance to spec instance struct
-func MapToSpecInstance(t *testing.T, identifier []byte, qbftInstance instance.Instancer, instanceShare *beacon.Share) *qbft2.Instance {
+func MapToSpecInstance(t *testing.T, identifier []byte, qbftInstance instance.Instancer, instanceShare *beacon.Share, domain types.DomainType) *qbft2.Instance {
 	mappedInstance := new(qbft2.Instance)
 	if qbftInstance != nil {
 		preparedValue := qbftInstance.GetState().GetPreparedValue()
@@ -164,7 +163,7 @@ func MapToSpecInstance(t *testing.T, identifier []byte, qbftInstance instance.In
 		}
 
 		mappedInstance.State = &qbft2.State{
-			Share:                           toSpecShare(instanceShare),
+			Share:                           toSpecShare(instanceShare, domain),
 			ID:                              identifier,
 			Round:                           round,
 			Height:                          qbftInstance.GetState().GetHeight(),
@@ -216,8 +215,8 @@ func NewQBFTStorage(ctx context.Context, t *testing.T, logger *zap.Logger, role
 	return db, qbftstorage.NewQBFTStore(db, logger, role)
 }
 
-// toSpecShare convert ssv share to spec share
-func toSpecShare(share *beacon.Share) *types.Share {
+// toSpecShare convert ssv share to spec share with the given domain
+func toSpecShare(share *beacon.Share, domain types.DomainType) *types.Share {
 	specCommittee := make([]*types.Operator, 0)
 	for operatorID, node := range share.Committee {
 		specCommittee = append(specCommittee, &types.Operator{
@@ -237,7 +236,7 @@ func toSpecShare(share *beacon.Share) *types.Share {
 		Committee:       specCommittee,
 		Quorum:          uint64(share.ThresholdSize()),
 		PartialQuorum:   uint64(share.PartialThresholdSize()),
-		DomainType:      types2.GetDefaultDomain(),
+		DomainType:      domain,
 		Graffiti:        nil,
 	}
 }
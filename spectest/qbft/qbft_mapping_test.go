@@ -1,55 +1,48 @@
 package qbft
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/controller"
+	"github.com/bloxapp/ssv-spec/qbft/spectest/tests/proposal"
 	"github.com/bloxapp/ssv/utils/logex"
-	"io"
-	"net/http"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	"github.com/bloxapp/ssv-spec/qbft/spectest"
 	spectests "github.com/bloxapp/ssv-spec/qbft/spectest/tests"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/bloxapp/ssv/protocol/v1/types"
+	"github.com/bloxapp/ssv/spectest/fixtures"
 )
 
+// specDomain is the domain that spec tests are signed with
+var specDomain = spectypes.PrimusTestnet
+
+// TestMain sets the domain once before running the tests, so tests can run in parallel
+func TestMain(m *testing.M) {
+	types.SetDefaultDomain(specDomain)
+	os.Exit(m.Run())
+}
+
 func TestQBFTMapping(t *testing.T) {
 	path, _ := os.Getwd()
-	fileName := "tests.json"
-	filePath := path + "/" + fileName
-	jsonTests, err := os.ReadFile(filePath)
-	if err != nil {
-		//resp, err := http.Get("https://raw.githubusercontent.com/bloxapp/ssv-spec/V0.2/qbft/spectest/generate/tests.json")
-		resp, err := http.Get("https://raw.githubusercontent.com/bloxapp/ssv-spec/qbft_sync_v0.2.1/qbft/spectest/generate/tests.json")
-		require.NoError(t, err)
-
-		defer func() {
-			require.NoError(t, resp.Body.Close())
-		}()
-
-		jsonTests, err = io.ReadAll(resp.Body)
-		require.NoError(t, err)
-
-		require.NoError(t, os.WriteFile(filePath, jsonTests, 0644))
-	}
+	jsonTests, err := fixtures.NewCache(path).Get(fixtures.Fixture{
+		Version: "qbft_sync_v0.2.1",
+		Path:    "qbft/spectest/generate/tests.json",
+	})
+	require.NoError(t, err)
 
 	untypedTests := map[string]interface{}{}
 	if err := json.Unmarshal(jsonTests, &untypedTests); err != nil {
 		panic(err.Error())
 	}
 
-	origDomain := types.GetDefaultDomain()
-	types.SetDefaultDomain(spectypes.PrimusTestnet)
-	defer func() {
-		types.SetDefaultDomain(origDomain)
-	}()
-
 	tests := make(map[string]spectest.SpecTest)
 	for name, test := range untypedTests {
 		logex.Reset()
@@ -70,7 +63,7 @@ func TestQBFTMapping(t *testing.T) {
 			require.NoError(t, json.Unmarshal(byts, &typedTest))
 
 			t.Run(typedTest.TestName(), func(t *testing.T) {
-				RunMsgProcessingSpecTest(t, typedTest)
+				RunMsgProcessingSpecTest(t, typedTest, specDomain)
 			})
 		case reflect.TypeOf(&spectests.MsgSpecTest{}).String():
 			byts, err := json.Marshal(test)
@@ -88,6 +81,43 @@ func TestQBFTMapping(t *testing.T) {
 	}
 }
 
+func TestQBFTMsgProcessingWithQueue(t *testing.T) {
+	test := proposal.HappyFlow()
+	t.Run(test.TestName(), func(t *testing.T) {
+		RunMsgProcessingSpecTestWithQueue(t, test, specDomain)
+	})
+}
+
+func TestQBFTMsgProcessingDomainIsolation(t *testing.T) {
+	cases := []struct {
+		name   string
+		domain spectypes.DomainType
+	}{
+		{"spec domain", specDomain},
+		{"other domain", types.ShifuTestnet},
+	}
+	for _, c := range cases {
+		domain := c.domain
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			test := proposal.HappyFlow()
+			for i := 0; i < 5; i++ {
+				res := runMsgProcessing(t, test, domain)
+				require.NoError(t, res.lastErr)
+				require.Len(t, res.outputMessages, 1)
+
+				// the output prepare is signed with the domain of its runner, regardless of the other runner
+				signedMsg := &specqbft.SignedMessage{}
+				require.NoError(t, signedMsg.Decode(res.outputMessages[0].Data))
+				require.NoError(t, signedMsg.GetSignature().VerifyByOperators(signedMsg, domain, spectypes.QBFTSignatureType, test.Pre.State.Share.Committee))
+				if !bytes.Equal(domain, specDomain) {
+					require.Error(t, signedMsg.GetSignature().VerifyByOperators(signedMsg, specDomain, spectypes.QBFTSignatureType, test.Pre.State.Share.Committee))
+				}
+			}
+		})
+	}
+}
+
 func excludeTest() map[string]bool {
 	return map[string]bool{
 		controller.FutureDecided().TestName():             true, // multi instance required
@@ -17,14 +17,14 @@ import (
 	"testing"
 )
 
-// RunCreateMessageSpecTest runs spec test type CreateMsgSpecTest
-func RunCreateMessageSpecTest(t *testing.T, test *spectests.CreateMsgSpecTest) {
+// RunCreateMessageSpecTest runs spec test type CreateMsgSpecTest with the given domain
+func RunCreateMessageSpecTest(t *testing.T, test *spectests.CreateMsgSpecTest, domain spectypes.DomainType) {
 	ctx := context.TODO()
 	logger := logex.Build(test.Name, zapcore.DebugLevel, nil)
 
 	identifier := []byte{1, 2, 3, 4}
 
-	beacon := validator.NewTestBeacon(t)
+	beacon := validator.NewTestBeaconWithDomain(t, domain)
 	db, _ := NewQBFTStorage(ctx, t, logger, spectypes.BNRoleAttester.String())
 	defer func() {
 		db.Close()
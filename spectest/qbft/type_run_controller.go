@@ -21,8 +21,8 @@ import (
 	"time"
 )
 
-// RunControllerSpecTest runs spec test type ControllerSpecTest
-func RunControllerSpecTest(t *testing.T, test *spectests.ControllerSpecTest) {
+// RunControllerSpecTest runs spec test type ControllerSpecTest with the given domain
+func RunControllerSpecTest(t *testing.T, test *spectests.ControllerSpecTest, domain spectypes.DomainType) {
 	ctx := context.TODO()
 	defer ctx.Done()
 	logger := logex.Build(test.Name, zapcore.DebugLevel, nil)
@@ -35,7 +35,7 @@ func RunControllerSpecTest(t *testing.T, test *spectests.ControllerSpecTest) {
 	share, keySet := ToMappedShare(t, testingutils.TestingShare(testingutils.Testing4SharesSet()))
 	pi, _ := protcolp2p.GenPeerID()
 	p2pNet := protcolp2p.NewMockNetwork(logger, pi, 10)
-	beacon := validator.NewTestBeacon(t)
+	beacon := validator.NewTestBeaconWithDomain(t, domain)
 	forkVersion := forksprotocol.GenesisForkVersion
 	ctrl := NewController(ctx, t, logger, identifier, qbftStorage, share, p2pNet, beacon, forkVersion)
 	require.NoError(t, ctrl.Init())
@@ -133,7 +133,7 @@ func RunControllerSpecTest(t *testing.T, test *spectests.ControllerSpecTest) {
 		require.EqualValues(t, runData.DecidedSyncCallCnt, decidedSyncCalledCnt)
 
 		if len(runData.ControllerPostRoot) > 0 {
-			r, err := GetControllerRoot(t, ctrl, storedInstances)
+			r, err := GetControllerRoot(t, ctrl, storedInstances, domain)
 			require.NoError(t, err)
 			require.EqualValues(t, runData.ControllerPostRoot, hex.EncodeToString(r))
 		}
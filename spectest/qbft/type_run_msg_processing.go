@@ -3,9 +3,9 @@ package qbft
 import (
 	"context"
 	"encoding/hex"
-	"fmt"
 	"testing"
 
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectests "github.com/bloxapp/ssv-spec/qbft/spectest/tests"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/stretchr/testify/require"
@@ -17,8 +17,41 @@ import (
 	"github.com/bloxapp/ssv/utils/logex"
 )
 
-// RunMsgProcessingSpecTest for spec test type MsgProcessingSpecTest
-func RunMsgProcessingSpecTest(t *testing.T, test *spectests.MsgProcessingSpecTest) {
+// msgProcessingResult is the outcome of processing the input messages of a MsgProcessingSpecTest
+type msgProcessingResult struct {
+	instance       *specqbft.Instance
+	lastErr        error
+	outputMessages []spectypes.SSVMessage
+}
+
+// RunMsgProcessingSpecTest for spec test type MsgProcessingSpecTest, output messages are signed with the given domain
+func RunMsgProcessingSpecTest(t *testing.T, test *spectests.MsgProcessingSpecTest, domain spectypes.DomainType) {
+	res := runMsgProcessing(t, test, domain)
+
+	ErrorHandling(t, test.ExpectedError, res.lastErr)
+
+	mappedRoot, err := res.instance.State.GetRoot()
+	require.NoError(t, err)
+	require.Equal(t, test.PostRoot, hex.EncodeToString(mappedRoot))
+
+	outputMessages := res.outputMessages
+	require.Equal(t, len(test.OutputMessages), len(outputMessages))
+
+	// TODO needed for version v0.3.2. need to revert on version v0.3.3
+	/*for i, outputMessage := range outputMessages {
+		msg1 := test.OutputMessages[i]
+		r1, _ := msg1.GetRoot()
+
+		msg2 := &specqbft.SignedMessage{}
+		require.NoError(t, msg2.Decode(outputMessage.Data))
+
+		r2, _ := msg2.GetRoot()
+		require.EqualValues(t, r1, r2, fmt.Sprintf("output msg %d roots not equal", i))
+	}*/
+}
+
+// runMsgProcessing processes the input messages of the given test with a new instance
+func runMsgProcessing(t *testing.T, test *spectests.MsgProcessingSpecTest, domain spectypes.DomainType) *msgProcessingResult {
 	ctx := context.TODO()
 	defer ctx.Done()
 	logger := logex.Build(test.Name, zapcore.DebugLevel, nil)
@@ -27,7 +60,7 @@ func RunMsgProcessingSpecTest(t *testing.T, test *spectests.MsgProcessingSpecTes
 	forkVersion := forksprotocol.GenesisForkVersion
 	pi, _ := protocolp2p.GenPeerID()
 	p2pNet := protocolp2p.NewMockNetwork(logger, pi, 10)
-	beacon := validator.NewTestBeacon(t)
+	beacon := validator.NewTestBeaconWithDomain(t, domain)
 
 	db, qbftStorage := NewQBFTStorage(ctx, t, logger, spectypes.BNRoleAttester.String())
 	defer func() {
@@ -52,29 +85,9 @@ func RunMsgProcessingSpecTest(t *testing.T, test *spectests.MsgProcessingSpecTes
 		}
 	}
 
-	mappedInstance := MapToSpecInstance(t, identifier, qbftInstance, share)
-
-	ErrorHandling(t, test.ExpectedError, lastErr)
-
-	encode, _ := mappedInstance.State.Encode()
-	fmt.Println("instance root -")
-	fmt.Println(string(encode)) // TODO REMOVE AFTER
-	mappedRoot, err := mappedInstance.State.GetRoot()
-	require.NoError(t, err)
-	require.Equal(t, test.PostRoot, hex.EncodeToString(mappedRoot))
-
-	outputMessages := p2pNet.(BroadcastMessagesGetter).GetBroadcastMessages()
-	require.Equal(t, len(test.OutputMessages), len(outputMessages))
-
-	// TODO needed for version v0.3.2. need to revert on version v0.3.3
-	/*for i, outputMessage := range outputMessages {
-		msg1 := test.OutputMessages[i]
-		r1, _ := msg1.GetRoot()
-
-		msg2 := &specqbft.SignedMessage{}
-		require.NoError(t, msg2.Decode(outputMessage.Data))
-
-		r2, _ := msg2.GetRoot()
-		require.EqualValues(t, r1, r2, fmt.Sprintf("output msg %d roots not equal", i))
-	}*/
+	return &msgProcessingResult{
+		instance:       MapToSpecInstance(t, identifier, qbftInstance, share, domain),
+		lastErr:        lastErr,
+		outputMessages: p2pNet.(BroadcastMessagesGetter).GetBroadcastMessages(),
+	}
 }
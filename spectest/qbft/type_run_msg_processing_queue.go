@@ -0,0 +1,132 @@
+package qbft
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectests "github.com/bloxapp/ssv-spec/qbft/spectest/tests"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/msgqueue"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// queueDrainTimeout is the time to wait for the queue consumer to process all input messages
+const queueDrainTimeout = time.Second * 5
+
+// specIdentifierIndexer wraps the given indexer so messages of the given (padded) message id are indexed by the spec identifier.
+// spec tests sign their messages with a short identifier, while the queue indexes messages by the padded message id
+func specIdentifierIndexer(indexer msgqueue.Indexer, mid spectypes.MessageID, identifier []byte) msgqueue.Indexer {
+	return func(msg *spectypes.SSVMessage) msgqueue.Index {
+		idx := indexer(msg)
+		if idx.ID == mid.String() {
+			idx.ID = hex.EncodeToString(identifier)
+		}
+		return idx
+	}
+}
+
+// RunMsgProcessingSpecTestWithQueue for spec test type MsgProcessingSpecTest.
+// unlike RunMsgProcessingSpecTest, the input messages are pushed into the controller msgqueue and handled by the
+// queue consumer goroutine with the controller message handler (as in production),
+// therefore the order in which messages are popped by the consumer is tested as well.
+// it is suitable only for tests that all of their input messages are expected to be popped by the consumer
+func RunMsgProcessingSpecTestWithQueue(t *testing.T, test *spectests.MsgProcessingSpecTest, domain spectypes.DomainType) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logex.Build(test.Name, zapcore.DebugLevel, nil)
+
+	identifier := test.Pre.State.ID
+	mid := specqbft.ControllerIdToMessageID(identifier)
+	forkVersion := forksprotocol.GenesisForkVersion
+	pi, _ := protocolp2p.GenPeerID()
+	p2pNet := protocolp2p.NewMockNetwork(logger, pi, 10)
+	beacon := validator.NewTestBeaconWithDomain(t, domain)
+
+	db, qbftStorage := NewQBFTStorage(ctx, t, logger, spectypes.BNRoleAttester.String())
+	defer func() {
+		db.Close()
+	}()
+	share, keySet := ToMappedShare(t, test.Pre.State.Share)
+
+	qbftInstance := NewQbftInstance(logger, qbftStorage, p2pNet, beacon, share, identifier[:], forkVersion)
+	qbftInstance.Init()
+	qbftInstance.GetState().InputValue.Store(test.Pre.StartValue)
+	qbftInstance.GetState().Round.Store(test.Pre.State.Round)
+	qbftInstance.GetState().Height.Store(test.Pre.State.Height)
+	qbftInstance.GetState().ProposalAcceptedForCurrentRound.Store(test.Pre.State.ProposalAcceptedForCurrentRound)
+	// the queue pops messages by state only once the instance is ready
+	qbftInstance.GetState().Stage.Store(int32(qbft.RoundStateReady))
+
+	// add share key to account
+	require.NoError(t, beacon.KeyManager.AddShare(keySet.Shares[share.NodeID]))
+
+	ctrl := NewController(ctx, t, logger, mid, qbftStorage, share, p2pNet, beacon, forkVersion)
+	// the controller validates the identifier of the input messages, which is the spec identifier
+	ctrl.Identifier = identifier[:]
+	q, err := msgqueue.New(
+		logger.With(zap.String("who", "msg_q")),
+		msgqueue.WithIndexers(
+			specIdentifierIndexer(msgqueue.SignedMsgIndexer(), mid, ctrl.Identifier),
+			specIdentifierIndexer(msgqueue.DecidedMsgIndexer(), mid, ctrl.Identifier),
+			specIdentifierIndexer(msgqueue.SignedPostConsensusMsgIndexer(), mid, ctrl.Identifier),
+		),
+	)
+	require.NoError(t, err)
+	ctrl.Q = q
+	ctrl.SetCurrentInstance(qbftInstance)
+
+	var lastErrLock sync.Mutex
+	var lastErr error
+	handled := atomic.NewInt64(0)
+	go ctrl.StartQueueConsumer(func(msg *spectypes.SSVMessage) error {
+		defer handled.Inc()
+		err := ctrl.MessageHandler(msg)
+		if err != nil {
+			lastErrLock.Lock()
+			lastErr = err
+			lastErrLock.Unlock()
+		}
+		return err
+	})
+
+	for _, msg := range test.InputMessages {
+		data, err := msg.Encode()
+		require.NoError(t, err)
+		ctrl.Q.Add(&spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   mid,
+			Data:    data,
+		})
+	}
+
+	require.Eventually(t, func() bool {
+		return handled.Load() == int64(len(test.InputMessages))
+	}, queueDrainTimeout, time.Millisecond*50, "queue consumer did not handle all input messages")
+	cancel()
+
+	mappedInstance := MapToSpecInstance(t, identifier, qbftInstance, share, domain)
+
+	lastErrLock.Lock()
+	ErrorHandling(t, test.ExpectedError, lastErr)
+	lastErrLock.Unlock()
+
+	mappedRoot, err := mappedInstance.State.GetRoot()
+	require.NoError(t, err)
+	require.Equal(t, test.PostRoot, hex.EncodeToString(mappedRoot))
+
+	outputMessages := p2pNet.(BroadcastMessagesGetter).GetBroadcastMessages()
+	require.Equal(t, len(test.OutputMessages), len(outputMessages))
+}
@@ -0,0 +1,162 @@
+package ssv
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	qbftStorage "github.com/bloxapp/ssv/ibft/storage"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v1/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// baseValidatorOptions are the dependencies of a validator that is created by BaseValidator
+type baseValidatorOptions struct {
+	logger        *zap.Logger
+	network       protocolp2p.Network
+	beacon        beaconprotocol.Beacon
+	keyManager    spectypes.KeyManager
+	storage       qbftstorage.QBFTStore
+	beaconNetwork core.Network
+	domain        spectypes.DomainType
+}
+
+// BaseValidatorOption overrides a default dependency of BaseValidator
+type BaseValidatorOption func(opts *baseValidatorOptions)
+
+// WithLogger sets the logger of the validator
+func WithLogger(logger *zap.Logger) BaseValidatorOption {
+	return func(opts *baseValidatorOptions) {
+		opts.logger = logger
+	}
+}
+
+// WithNetwork sets the p2p network of the validator, e.g. a network that records broadcasted messages
+func WithNetwork(network protocolp2p.Network) BaseValidatorOption {
+	return func(opts *baseValidatorOptions) {
+		opts.network = network
+	}
+}
+
+// WithBeacon sets the beacon and key manager of the validator, the shares of the key set are not added to the key manager
+func WithBeacon(beacon beaconprotocol.Beacon, keyManager spectypes.KeyManager) BaseValidatorOption {
+	return func(opts *baseValidatorOptions) {
+		opts.beacon = beacon
+		opts.keyManager = keyManager
+	}
+}
+
+// WithStorage sets the qbft storage of the validator
+func WithStorage(store qbftstorage.QBFTStore) BaseValidatorOption {
+	return func(opts *baseValidatorOptions) {
+		opts.storage = store
+	}
+}
+
+// WithBeaconNetwork sets the beacon network of the validator
+func WithBeaconNetwork(network core.Network) BaseValidatorOption {
+	return func(opts *baseValidatorOptions) {
+		opts.beaconNetwork = network
+	}
+}
+
+// WithDomain sets the domain that the default beacon signs with, signatures are verified with the global domain
+func WithDomain(domain spectypes.DomainType) BaseValidatorOption {
+	return func(opts *baseValidatorOptions) {
+		opts.domain = domain
+	}
+}
+
+// BaseValidator creates an attester validator of the first operator in the given key set.
+// dependencies that were not set by the given options are replaced with a nop logger, a mock network, a test beacon
+// that holds the shares of the key set and an in-memory storage. the validator is closed once the test is done
+func BaseValidator(t *testing.T, keySet *testingutils.TestKeySet, opts ...BaseValidatorOption) *validator.Validator {
+	options := &baseValidatorOptions{
+		beaconNetwork: core.PraterNetwork,
+		domain:        spectypes.PrimusTestnet,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if options.logger == nil {
+		options.logger = zap.L()
+	}
+	if options.network == nil {
+		pi, err := protocolp2p.GenPeerID()
+		require.NoError(t, err)
+		options.network = protocolp2p.NewMockNetwork(options.logger, pi, 10)
+	}
+	if options.beacon == nil {
+		beacon := validator.NewTestBeaconWithDomain(t, options.domain)
+		for _, sk := range keySet.Shares {
+			require.NoError(t, beacon.AddShare(sk))
+		}
+		options.beacon = beacon
+		options.keyManager = beacon.KeyManager
+	}
+	if options.storage == nil {
+		db, err := storage.GetStorageFactory(basedb.Options{
+			Type:   "badger-memory",
+			Logger: options.logger,
+			Ctx:    ctx,
+		})
+		require.NoError(t, err)
+		t.Cleanup(db.Close)
+		options.storage = qbftStorage.New(db, options.logger, spectypes.BNRoleAttester.String(), forksprotocol.GenesisForkVersion)
+	}
+
+	committee := make(map[spectypes.OperatorID]*beaconprotocol.Node, len(keySet.Shares))
+	operatorIds := make([]uint64, 0, len(keySet.Shares))
+	for id, sk := range keySet.Shares {
+		committee[id] = &beaconprotocol.Node{
+			IbftID: uint64(id),
+			Pk:     sk.GetPublicKey().Serialize(),
+		}
+		operatorIds = append(operatorIds, uint64(id))
+	}
+	// the leader is selected by the position of the operator, therefore the order must be deterministic
+	sort.Slice(operatorIds, func(i, j int) bool {
+		return operatorIds[i] < operatorIds[j]
+	})
+	share := &beaconprotocol.Share{
+		NodeID:      1,
+		PublicKey:   keySet.ValidatorPK,
+		Committee:   committee,
+		OperatorIds: operatorIds,
+	}
+
+	v := validator.NewValidator(&validator.Options{
+		Context:                    ctx,
+		Logger:                     options.logger,
+		IbftStorage:                options.storage,
+		Network:                    beaconprotocol.NewNetwork(options.beaconNetwork),
+		P2pNetwork:                 options.network,
+		Beacon:                     options.beacon,
+		KeyManager:                 options.keyManager,
+		Share:                      share,
+		ForkVersion:                forksprotocol.GenesisForkVersion,
+		SyncRateLimit:              time.Second * 5,
+		SignatureCollectionTimeout: time.Second * 5,
+		MinPeers:                   2,
+		DutyRoles:                  []spectypes.BeaconRole{spectypes.BNRoleAttester},
+	}).(*validator.Validator)
+	t.Cleanup(func() {
+		_ = v.Close()
+	})
+	return v
+}
@@ -0,0 +1,67 @@
+package ssv
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// recordingNetwork records the broadcasted messages
+type recordingNetwork struct {
+	protocolp2p.Network
+
+	lock        sync.Mutex
+	broadcasted []spectypes.SSVMessage
+}
+
+func (n *recordingNetwork) Broadcast(msg spectypes.SSVMessage) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.broadcasted = append(n.broadcasted, msg)
+	return nil
+}
+
+func (n *recordingNetwork) messages() []spectypes.SSVMessage {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return append([]spectypes.SSVMessage{}, n.broadcasted...)
+}
+
+func TestBaseValidator_RecordingNetwork(t *testing.T) {
+	logger := logex.Build(t.Name(), zapcore.DebugLevel, nil)
+	pi, err := protocolp2p.GenPeerID()
+	require.NoError(t, err)
+	net := &recordingNetwork{Network: protocolp2p.NewMockNetwork(logger, pi, 10)}
+
+	keySet := testingutils.Testing4SharesSet()
+	v := BaseValidator(t, keySet, WithNetwork(net), WithLogger(logger))
+
+	pk := phase0.BLSPubKey{}
+	copy(pk[:], keySet.ValidatorPK.Serialize())
+	decidedValue, err := (&spectypes.ConsensusData{
+		Duty: &spectypes.Duty{Type: spectypes.BNRoleAttester, PubKey: pk, Slot: 12},
+		AttestationData: &phase0.AttestationData{
+			Slot:   12,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{Epoch: 1},
+		},
+	}).Encode()
+	require.NoError(t, err)
+
+	// the post consensus partial signature is broadcasted through the injected network
+	ctrl := v.Ibfts()[spectypes.BNRoleAttester]
+	require.NoError(t, ctrl.PostConsensusDutyExecution(logger, decidedValue, 3, spectypes.BNRoleAttester))
+
+	msgs := net.messages()
+	require.Len(t, msgs, 1)
+	require.Equal(t, spectypes.SSVPartialSignatureMsgType, msgs[0].MsgType)
+	require.Equal(t, spectypes.NewMsgID(keySet.ValidatorPK.Serialize(), spectypes.BNRoleAttester), msgs[0].MsgID)
+}
@@ -5,9 +5,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"github.com/bloxapp/ssv/protocol/v1/types"
-	"io/ioutil"
-	"net/http"
 	"os"
 	"testing"
 	"time"
@@ -26,50 +23,37 @@ import (
 
 	qbftStorage "github.com/bloxapp/ssv/ibft/storage"
 	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
-	beaconprotocol "github.com/bloxapp/ssv/protocol/v1/blockchain/beacon"
-	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v1/qbft/instance/msgcont"
-	"github.com/bloxapp/ssv/protocol/v1/validator"
+	"github.com/bloxapp/ssv/protocol/v1/types"
+	"github.com/bloxapp/ssv/spectest/fixtures"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils/logex"
 )
 
+// TestMain sets the domain that spec tests are signed with once before running the tests, so tests can run in parallel
+func TestMain(m *testing.M) {
+	types.SetDefaultDomain(spectypes.PrimusTestnet)
+	os.Exit(m.Run())
+}
+
 func TestSSVMapping(t *testing.T) {
 	// TODO(nkryuchkov): fix
 	t.Skip()
 
 	path, _ := os.Getwd()
-	fileName := "tests.json"
-	filePath := path + "/" + fileName
-
-	jsonTests, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		resp, err := http.Get("https://raw.githubusercontent.com/bloxapp/ssv-spec/V0.2/ssv/spectest/generate/tests.json")
-		require.NoError(t, err)
-
-		defer func() {
-			require.NoError(t, resp.Body.Close())
-		}()
-
-		jsonTests, err = ioutil.ReadAll(resp.Body)
-		require.NoError(t, err)
-
-		require.NoError(t, ioutil.WriteFile(filePath, jsonTests, 0644))
-	}
+	jsonTests, err := fixtures.NewCache(path).Get(fixtures.Fixture{
+		Version: "V0.2",
+		Path:    "ssv/spectest/generate/tests.json",
+	})
+	require.NoError(t, err)
 
 	specTests := map[string]*tests.SpecTest{}
 	if err := json.Unmarshal(jsonTests, &specTests); err != nil {
 		require.NoError(t, err)
 	}
 
-	origDomain := types.GetDefaultDomain()
-	types.SetDefaultDomain(spectypes.PrimusTestnet)
-	defer func() {
-		types.SetDefaultDomain(origDomain)
-	}()
-
 	testMap := testsToRun() // TODO: remove
 
 	for _, test := range specTests {
@@ -79,7 +63,7 @@ func TestSSVMapping(t *testing.T) {
 		}
 
 		t.Run(test.Name, func(t *testing.T) {
-			runMappingTest(t, test)
+			runMappingTest(t, test, spectypes.PrimusTestnet)
 		})
 	}
 }
@@ -97,14 +81,11 @@ func testsToRun() map[string]struct{} {
 	return result
 }
 
-func runMappingTest(t *testing.T, test *tests.SpecTest) {
+func runMappingTest(t *testing.T, test *tests.SpecTest, domain spectypes.DomainType) {
 	ctx := context.TODO()
 	logger := logex.Build(test.Name, zapcore.DebugLevel, nil)
 
 	forkVersion := forksprotocol.GenesisForkVersion
-	pi, _ := protocolp2p.GenPeerID()
-	beacon := validator.NewTestBeacon(t)
-
 	keysSet := testingutils.Testing4SharesSet()
 
 	beaconNetwork := core.NetworkFromString(string(test.Runner.BeaconNetwork))
@@ -122,10 +103,6 @@ func runMappingTest(t *testing.T, test *tests.SpecTest) {
 	//require.Equalf(t, spectypes.BNRoleAttester, beaconRoleType, "only attester role is supported now")
 
 	ibftStorage := qbftStorage.New(db, logger, test.Runner.BeaconRoleType.String(), forkVersion)
-	require.NoError(t, beacon.AddShare(keysSet.Shares[1]))
-	require.NoError(t, beacon.AddShare(keysSet.Shares[2]))
-	require.NoError(t, beacon.AddShare(keysSet.Shares[3]))
-	require.NoError(t, beacon.AddShare(keysSet.Shares[4]))
 
 	// TODO: add an option: array of duty roles, setup ibfts depending on that.
 	const attesterRoleType = spectypes.BNRoleAttester
@@ -135,51 +112,18 @@ func runMappingTest(t *testing.T, test *tests.SpecTest) {
 		fmt.Printf("test.Runner.Share.Committee[%d].GetPublicKey(): %v\n", i, hex.EncodeToString(test.Runner.Share.Committee[i].GetPublicKey()))
 	}
 
-	share := &beaconprotocol.Share{
-		NodeID:    1,
-		PublicKey: keysSet.ValidatorPK,
-		Committee: map[spectypes.OperatorID]*beaconprotocol.Node{
-			1: {
-				IbftID: 1,
-				Pk:     keysSet.Shares[1].GetPublicKey().Serialize(),
-			},
-			2: {
-				IbftID: 2,
-				Pk:     keysSet.Shares[2].GetPublicKey().Serialize(),
-			},
-			3: {
-				IbftID: 3,
-				Pk:     keysSet.Shares[3].GetPublicKey().Serialize(),
-			},
-			4: {
-				IbftID: 4,
-				Pk:     keysSet.Shares[4].GetPublicKey().Serialize(),
-			},
-		},
-		OperatorIds: []uint64{1, 2, 3, 4},
-	}
-
-	v := validator.NewValidator(&validator.Options{
-		Context:                    ctx,
-		Logger:                     logger,
-		IbftStorage:                ibftStorage,
-		Network:                    beaconprotocol.NewNetwork(beaconNetwork),
-		P2pNetwork:                 protocolp2p.NewMockNetwork(logger, pi, 10),
-		Beacon:                     beacon,
-		Share:                      share,
-		ForkVersion:                forkVersion,
-		SyncRateLimit:              time.Second * 5,
-		SignatureCollectionTimeout: time.Second * 5,
-		MinPeers:                   2,
-		ReadMode:                   false,
-		FullNode:                   false,
-		DutyRoles:                  []spectypes.BeaconRole{attesterRoleType},
-	})
+	v := BaseValidator(t, keysSet,
+		WithLogger(logger),
+		WithStorage(ibftStorage),
+		WithBeaconNetwork(beaconNetwork),
+		WithDomain(domain),
+	)
 
-	qbftCtrl := v.(*validator.Validator).Ibfts()[attesterRoleType].(*controller.Controller)
+	qbftCtrl := v.Ibfts()[attesterRoleType].(*controller.Controller)
 	qbftCtrl.State = controller.Ready
 	go qbftCtrl.StartQueueConsumer(qbftCtrl.MessageHandler)
 	require.NoError(t, qbftCtrl.Init())
+	require.NoError(t, v.Start())
 	go v.StartDuty(test.Duty)
 
 	for _, msg := range test.Messages {
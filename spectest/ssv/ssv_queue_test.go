@@ -0,0 +1,79 @@
+package ssv
+
+import (
+	"testing"
+	"time"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	"github.com/bloxapp/ssv-spec/ssv/spectest/tests"
+	"github.com/bloxapp/ssv-spec/ssv/spectest/tests/consensus/attester"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	protocolp2p "github.com/bloxapp/ssv/protocol/v1/p2p"
+	"github.com/bloxapp/ssv/protocol/v1/qbft/controller"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+func TestSSVMsgProcessingWithQueue(t *testing.T) {
+	test := attester.HappyFlow()
+	t.Run(test.Name, func(t *testing.T) {
+		runMsgProcessingWithQueue(t, test, testingutils.TestAttesterConsensusDataByts)
+	})
+}
+
+// runMsgProcessingWithQueue starts the duty of the given test and pushes its messages into the validator,
+// the messages are added to the queue of the role controller and handled by its queue consumer (as in production).
+// the post duty runner state root is not compared as the state mapping is not supported yet (see TestSSVMapping),
+// instead the test checks that the expected value was decided and that the partial signature was broadcasted
+func runMsgProcessingWithQueue(t *testing.T, test *tests.SpecTest, expectedDecidedValue []byte) {
+	logger := logex.Build(test.Name, zapcore.DebugLevel, nil)
+	keySet := testingutils.Testing4SharesSet()
+
+	pi, err := protocolp2p.GenPeerID()
+	require.NoError(t, err)
+	mockNet := protocolp2p.NewMockNetwork(logger, pi, 10)
+	// the committee peers are required for the controller to sync and start the instance
+	for oid := range keySet.Shares {
+		if oid == 1 {
+			continue
+		}
+		peerID, err := protocolp2p.GenPeerID()
+		require.NoError(t, err)
+		mockNet.AddPeers(keySet.ValidatorPK.Serialize(), protocolp2p.NewMockNetwork(logger, peerID, 10))
+	}
+	net := &recordingNetwork{Network: mockNet}
+
+	v := BaseValidator(t, keySet, WithNetwork(net), WithLogger(logger))
+	ctrl := v.Ibfts()[test.Duty.Type].(*controller.Controller)
+	require.NoError(t, v.Start())
+	require.Eventually(t, ctrl.IsReady, time.Second*5, time.Millisecond*50, "controller is not ready")
+
+	go v.StartDuty(test.Duty)
+	require.Eventually(t, func() bool {
+		return ctrl.GetCurrentInstance() != nil
+	}, time.Second*5, time.Millisecond*50, "instance was not started")
+
+	for _, msg := range test.Messages {
+		require.NoError(t, v.ProcessMsg(msg))
+	}
+
+	require.Eventually(t, func() bool {
+		return ctrl.Q.Size() == 0
+	}, time.Second*5, time.Millisecond*50, "queue consumer did not handle all messages")
+
+	decidedValue, found, err := ctrl.GetDecidedValue(specqbft.FirstHeight)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, expectedDecidedValue, decidedValue)
+
+	var partialSigs int
+	for _, msg := range net.messages() {
+		if msg.MsgType == spectypes.SSVPartialSignatureMsgType {
+			partialSigs++
+		}
+	}
+	require.Equal(t, 1, partialSigs)
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// checksumFileSuffix is the suffix of the file that holds the checksum of a backup
+const checksumFileSuffix = ".sha256"
+
+// ChecksumFilePath returns the path of the checksum file of the given backup file
+func ChecksumFilePath(backupPath string) string {
+	return backupPath + checksumFileSuffix
+}
+
+// BackupToFile writes a backup of the given db into a file,
+// the checksum of the backup is saved next to it and is used to verify the backup on restore
+func BackupToFile(db basedb.IDb, backupPath string) (string, error) {
+	f, err := os.OpenFile(backupPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", errors.Wrap(err, "could not create backup file")
+	}
+	h := sha256.New()
+	if err := db.Backup(io.MultiWriter(f, h)); err != nil {
+		_ = f.Close()
+		return "", errors.Wrap(err, "could not backup db")
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return "", errors.Wrap(err, "could not sync backup file")
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Wrap(err, "could not close backup file")
+	}
+	checksum := hex.EncodeToString(h.Sum(nil))
+	if err := ioutil.WriteFile(ChecksumFilePath(backupPath), []byte(checksum), 0600); err != nil {
+		return "", errors.Wrap(err, "could not write checksum file")
+	}
+	return checksum, nil
+}
+
+// VerifyBackupFile verifies the integrity of the given backup file against its checksum file
+func VerifyBackupFile(backupPath string) error {
+	expected, err := ioutil.ReadFile(ChecksumFilePath(backupPath))
+	if err != nil {
+		return errors.Wrap(err, "could not read checksum file")
+	}
+	f, err := os.Open(backupPath)
+	if err != nil {
+		return errors.Wrap(err, "could not open backup file")
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Wrap(err, "could not read backup file")
+	}
+	if strings.TrimSpace(string(expected)) != hex.EncodeToString(h.Sum(nil)) {
+		return errors.New("backup checksum mismatch")
+	}
+	return nil
+}
+
+// RestoreFromFile verifies the given backup file and loads it into the db,
+// all existing data in the db is replaced
+func RestoreFromFile(db basedb.IDb, backupPath string) error {
+	if err := VerifyBackupFile(backupPath); err != nil {
+		return errors.Wrap(err, "could not verify backup")
+	}
+	f, err := os.Open(backupPath)
+	if err != nil {
+		return errors.Wrap(err, "could not open backup file")
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	if err := db.Restore(f); err != nil {
+		return errors.Wrap(err, "could not restore db")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func newTestDB(t *testing.T) basedb.IDb {
+	db, err := GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestBackupRestore(t *testing.T) {
+	prefix := []byte("prefix")
+	backupPath := filepath.Join(t.TempDir(), "db.backup")
+
+	src := newTestDB(t)
+	defer src.Close()
+	require.NoError(t, src.Set(prefix, []byte("key1"), []byte("value1")))
+	require.NoError(t, src.Set(prefix, []byte("key2"), []byte("value2")))
+
+	checksum, err := BackupToFile(src, backupPath)
+	require.NoError(t, err)
+	require.NotEmpty(t, checksum)
+	require.NoError(t, VerifyBackupFile(backupPath))
+
+	// existing data is replaced by the backup
+	dst := newTestDB(t)
+	defer dst.Close()
+	require.NoError(t, dst.Set(prefix, []byte("key3"), []byte("value3")))
+	require.NoError(t, RestoreFromFile(dst, backupPath))
+
+	count, err := dst.CountByCollection(prefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+	obj, found, err := dst.Get(prefix, []byte("key1"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value1"), obj.Value)
+	_, found, err = dst.Get(prefix, []byte("key3"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	t.Run("existing backup file", func(t *testing.T) {
+		_, err := BackupToFile(src, backupPath)
+		require.Error(t, err)
+	})
+
+	t.Run("corrupted backup", func(t *testing.T) {
+		raw, err := ioutil.ReadFile(backupPath)
+		require.NoError(t, err)
+		raw[len(raw)-1] ^= 0xff
+		require.NoError(t, ioutil.WriteFile(backupPath, raw, 0600))
+		require.EqualError(t, VerifyBackupFile(backupPath), "backup checksum mismatch")
+		db := newTestDB(t)
+		defer db.Close()
+		require.Error(t, RestoreFromFile(db, backupPath))
+	})
+}
@@ -2,6 +2,7 @@ package basedb
 
 import (
 	"context"
+	"io"
 
 	"go.uber.org/zap"
 )
@@ -13,6 +14,8 @@ type Options struct {
 	Reporting bool   `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
 	Logger    *zap.Logger
 	Ctx       context.Context
+	// ReadOnly opens an existing db w/o allowing writes, used by diagnostic tools
+	ReadOnly bool
 }
 
 // Txn interface for badger transaction like functions
@@ -27,17 +30,38 @@ type Txn interface {
 type IDb interface {
 	Set(prefix []byte, key []byte, value []byte) error
 	SetMany(prefix []byte, n int, next func(int) (Obj, error)) error
+	// SetItems saves the given items atomically, either all items are saved or none of them
+	SetItems(items []Item) error
 	Get(prefix []byte, key []byte) (Obj, bool, error)
 	GetMany(prefix []byte, keys [][]byte, iterator func(Obj) error) error
 	Delete(prefix []byte, key []byte) error
 	DeleteByPrefix(prefix []byte) (int, error)
 	GetAll(prefix []byte, handler func(int, Obj) error) error
+	// GetAllPaged iterates all the items of a given collection in a single read transaction, loading a page of items at once
+	GetAllPaged(prefix []byte, pageSize int, handler func(int, Obj) error) error
 	CountByCollection(prefix []byte) (int64, error)
+	SizeByCollection(prefix []byte) (int64, error)
 	RemoveAllByCollection(prefix []byte) error
+	// Update runs the given function in a read-write transaction,
+	// writes are committed only if the function returns no error
 	Update(fn func(Txn) error) error
+	// Backup writes a full backup of the db into the given writer
+	Backup(w io.Writer) error
+	// Restore replaces all existing data with the backup from the given reader, existing data is kept if restoring fails
+	Restore(r io.Reader) error
 	Close()
 }
 
+// DefaultPageSize is the default amount of items that are loaded at once by GetAllPaged
+const DefaultPageSize = 1000
+
+// Item is a key/value pair under some prefix, used for multi-key writes
+type Item struct {
+	Prefix []byte
+	Key    []byte
+	Value  []byte
+}
+
 // Obj struct for getting key/value from storage
 type Obj struct {
 	Key   []byte
@@ -2,6 +2,11 @@ package kv
 
 import (
 	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/bloxapp/ssv/storage/basedb"
@@ -14,12 +19,25 @@ import (
 const (
 	// EntryNotFoundError is an error for a storage entry not found
 	EntryNotFoundError = "EntryNotFoundError"
+	// badgerLockErrorMsg is part of the error returned by badger when the directory lock is already acquired
+	badgerLockErrorMsg = "Another process is using this Badger database"
+	// restoreMaxPendingWrites is the max number of pending writes while loading a backup
+	restoreMaxPendingWrites = 256
+)
+
+var (
+	// ErrDBLocked is returned when the db directory is already in use by another process or instance
+	ErrDBLocked = errors.New("db is already in use by another process")
+	// ErrReadOnly is returned when a write is attempted on a db that was opened in read-only mode
+	ErrReadOnly = errors.New("db is opened in read-only mode")
 )
 
 // BadgerDb struct
 type BadgerDb struct {
-	db     *badger.DB
-	logger *zap.Logger
+	db       *badger.DB
+	opt      badger.Options
+	logger   *zap.Logger
+	readOnly bool
 }
 
 // New create new instance of Badger db
@@ -33,6 +51,17 @@ func New(options basedb.Options) (basedb.IDb, error) {
 		opt.InMemory = true
 		opt.Dir = ""
 		opt.ValueDir = ""
+		if options.ReadOnly {
+			return nil, errors.New("read-only mode is not supported for in-memory db")
+		}
+	} else if options.ReadOnly {
+		// a read-only db is never created, it must already exist
+		if _, err := os.Stat(options.Path); err != nil {
+			return nil, errors.Wrapf(err, "could not find db at %s", options.Path)
+		}
+		opt.ReadOnly = true
+	} else if err := validateDBPath(options.Path); err != nil {
+		return nil, err
 	}
 
 	if options.Logger != nil && options.Reporting {
@@ -43,11 +72,16 @@ func New(options basedb.Options) (basedb.IDb, error) {
 
 	db, err := badger.Open(opt)
 	if err != nil {
+		if strings.Contains(err.Error(), badgerLockErrorMsg) {
+			return nil, errors.Wrapf(ErrDBLocked, "could not open db at %s", options.Path)
+		}
 		return nil, errors.Wrap(err, "failed to open badger")
 	}
 	_db := BadgerDb{
-		db:     db,
-		logger: options.Logger,
+		db:       db,
+		opt:      opt,
+		logger:   options.Logger,
+		readOnly: options.ReadOnly,
 	}
 
 	if options.Reporting && options.Ctx != nil {
@@ -58,8 +92,27 @@ func New(options basedb.Options) (basedb.IDb, error) {
 	return &_db, nil
 }
 
+// validateDBPath makes sure the db directory exists and is writable
+func validateDBPath(path string) error {
+	if len(path) == 0 {
+		return errors.New("db path is empty")
+	}
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return errors.Wrapf(err, "could not create db path %s", path)
+	}
+	f, err := ioutil.TempFile(path, ".write-check-")
+	if err != nil {
+		return errors.Wrapf(err, "db path %s is not writable", path)
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
 // Set save value with key to storage
 func (b *BadgerDb) Set(prefix []byte, key []byte, value []byte) error {
+	if b.readOnly {
+		return ErrReadOnly
+	}
 	return b.db.Update(func(txn *badger.Txn) error {
 		return badgerTxn{txn}.Set(prefix, key, value)
 	})
@@ -67,6 +120,9 @@ func (b *BadgerDb) Set(prefix []byte, key []byte, value []byte) error {
 
 // SetMany save many values with the given keys in a single badger transaction
 func (b *BadgerDb) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	if b.readOnly {
+		return ErrReadOnly
+	}
 	wb := b.db.NewWriteBatch()
 	for i := 0; i < n; i++ {
 		item, err := next(i)
@@ -82,6 +138,21 @@ func (b *BadgerDb) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, err
 	return wb.Flush()
 }
 
+// SetItems saves the given items in a single badger transaction, so either all items are saved or none of them
+func (b *BadgerDb) SetItems(items []basedb.Item) error {
+	if b.readOnly {
+		return ErrReadOnly
+	}
+	return b.db.Update(func(txn *badger.Txn) error {
+		for _, item := range items {
+			if err := (badgerTxn{txn}).Set(item.Prefix, item.Key, item.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // Get return value for specified key
 func (b *BadgerDb) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
 	txn := b.db.NewTransaction(false)
@@ -127,6 +198,9 @@ func (b *BadgerDb) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Ob
 
 // Delete key in specific prefix
 func (b *BadgerDb) Delete(prefix []byte, key []byte) error {
+	if b.readOnly {
+		return ErrReadOnly
+	}
 	return b.db.Update(func(txn *badger.Txn) error {
 		return badgerTxn{txn}.Delete(prefix, key)
 	})
@@ -134,6 +208,9 @@ func (b *BadgerDb) Delete(prefix []byte, key []byte) error {
 
 // DeleteByPrefix all items with this prefix
 func (b *BadgerDb) DeleteByPrefix(prefix []byte) (int, error) {
+	if b.readOnly {
+		return 0, ErrReadOnly
+	}
 	count := 0
 	err := b.db.Update(func(txn *badger.Txn) error {
 		rawKeys := b.listRawKeys(prefix, txn)
@@ -180,6 +257,60 @@ func (b *BadgerDb) GetAll(prefix []byte, handler func(int, basedb.Obj) error) er
 	return err
 }
 
+// GetAllPaged iterates all the items of a given collection in a single read transaction, so the handler sees a consistent snapshot.
+// items are loaded a page at a time, where every page is loaded by seeking right after the last key of the previous page
+func (b *BadgerDb) GetAllPaged(prefix []byte, pageSize int, handler func(int, basedb.Obj) error) error {
+	if pageSize <= 0 {
+		return errors.Errorf("invalid page size %d", pageSize)
+	}
+	return b.db.View(func(txn *badger.Txn) error {
+		i := 0
+		seek := prefix
+		for {
+			page, err := b.loadPage(txn, prefix, seek, pageSize)
+			if err != nil {
+				return err
+			}
+			for _, obj := range page {
+				if err := handler(i, obj); err != nil {
+					return err
+				}
+				i++
+			}
+			if len(page) < pageSize {
+				return nil
+			}
+			// the smallest key that is bigger than the last key of the page
+			last := page[len(page)-1].Key
+			seek = make([]byte, 0, len(prefix)+len(last)+1)
+			seek = append(append(append(seek, prefix...), last...), 0)
+		}
+	})
+}
+
+// loadPage returns up to pageSize items of a given collection, starting at the given key
+func (b *BadgerDb) loadPage(txn *badger.Txn, prefix, seek []byte, pageSize int) ([]basedb.Obj, error) {
+	opt := badger.DefaultIteratorOptions
+	opt.Prefix = prefix
+	opt.PrefetchValues = false
+	it := txn.NewIterator(opt)
+	defer it.Close()
+
+	page := make([]basedb.Obj, 0, pageSize)
+	for it.Seek(seek); it.ValidForPrefix(prefix) && len(page) < pageSize; it.Next() {
+		item := it.Item()
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to copy value")
+		}
+		page = append(page, basedb.Obj{
+			Key:   bytes.TrimPrefix(item.KeyCopy(nil), prefix),
+			Value: val,
+		})
+	}
+	return page, nil
+}
+
 // CountByCollection return the object count for all keys under specified prefix(bucket)
 func (b *BadgerDb) CountByCollection(prefix []byte) (int64, error) {
 	var res int64
@@ -196,11 +327,157 @@ func (b *BadgerDb) CountByCollection(prefix []byte) (int64, error) {
 	return res, err
 }
 
+// SizeByCollection returns the approximate size in bytes of all items in a collection
+func (b *BadgerDb) SizeByCollection(prefix []byte) (int64, error) {
+	var res int64
+	err := b.db.View(func(txn *badger.Txn) error {
+		opt := badger.DefaultIteratorOptions
+		opt.Prefix = prefix
+		opt.PrefetchValues = false
+		it := txn.NewIterator(opt)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			res += it.Item().EstimatedSize()
+		}
+		return nil
+	})
+	return res, err
+}
+
 // RemoveAllByCollection cleans all items in a collection
 func (b *BadgerDb) RemoveAllByCollection(prefix []byte) error {
+	if b.readOnly {
+		return ErrReadOnly
+	}
 	return b.db.DropPrefix(prefix)
 }
 
+// Backup writes a full backup of the db into the given writer, using badger streaming backup
+func (b *BadgerDb) Backup(w io.Writer) error {
+	if _, err := b.db.Backup(w, 0); err != nil {
+		return errors.Wrap(err, "failed to backup badger")
+	}
+	return nil
+}
+
+// Restore replaces all existing data with the backup from the given reader.
+// the backup is loaded and validated in a staging db first, which then replaces the current db,
+// so a failed restore leaves the existing data untouched.
+// the db must not be used by other goroutines while restoring
+func (b *BadgerDb) Restore(r io.Reader) error {
+	if b.readOnly {
+		return ErrReadOnly
+	}
+	stagingOpt := b.opt
+	if !b.opt.InMemory {
+		// the staging dir is created next to the db, so it can be renamed into place
+		dir := filepath.Clean(b.opt.Dir)
+		stagingDir, err := ioutil.TempDir(filepath.Dir(dir), filepath.Base(dir)+".restore-")
+		if err != nil {
+			return errors.Wrap(err, "failed to create staging dir")
+		}
+		defer func() {
+			_ = os.RemoveAll(stagingDir)
+		}()
+		stagingOpt.Dir = stagingDir
+		stagingOpt.ValueDir = stagingDir
+	}
+	staging, err := badger.Open(stagingOpt)
+	if err != nil {
+		return errors.Wrap(err, "failed to open staging db")
+	}
+	if err := loadAndValidate(staging, r); err != nil {
+		_ = staging.Close()
+		return err
+	}
+	if b.opt.InMemory {
+		if err := b.db.Close(); err != nil {
+			_ = staging.Close()
+			return errors.Wrap(err, "failed to close db")
+		}
+		b.db = staging
+		return nil
+	}
+	if err := staging.Close(); err != nil {
+		return errors.Wrap(err, "failed to close staging db")
+	}
+	return b.swapDir(stagingOpt.Dir)
+}
+
+// loadAndValidate loads the backup into the given db and makes sure all of its values are readable
+func loadAndValidate(db *badger.DB, r io.Reader) (err error) {
+	// badger might panic on malformed input
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = errors.Errorf("failed to load backup: %v", rec)
+		}
+	}()
+	if err := db.Load(r, restoreMaxPendingWrites); err != nil {
+		return errors.Wrap(err, "failed to load backup")
+	}
+	err = db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			if err := it.Item().Value(func([]byte) error { return nil }); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "invalid backup")
+	}
+	return nil
+}
+
+// swapDir replaces the db directory with the given one and reopens the db.
+// the previous directory is restored if the new db can't be opened
+func (b *BadgerDb) swapDir(dir string) error {
+	if err := b.db.Close(); err != nil {
+		return errors.Wrap(err, "failed to close db")
+	}
+	dbDir := filepath.Clean(b.opt.Dir)
+	prevDir := dbDir + ".prev"
+	if err := os.RemoveAll(prevDir); err != nil {
+		return errors.Wrap(err, "failed to remove previous db dir")
+	}
+	reopen := func() error {
+		db, err := badger.Open(b.opt)
+		if err != nil {
+			return err
+		}
+		b.db = db
+		return nil
+	}
+	if err := os.Rename(dbDir, prevDir); err != nil {
+		if rerr := reopen(); rerr != nil {
+			b.logger.Error("failed to reopen db", zap.Error(rerr))
+		}
+		return errors.Wrap(err, "failed to move db dir")
+	}
+	if err := os.Rename(dir, dbDir); err != nil {
+		if rerr := os.Rename(prevDir, dbDir); rerr != nil {
+			return errors.Wrapf(err, "failed to move restored db dir, previous db is at %s", prevDir)
+		}
+		if rerr := reopen(); rerr != nil {
+			b.logger.Error("failed to reopen db", zap.Error(rerr))
+		}
+		return errors.Wrap(err, "failed to move restored db dir")
+	}
+	if err := reopen(); err != nil {
+		_ = os.RemoveAll(dbDir)
+		if rerr := os.Rename(prevDir, dbDir); rerr != nil {
+			return errors.Wrapf(err, "failed to open restored db, previous db is at %s", prevDir)
+		}
+		if rerr := reopen(); rerr != nil {
+			b.logger.Error("failed to reopen db", zap.Error(rerr))
+		}
+		return errors.Wrap(err, "failed to open restored db")
+	}
+	return os.RemoveAll(prevDir)
+}
+
 // Close close db
 func (b *BadgerDb) Close() {
 	if err := b.db.Close(); err != nil {
@@ -238,6 +515,9 @@ func (b *BadgerDb) listRawKeys(prefix []byte, txn *badger.Txn) [][]byte {
 // Update is a gateway to badger db Update function
 // creating and managing a read-write transaction
 func (b *BadgerDb) Update(fn func(basedb.Txn) error) error {
+	if b.readOnly {
+		return ErrReadOnly
+	}
 	return b.db.Update(func(txn *badger.Txn) error {
 		return fn(&badgerTxn{txn: txn})
 	})
@@ -6,13 +6,250 @@ import (
 	"encoding/binary"
 	"fmt"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 	"time"
 )
 
+func TestBadgerDBLocked(t *testing.T) {
+	options := basedb.Options{
+		Type:   "badger-db",
+		Logger: zap.L(),
+		Path:   t.TempDir(),
+	}
+
+	db, err := New(options)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = New(options)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrDBLocked))
+	require.Contains(t, err.Error(), options.Path)
+}
+
+func TestBadgerReadOnly(t *testing.T) {
+	options := basedb.Options{
+		Type:   "badger-db",
+		Logger: zap.L(),
+		Path:   t.TempDir(),
+	}
+	db, err := New(options)
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+	db.Close()
+
+	options.ReadOnly = true
+	db, err = New(options)
+	require.NoError(t, err)
+	defer db.Close()
+
+	obj, found, err := db.Get([]byte("prefix"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+
+	require.True(t, errors.Is(db.Set([]byte("prefix"), []byte("key"), []byte("value2")), ErrReadOnly))
+	require.True(t, errors.Is(db.Delete([]byte("prefix"), []byte("key")), ErrReadOnly))
+	require.True(t, errors.Is(db.RemoveAllByCollection([]byte("prefix")), ErrReadOnly))
+	require.True(t, errors.Is(db.Update(func(txn basedb.Txn) error {
+		return txn.Set([]byte("prefix"), []byte("key"), []byte("value2"))
+	}), ErrReadOnly))
+
+	t.Run("missing db", func(t *testing.T) {
+		_, err := New(basedb.Options{
+			Type:     "badger-db",
+			Logger:   zap.L(),
+			Path:     fmt.Sprintf("%s/missing", t.TempDir()),
+			ReadOnly: true,
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestBadgerGetAllPaged(t *testing.T) {
+	db, err := New(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	prefix := []byte("prefix")
+	total := 1000
+	require.NoError(t, db.SetMany(prefix, total, func(i int) (basedb.Obj, error) {
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, uint64(i))
+		return basedb.Obj{Key: key, Value: []byte(fmt.Sprintf("value-%d", i))}, nil
+	}))
+	// items under another prefix are not included
+	require.NoError(t, db.Set([]byte("other"), []byte("key"), []byte("value")))
+
+	var seen []uint64
+	err = db.GetAllPaged(prefix, 150, func(i int, obj basedb.Obj) error {
+		key := binary.BigEndian.Uint64(obj.Key)
+		require.Equal(t, len(seen), i)
+		require.Equal(t, fmt.Sprintf("value-%d", key), string(obj.Value))
+		seen = append(seen, key)
+		if i == 0 {
+			// items that are saved during the iteration are not part of its snapshot
+			newKey := make([]byte, 8)
+			binary.BigEndian.PutUint64(newKey, uint64(total))
+			require.NoError(t, db.Set(prefix, newKey, []byte("new value")))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, seen, total)
+	for i, key := range seen {
+		require.Equal(t, uint64(i), key)
+	}
+
+	// a single page that holds the whole collection
+	count := 0
+	require.NoError(t, db.GetAllPaged(prefix, total+1, func(i int, obj basedb.Obj) error {
+		count++
+		return nil
+	}))
+	require.Equal(t, total+1, count)
+
+	require.Error(t, db.GetAllPaged(prefix, 0, func(i int, obj basedb.Obj) error {
+		return nil
+	}))
+}
+
+func TestBadgerAtomicWrites(t *testing.T) {
+	db, err := New(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	prefix := []byte("prefix")
+
+	t.Run("set items", func(t *testing.T) {
+		require.NoError(t, db.SetItems([]basedb.Item{
+			{Prefix: prefix, Key: []byte("key1"), Value: []byte("value1")},
+			{Prefix: []byte("other"), Key: []byte("key2"), Value: []byte("value2")},
+		}))
+		_, found, err := db.Get(prefix, []byte("key1"))
+		require.NoError(t, err)
+		require.True(t, found)
+		_, found, err = db.Get([]byte("other"), []byte("key2"))
+		require.NoError(t, err)
+		require.True(t, found)
+	})
+
+	t.Run("set items fails in the middle", func(t *testing.T) {
+		err := db.SetItems([]basedb.Item{
+			{Prefix: prefix, Key: []byte("key3"), Value: []byte("value3")},
+			{Prefix: nil, Key: nil, Value: []byte("invalid")}, // empty keys are not allowed
+			{Prefix: prefix, Key: []byte("key4"), Value: []byte("value4")},
+		})
+		require.Error(t, err)
+		_, found, err := db.Get(prefix, []byte("key3"))
+		require.NoError(t, err)
+		require.False(t, found)
+		_, found, err = db.Get(prefix, []byte("key4"))
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+
+	t.Run("update fails in the middle", func(t *testing.T) {
+		err := db.Update(func(txn basedb.Txn) error {
+			if err := txn.Set(prefix, []byte("key5"), []byte("value5")); err != nil {
+				return err
+			}
+			if err := txn.Delete(prefix, []byte("key1")); err != nil {
+				return err
+			}
+			return errors.New("forced error")
+		})
+		require.EqualError(t, err, "forced error")
+		_, found, err := db.Get(prefix, []byte("key5"))
+		require.NoError(t, err)
+		require.False(t, found)
+		obj, found, err := db.Get(prefix, []byte("key1"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value1"), obj.Value)
+	})
+}
+
+func TestBadgerRestore(t *testing.T) {
+	prefix := []byte("prefix")
+
+	src, err := New(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+	defer src.Close()
+	require.NoError(t, src.Set(prefix, []byte("key1"), []byte("value1")))
+	var backup bytes.Buffer
+	require.NoError(t, src.Backup(&backup))
+
+	for _, dbType := range []string{"badger-db", "badger-memory"} {
+		dbType := dbType
+		t.Run(dbType, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "db")
+			db, err := New(basedb.Options{
+				Type:   dbType,
+				Logger: zap.L(),
+				Path:   path,
+			})
+			require.NoError(t, err)
+			defer db.Close()
+			require.NoError(t, db.Set(prefix, []byte("key2"), []byte("value2")))
+
+			// an invalid or truncated backup leaves the existing data untouched
+			require.Error(t, db.Restore(bytes.NewReader([]byte("invalid backup"))))
+			require.Error(t, db.Restore(bytes.NewReader(backup.Bytes()[:backup.Len()-1])))
+			obj, found, err := db.Get(prefix, []byte("key2"))
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, []byte("value2"), obj.Value)
+
+			// existing data is replaced by the backup
+			require.NoError(t, db.Restore(bytes.NewReader(backup.Bytes())))
+			obj, found, err = db.Get(prefix, []byte("key1"))
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, []byte("value1"), obj.Value)
+			_, found, err = db.Get(prefix, []byte("key2"))
+			require.NoError(t, err)
+			require.False(t, found)
+
+			// the restored db is writable and no staging dirs are left behind
+			require.NoError(t, db.Set(prefix, []byte("key3"), []byte("value3")))
+			if dbType == "badger-db" {
+				entries, err := ioutil.ReadDir(dir)
+				require.NoError(t, err)
+				require.Len(t, entries, 1)
+				require.Equal(t, "db", entries[0].Name())
+			}
+		})
+	}
+}
+
+func TestValidateDBPath(t *testing.T) {
+	t.Run("creates missing dir", func(t *testing.T) {
+		path := fmt.Sprintf("%s/nested/db", t.TempDir())
+		require.NoError(t, validateDBPath(path))
+	})
+
+	t.Run("empty path", func(t *testing.T) {
+		require.EqualError(t, validateDBPath(""), "db path is empty")
+	})
+}
+
 func TestBadgerEndToEnd(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
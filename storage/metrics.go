@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	metricsCollectionKeys = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:storage:collection_keys",
+		Help: "Count of keys in a storage collection",
+	}, []string{"collection"})
+	metricsCollectionSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:storage:collection_size_bytes",
+		Help: "Approximate size in bytes of a storage collection",
+	}, []string{"collection"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsCollectionKeys); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsCollectionSize); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
+
+// Collection is a named group of storage prefixes that is reported as a single collection
+type Collection struct {
+	Name     string
+	Prefixes [][]byte
+}
+
+// ReportCollections computes the keys count and the approximate size of the given collections
+func ReportCollections(logger *zap.Logger, db basedb.IDb, collections []Collection) {
+	for _, c := range collections {
+		var keys, size int64
+		for _, prefix := range c.Prefixes {
+			n, err := db.CountByCollection(prefix)
+			if err != nil {
+				logger.Warn("could not count collection keys", zap.String("collection", c.Name), zap.Error(err))
+				continue
+			}
+			s, err := db.SizeByCollection(prefix)
+			if err != nil {
+				logger.Warn("could not get collection size", zap.String("collection", c.Name), zap.Error(err))
+				continue
+			}
+			keys += n
+			size += s
+		}
+		metricsCollectionKeys.WithLabelValues(c.Name).Set(float64(keys))
+		metricsCollectionSize.WithLabelValues(c.Name).Set(float64(size))
+		logger.Debug("reported storage collection", zap.String("collection", c.Name),
+			zap.Int64("keys", keys), zap.Int64("size", size))
+	}
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReportCollections(t *testing.T) {
+	db := newTestDB(t)
+	defer db.Close()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, db.Set([]byte("decided-"), []byte(fmt.Sprintf("key%d", i)), make([]byte, 100)))
+	}
+	require.NoError(t, db.Set([]byte("highest_att-"), []byte("key"), make([]byte, 10)))
+	require.NoError(t, db.Set([]byte("highest_prop-"), []byte("key"), make([]byte, 10)))
+
+	ReportCollections(zap.L(), db, []Collection{
+		{Name: "test_decided", Prefixes: [][]byte{[]byte("decided-")}},
+		{Name: "test_slashing", Prefixes: [][]byte{[]byte("highest_att-"), []byte("highest_prop-")}},
+		{Name: "test_empty", Prefixes: [][]byte{[]byte("empty-")}},
+	})
+
+	require.Equal(t, float64(5), testutil.ToFloat64(metricsCollectionKeys.WithLabelValues("test_decided")))
+	require.GreaterOrEqual(t, testutil.ToFloat64(metricsCollectionSize.WithLabelValues("test_decided")), float64(500))
+	require.Equal(t, float64(2), testutil.ToFloat64(metricsCollectionKeys.WithLabelValues("test_slashing")))
+	require.Greater(t, testutil.ToFloat64(metricsCollectionSize.WithLabelValues("test_slashing")), float64(0))
+	require.Equal(t, float64(0), testutil.ToFloat64(metricsCollectionKeys.WithLabelValues("test_empty")))
+	require.Equal(t, float64(0), testutil.ToFloat64(metricsCollectionSize.WithLabelValues("test_empty")))
+}
@@ -0,0 +1,17 @@
+package commons
+
+import "fmt"
+
+// GraffitiSize is the size of a beacon block graffiti
+const GraffitiSize = 32
+
+// BuildGraffiti returns the graffiti to use in beacon blocks.
+// if withVersion is set, the node version is appended and the result is truncated / padded to GraffitiSize bytes
+func BuildGraffiti(graffiti string, withVersion bool) []byte {
+	if !withVersion {
+		return []byte(graffiti)
+	}
+	res := make([]byte, GraffitiSize)
+	copy(res, fmt.Sprintf("%s %s", graffiti, GetNodeVersion()))
+	return res
+}
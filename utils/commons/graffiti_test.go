@@ -0,0 +1,30 @@
+package commons
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGraffiti(t *testing.T) {
+	defer SetBuildData(appName, version)
+
+	t.Run("static", func(t *testing.T) {
+		require.Equal(t, []byte("SSV.Network"), BuildGraffiti("SSV.Network", false))
+	})
+
+	t.Run("with version", func(t *testing.T) {
+		SetBuildData("SSV-Node", "v0.3.1")
+		graffiti := BuildGraffiti("SSV.Network", true)
+		require.Len(t, graffiti, GraffitiSize)
+		require.True(t, bytes.HasPrefix(graffiti, []byte("SSV.Network v0.3.1")))
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		SetBuildData("SSV-Node", "v0.3.1-rc.1-25-gabcdef0123")
+		graffiti := BuildGraffiti("SSV.Network", true)
+		require.Len(t, graffiti, GraffitiSize)
+		require.Equal(t, []byte("SSV.Network v0.3.1-rc.1-25-gabcd"), graffiti)
+	})
+}
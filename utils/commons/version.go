@@ -0,0 +1,45 @@
+package commons
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Version is a parsed node version, in the format of vMAJOR.MINOR.PATCH
+type Version [3]uint64
+
+// ParseVersion parses the given version string (e.g. v0.3.1), a suffix such as -rc1 or -3-gd1e2f3 is ignored
+func ParseVersion(v string) (Version, error) {
+	var res Version
+	raw := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(raw, "-+"); i >= 0 {
+		raw = raw[:i]
+	}
+	parts := strings.Split(raw, ".")
+	if len(parts) != len(res) {
+		return res, errors.Errorf("invalid version '%s'", v)
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return res, errors.Wrapf(err, "invalid version '%s'", v)
+		}
+		res[i] = n
+	}
+	return res, nil
+}
+
+// Compare returns -1 if v is older than other, 1 if newer, or 0 if equal
+func (v Version) Compare(other Version) int {
+	for i := range v {
+		if v[i] < other[i] {
+			return -1
+		}
+		if v[i] > other[i] {
+			return 1
+		}
+	}
+	return 0
+}
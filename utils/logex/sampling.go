@@ -0,0 +1,117 @@
+package logex
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// samplerCounters is the amount of counters that are shared by sampled messages
+const samplerCounters = 1024
+
+// SamplingConfig represents the configuration of sampling for high-frequency logs.
+// within each tick, the first Initial entries of a message are logged and then every Thereafter-th entry.
+// sampling is disabled when Initial is not positive
+type SamplingConfig struct {
+	Tick       time.Duration
+	Initial    int
+	Thereafter int
+}
+
+var samplerLock sync.RWMutex
+var sampler *logSampler
+
+// SetSampling sets the sampling configuration of loggers that are created with Sampled, nil disables sampling
+func SetSampling(cfg *SamplingConfig) {
+	samplerLock.Lock()
+	defer samplerLock.Unlock()
+
+	if cfg == nil || cfg.Initial <= 0 || cfg.Tick <= 0 {
+		sampler = nil
+		return
+	}
+	sampler = newLogSampler(*cfg)
+}
+
+// Sampled returns a logger that samples the entries of the given logger according to the configured sampling,
+// it should be used for high-frequency logs. the counters are shared across all sampled loggers,
+// therefore it is cheap to create a sampled logger per component
+func Sampled(logger *zap.Logger) *zap.Logger {
+	samplerLock.RLock()
+	s := sampler
+	samplerLock.RUnlock()
+
+	if s == nil {
+		return logger
+	}
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &sampledCore{Core: core, sampler: s}
+	}))
+}
+
+type counter struct {
+	resetAt int64
+	count   uint64
+}
+
+// incCheckReset increments the counter, it is reset once the tick is over
+func (c *counter) incCheckReset(t time.Time, tick time.Duration) uint64 {
+	tn := t.UnixNano()
+	resetAt := atomic.LoadInt64(&c.resetAt)
+	if resetAt > tn {
+		return atomic.AddUint64(&c.count, 1)
+	}
+	atomic.StoreUint64(&c.count, 1)
+	if !atomic.CompareAndSwapInt64(&c.resetAt, resetAt, tn+tick.Nanoseconds()) {
+		// another goroutine has reset the counter
+		return atomic.AddUint64(&c.count, 1)
+	}
+	return 1
+}
+
+// logSampler counts entries by level and message
+type logSampler struct {
+	cfg      SamplingConfig
+	counters [samplerCounters]counter
+}
+
+func newLogSampler(cfg SamplingConfig) *logSampler {
+	return &logSampler{cfg: cfg}
+}
+
+// allow returns true if the given entry should be logged
+func (s *logSampler) allow(ent zapcore.Entry) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte{byte(ent.Level)})
+	_, _ = h.Write([]byte(ent.Message))
+	n := s.counters[h.Sum32()%samplerCounters].incCheckReset(ent.Time, s.cfg.Tick)
+	initial := uint64(s.cfg.Initial)
+	if n <= initial {
+		return true
+	}
+	return s.cfg.Thereafter > 0 && (n-initial)%uint64(s.cfg.Thereafter) == 0
+}
+
+// sampledCore is a zapcore.Core that drops entries according to the shared sampler
+type sampledCore struct {
+	zapcore.Core
+	sampler *logSampler
+}
+
+func (c *sampledCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sampledCore{Core: c.Core.With(fields), sampler: c.sampler}
+}
+
+func (c *sampledCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	if !c.sampler.allow(ent) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
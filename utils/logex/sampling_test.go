@@ -0,0 +1,44 @@
+package logex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSampled(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	t.Run("disabled", func(t *testing.T) {
+		SetSampling(nil)
+		require.Equal(t, logger, Sampled(logger))
+		SetSampling(&SamplingConfig{Tick: time.Minute, Initial: 0, Thereafter: 10})
+		require.Equal(t, logger, Sampled(logger))
+	})
+
+	t.Run("burst", func(t *testing.T) {
+		SetSampling(&SamplingConfig{Tick: time.Minute, Initial: 5, Thereafter: 10})
+		defer SetSampling(nil)
+		defer logs.TakeAll()
+
+		sampled := Sampled(logger.With(zap.String("who", "test")))
+		for i := 0; i < 100; i++ {
+			sampled.Debug("got message, add to queue", zap.Int("i", i))
+		}
+		// 5 initial entries and then every 10th of the remaining 95
+		require.Equal(t, 14, logs.Len())
+
+		sampled.Debug("another message")
+		require.Equal(t, 15, logs.Len())
+
+		// the original logger is not sampled
+		for i := 0; i < 100; i++ {
+			logger.Debug("got message, add to queue")
+		}
+		require.Equal(t, 115, logs.Len())
+	})
+}
@@ -51,6 +51,15 @@ func DecodeKey(sk *rsa.PrivateKey, hashBase64 string) (string, error) {
 	return string(decryptedKey), nil
 }
 
+// EncodeKey with public key, return the encrypted key (base64)
+func EncodeKey(pk *rsa.PublicKey, key string) (string, error) {
+	encryptedKey, err := rsa.EncryptPKCS1v15(rand.Reader, pk, []byte(key))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to encrypt key")
+	}
+	return base64.StdEncoding.EncodeToString(encryptedKey), nil
+}
+
 // ConvertPemToPrivateKey return rsa private key from secret key
 func ConvertPemToPrivateKey(skPem string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode([]byte(skPem))
@@ -72,6 +81,38 @@ func ConvertPemToPrivateKey(skPem string) (*rsa.PrivateKey, error) {
 	return parsedSk, nil
 }
 
+// EncryptPrivateKey encrypts the given private key (pem) with the given password
+func EncryptPrivateKey(skPem []byte, password string) ([]byte, error) {
+	block, _ := pem.Decode(skPem)
+	if block == nil {
+		return nil, errors.New("Failed to decode private key")
+	}
+	// TODO: resolve deprecation https://github.com/golang/go/issues/8860
+	encrypted, err := x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte(password), x509.PEMCipherAES256) //nolint
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to encrypt private key")
+	}
+	return pem.EncodeToMemory(encrypted), nil
+}
+
+// DecryptPrivateKey return rsa private key from a password encrypted secret key (pem)
+func DecryptPrivateKey(encryptedSkPem []byte, password string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(encryptedSkPem)
+	if block == nil {
+		return nil, errors.New("Failed to decode private key")
+	}
+	// TODO: resolve deprecation https://github.com/golang/go/issues/8860
+	b, err := x509.DecryptPEMBlock(block, []byte(password)) //nolint
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decrypt private key")
+	}
+	parsedSk, err := x509.ParsePKCS1PrivateKey(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse private key")
+	}
+	return parsedSk, nil
+}
+
 // PrivateKeyToByte converts privateKey to []byte
 func PrivateKeyToByte(sk *rsa.PrivateKey) []byte {
 	return pem.EncodeToMemory(
@@ -42,3 +42,21 @@ func TestPrivateKeyToByte(t *testing.T) {
 	require.NotNil(t, b)
 	require.Greater(t, len(b), 1024)
 }
+
+func TestEncryptPrivateKey(t *testing.T) {
+	_, skByte, err := GenerateKeys()
+	require.NoError(t, err)
+	sk, err := ConvertPemToPrivateKey(string(skByte))
+	require.NoError(t, err)
+
+	encrypted, err := EncryptPrivateKey(skByte, "123456")
+	require.NoError(t, err)
+	require.NotEqual(t, skByte, encrypted)
+
+	decrypted, err := DecryptPrivateKey(encrypted, "123456")
+	require.NoError(t, err)
+	require.True(t, sk.Equal(decrypted))
+
+	_, err = DecryptPrivateKey(encrypted, "wrong")
+	require.Error(t, err)
+}